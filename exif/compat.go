@@ -0,0 +1,34 @@
+// Package exif is the v1 API of package github.com/rwcarlsen/goexif/v2/exif,
+// which new code should import instead.  Its types are aliases of the v2
+// types and its functions call the v2 functions, so values pass freely
+// between code using either version while it migrates.  See the v2 package
+// for the documentation.
+package exif
+
+//go:generate go run ../internal/gencompat -skip Decode,RegisterFields github.com/rwcarlsen/goexif/v2/exif
+
+import (
+	"io"
+
+	v2 "github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// Decode parses the EXIF data of r like the v2 function with no options.
+func Decode(r io.Reader) (*Exif, error) {
+	return v2.Decode(r)
+}
+
+// DecodeWith decodes the EXIF data of r like Decode, with a Decoder
+// configured by opts.  It is the v2 Decode.
+func DecodeWith(r io.Reader, opts ...Option) (*Exif, error) {
+	return v2.Decode(r, opts...)
+}
+
+// RegisterFields registers the field names of the makernote (or other
+// vendor) namespace ns like the v2 function, but panics where it returns an
+// error.
+func RegisterFields(ns string, fields map[uint16]FieldName) {
+	if err := v2.RegisterFields(ns, fields); err != nil {
+		panic(err)
+	}
+}
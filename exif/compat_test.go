@@ -0,0 +1,44 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v2 "github.com/rwcarlsen/goexif/v2/exif"
+)
+
+func TestCompat(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "v2", "exif", "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// v1 values are v2 values.
+	var x *v2.Exif
+	if x, err = Decode(f); err != nil {
+		t.Fatal(err)
+	}
+	if tag, err := x.Get(Model); err != nil || tag.String() != `"NIKON D2H"` {
+		t.Errorf("Model = %v, %v", tag, err)
+	}
+	f.Seek(0, 0)
+	if x, err = DecodeWith(f, WithKeepOnly(Model)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Get(Make); err == nil {
+		t.Error("DecodeWith ignored its options")
+	}
+	if ErrNoExif != v2.ErrNoExif {
+		t.Error("ErrNoExif differs from the v2 error")
+	}
+
+	RegisterFields("CompatVendor", map[uint16]FieldName{0x0001: "LensType"})
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterFields of a registered namespace did not panic")
+		}
+	}()
+	RegisterFields("CompatVendor", nil)
+}
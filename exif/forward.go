@@ -0,0 +1,499 @@
+// Code generated by gencompat; DO NOT EDIT.
+
+package exif
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rwcarlsen/goexif/tiff"
+	v2 "github.com/rwcarlsen/goexif/v2/exif"
+)
+
+type (
+	AIDetection        = v2.AIDetection
+	AIVerdict          = v2.AIVerdict
+	BatchDecoder       = v2.BatchDecoder
+	BatchResult        = v2.BatchResult
+	Burst              = v2.Burst
+	CFAColor           = v2.CFAColor
+	CFALayout          = v2.CFALayout
+	CTMDRecord         = v2.CTMDRecord
+	Carved             = v2.Carved
+	ChangeFunc         = v2.ChangeFunc
+	Chromaticity       = v2.Chromaticity
+	Classification     = v2.Classification
+	ColorSpaceID       = v2.ColorSpaceID
+	Container          = v2.Container
+	CopyrightNotice    = v2.CopyrightNotice
+	Decoder            = v2.Decoder
+	DuplicatePolicy    = v2.DuplicatePolicy
+	EditError          = v2.EditError
+	Exif               = v2.Exif
+	ExifTx             = v2.ExifTx
+	Exposure           = v2.Exposure
+	FieldInfo          = v2.FieldInfo
+	FieldName          = v2.FieldName
+	Filter             = v2.Filter
+	GPSFix             = v2.GPSFix
+	ImageSource        = v2.ImageSource
+	IndexEntry         = v2.IndexEntry
+	JPEGFingerprint    = v2.JPEGFingerprint
+	JSONEncoder        = v2.JSONEncoder
+	Label              = v2.Label
+	LensCorrection     = v2.LensCorrection
+	LensCorrectionKind = v2.LensCorrectionKind
+	MakerNoteParser    = v2.MakerNoteParser
+	Opcode             = v2.Opcode
+	Option             = v2.Option
+	Parser             = v2.Parser
+	Policy             = v2.Policy
+	Primaries          = v2.Primaries
+	RangeFunc          = v2.RangeFunc
+	RangeReader        = v2.RangeReader
+	RangeStats         = v2.RangeStats
+	RationalFormat     = v2.RationalFormat
+	ReadWriterAt       = v2.ReadWriterAt
+	Rights             = v2.Rights
+	Signer             = v2.Signer
+	Stage              = v2.Stage
+	StampVars          = v2.StampVars
+	Stats              = v2.Stats
+	TagNotPresentError = v2.TagNotPresentError
+	TamperFinding      = v2.TamperFinding
+	TamperReport       = v2.TamperReport
+	Template           = v2.Template
+	TimeStamp          = v2.TimeStamp
+	TimelineEntry      = v2.TimelineEntry
+	Times              = v2.Times
+	Trailer            = v2.Trailer
+	TrailerKind        = v2.TrailerKind
+	Verifier           = v2.Verifier
+	Violation          = v2.Violation
+	Walker             = v2.Walker
+	Warning            = v2.Warning
+	WhiteBalanceMode   = v2.WhiteBalanceMode
+)
+
+const (
+	AIDeclared                       = v2.AIDeclared
+	AILikely                         = v2.AILikely
+	AINone                           = v2.AINone
+	AIPossible                       = v2.AIPossible
+	Acceleration                     = v2.Acceleration
+	ApertureValue                    = v2.ApertureValue
+	Artist                           = v2.Artist
+	BitsPerSample                    = v2.BitsPerSample
+	BrightnessValue                  = v2.BrightnessValue
+	CFABlue                          = v2.CFABlue
+	CFACyan                          = v2.CFACyan
+	CFAGreen                         = v2.CFAGreen
+	CFAMagenta                       = v2.CFAMagenta
+	CFAPattern                       = v2.CFAPattern
+	CFAPattern2                      = v2.CFAPattern2
+	CFARed                           = v2.CFARed
+	CFARepeatPatternDim              = v2.CFARepeatPatternDim
+	CFAWhite                         = v2.CFAWhite
+	CFAYellow                        = v2.CFAYellow
+	CameraElevationAngle             = v2.CameraElevationAngle
+	ColorAdobeRGB                    = v2.ColorAdobeRGB
+	ColorSRGB                        = v2.ColorSRGB
+	ColorSpace                       = v2.ColorSpace
+	ColorUncalibrated                = v2.ColorUncalibrated
+	ComponentsConfiguration          = v2.ComponentsConfiguration
+	CompressedBitsPerPixel           = v2.CompressedBitsPerPixel
+	Compression                      = v2.Compression
+	ContainerAVI                     = v2.ContainerAVI
+	ContainerCR3                     = v2.ContainerCR3
+	ContainerJPEG                    = v2.ContainerJPEG
+	ContainerPSD                     = v2.ContainerPSD
+	ContainerRawExif                 = v2.ContainerRawExif
+	ContainerTIFF                    = v2.ContainerTIFF
+	ContainerUnknown                 = v2.ContainerUnknown
+	ContainerVideo                   = v2.ContainerVideo
+	Contrast                         = v2.Contrast
+	Copyright                        = v2.Copyright
+	CustomRendered                   = v2.CustomRendered
+	DateTime                         = v2.DateTime
+	DateTimeDigitized                = v2.DateTimeDigitized
+	DateTimeOriginal                 = v2.DateTimeOriginal
+	DefaultWindow                    = v2.DefaultWindow
+	DeviceSettingDescription         = v2.DeviceSettingDescription
+	DigitalZoomRatio                 = v2.DigitalZoomRatio
+	DuplicatesFirst                  = v2.DuplicatesFirst
+	DuplicatesKeepAll                = v2.DuplicatesKeepAll
+	DuplicatesLast                   = v2.DuplicatesLast
+	ExifIFDPointer                   = v2.ExifIFDPointer
+	ExifIFDPointerID                 = v2.ExifIFDPointerID
+	ExifVersion                      = v2.ExifVersion
+	ExposureBiasValue                = v2.ExposureBiasValue
+	ExposureIndex                    = v2.ExposureIndex
+	ExposureMode                     = v2.ExposureMode
+	ExposureProgram                  = v2.ExposureProgram
+	ExposureTime                     = v2.ExposureTime
+	FNumber                          = v2.FNumber
+	FileSource                       = v2.FileSource
+	FixVignetteRadial                = v2.FixVignetteRadial
+	Flash                            = v2.Flash
+	FlashEnergy                      = v2.FlashEnergy
+	FlashpixVersion                  = v2.FlashpixVersion
+	FocalLength                      = v2.FocalLength
+	FocalLengthIn35mmFilm            = v2.FocalLengthIn35mmFilm
+	FocalPlaneResolutionUnit         = v2.FocalPlaneResolutionUnit
+	FocalPlaneXResolution            = v2.FocalPlaneXResolution
+	FocalPlaneYResolution            = v2.FocalPlaneYResolution
+	GPSAPP1Size                      = v2.GPSAPP1Size
+	GPSAltitude                      = v2.GPSAltitude
+	GPSAltitudeRef                   = v2.GPSAltitudeRef
+	GPSAreaInformation               = v2.GPSAreaInformation
+	GPSDOP                           = v2.GPSDOP
+	GPSDateStamp                     = v2.GPSDateStamp
+	GPSDestBearing                   = v2.GPSDestBearing
+	GPSDestBearingRef                = v2.GPSDestBearingRef
+	GPSDestDistance                  = v2.GPSDestDistance
+	GPSDestDistanceRef               = v2.GPSDestDistanceRef
+	GPSDestLatitude                  = v2.GPSDestLatitude
+	GPSDestLatitudeRef               = v2.GPSDestLatitudeRef
+	GPSDestLongitude                 = v2.GPSDestLongitude
+	GPSDestLongitudeRef              = v2.GPSDestLongitudeRef
+	GPSDifferential                  = v2.GPSDifferential
+	GPSIFDPointerID                  = v2.GPSIFDPointerID
+	GPSImgDirection                  = v2.GPSImgDirection
+	GPSImgDirectionRef               = v2.GPSImgDirectionRef
+	GPSInfoIFDPointer                = v2.GPSInfoIFDPointer
+	GPSLatitude                      = v2.GPSLatitude
+	GPSLatitudeRef                   = v2.GPSLatitudeRef
+	GPSLongitude                     = v2.GPSLongitude
+	GPSLongitudeRef                  = v2.GPSLongitudeRef
+	GPSMapDatum                      = v2.GPSMapDatum
+	GPSMeasureMode                   = v2.GPSMeasureMode
+	GPSProcessingMethod              = v2.GPSProcessingMethod
+	GPSSatelites                     = v2.GPSSatelites
+	GPSSpeed                         = v2.GPSSpeed
+	GPSSpeedRef                      = v2.GPSSpeedRef
+	GPSStatus                        = v2.GPSStatus
+	GPSTimeStamp                     = v2.GPSTimeStamp
+	GPSTrack                         = v2.GPSTrack
+	GPSTrackRef                      = v2.GPSTrackRef
+	GPSVersionID                     = v2.GPSVersionID
+	GainControl                      = v2.GainControl
+	Gamma                            = v2.Gamma
+	GroupExif                        = v2.GroupExif
+	GroupGPS                         = v2.GroupGPS
+	GroupIFD0                        = v2.GroupIFD0
+	GroupIFD1                        = v2.GroupIFD1
+	GroupInterop                     = v2.GroupInterop
+	GroupMakerNote                   = v2.GroupMakerNote
+	GroupSep                         = v2.GroupSep
+	Humidity                         = v2.Humidity
+	ISOSpeedRatings                  = v2.ISOSpeedRatings
+	ImageDescription                 = v2.ImageDescription
+	ImageLength                      = v2.ImageLength
+	ImageUniqueID                    = v2.ImageUniqueID
+	ImageWidth                       = v2.ImageWidth
+	InteropIFDPointerID              = v2.InteropIFDPointerID
+	InteroperabilityIFDPointer       = v2.InteroperabilityIFDPointer
+	InteroperabilityIndex            = v2.InteroperabilityIndex
+	LensMake                         = v2.LensMake
+	LensModel                        = v2.LensModel
+	LightSource                      = v2.LightSource
+	Make                             = v2.Make
+	MakerNote                        = v2.MakerNote
+	MakerNoteID                      = v2.MakerNoteID
+	MaxApertureValue                 = v2.MaxApertureValue
+	MeteringMode                     = v2.MeteringMode
+	Model                            = v2.Model
+	NamespaceSep                     = v2.NamespaceSep
+	NewSubfileType                   = v2.NewSubfileType
+	OECF                             = v2.OECF
+	OffsetTime                       = v2.OffsetTime
+	OffsetTimeDigitized              = v2.OffsetTimeDigitized
+	OffsetTimeOriginal               = v2.OffsetTimeOriginal
+	Orientation                      = v2.Orientation
+	PhotometricInterpretation        = v2.PhotometricInterpretation
+	PixelXDimension                  = v2.PixelXDimension
+	PixelYDimension                  = v2.PixelYDimension
+	PlanarConfiguration              = v2.PlanarConfiguration
+	Pressure                         = v2.Pressure
+	PrimaryChromaticities            = v2.PrimaryChromaticities
+	RelatedSoundFile                 = v2.RelatedSoundFile
+	ResolutionUnit                   = v2.ResolutionUnit
+	RowsPerStrip                     = v2.RowsPerStrip
+	RuleCopyright                    = v2.RuleCopyright
+	RuleForbid                       = v2.RuleForbid
+	RuleGPS                          = v2.RuleGPS
+	RuleMaxAge                       = v2.RuleMaxAge
+	RuleMaxThumbnail                 = v2.RuleMaxThumbnail
+	RuleRequire                      = v2.RuleRequire
+	SamplesPerPixel                  = v2.SamplesPerPixel
+	Saturation                       = v2.Saturation
+	SceneCaptureType                 = v2.SceneCaptureType
+	SceneType                        = v2.SceneType
+	SensingColorSequence             = v2.SensingColorSequence
+	SensingMethod                    = v2.SensingMethod
+	SensingOneChipColor              = v2.SensingOneChipColor
+	SensingSequential                = v2.SensingSequential
+	SensingThreeChip                 = v2.SensingThreeChip
+	SensingTrilinear                 = v2.SensingTrilinear
+	SensingTwoChipColor              = v2.SensingTwoChipColor
+	SensingUndefined                 = v2.SensingUndefined
+	Sharpness                        = v2.Sharpness
+	ShutterSpeedValue                = v2.ShutterSpeedValue
+	Software                         = v2.Software
+	SourceCamera                     = v2.SourceCamera
+	SourceEdited                     = v2.SourceEdited
+	SourceScan                       = v2.SourceScan
+	SourceScreenshot                 = v2.SourceScreenshot
+	SourceUnknown                    = v2.SourceUnknown
+	SpatialFrequencyResponse         = v2.SpatialFrequencyResponse
+	SpectralSensitivity              = v2.SpectralSensitivity
+	StageParse                       = v2.StageParse
+	StageRead                        = v2.StageRead
+	StripByteCounts                  = v2.StripByteCounts
+	StripOffsets                     = v2.StripOffsets
+	SubIFDs                          = v2.SubIFDs
+	SubSecTime                       = v2.SubSecTime
+	SubSecTimeDigitized              = v2.SubSecTimeDigitized
+	SubSecTimeOriginal               = v2.SubSecTimeOriginal
+	SubjectArea                      = v2.SubjectArea
+	SubjectDistance                  = v2.SubjectDistance
+	SubjectDistanceRange             = v2.SubjectDistanceRange
+	SubjectLocation                  = v2.SubjectLocation
+	Temperature                      = v2.Temperature
+	ThumbJPEGInterchangeFormat       = v2.ThumbJPEGInterchangeFormat
+	ThumbJPEGInterchangeFormatLength = v2.ThumbJPEGInterchangeFormatLength
+	TrailerImage                     = v2.TrailerImage
+	TrailerPadding                   = v2.TrailerPadding
+	TrailerSamsung                   = v2.TrailerSamsung
+	TrailerUnknown                   = v2.TrailerUnknown
+	TrailerVideo                     = v2.TrailerVideo
+	UnknownPrefix                    = v2.UnknownPrefix
+	UserComment                      = v2.UserComment
+	WarpFisheye                      = v2.WarpFisheye
+	WarpRectilinear                  = v2.WarpRectilinear
+	WaterDepth                       = v2.WaterDepth
+	WhiteBalance                     = v2.WhiteBalance
+	WhiteBalanceAuto                 = v2.WhiteBalanceAuto
+	WhiteBalanceManual               = v2.WhiteBalanceManual
+	WhitePoint                       = v2.WhitePoint
+	XPAuthor                         = v2.XPAuthor
+	XPComment                        = v2.XPComment
+	XPKeywords                       = v2.XPKeywords
+	XPSubject                        = v2.XPSubject
+	XPTitle                          = v2.XPTitle
+	XResolution                      = v2.XResolution
+	YCbCrPositioning                 = v2.YCbCrPositioning
+	YCbCrSubSampling                 = v2.YCbCrSubSampling
+	YResolution                      = v2.YResolution
+)
+
+var (
+	ErrNoAudio       = v2.ErrNoAudio
+	ErrNoExif        = v2.ErrNoExif
+	ErrNoOrientation = v2.ErrNoOrientation
+	ErrNoSignature   = v2.ErrNoSignature
+	ErrTimeout       = v2.ErrTimeout
+)
+
+func AppendGPSAPP1(dst []byte, fix GPSFix) ([]byte, error) {
+	return v2.AppendGPSAPP1(dst, fix)
+}
+
+func ClockOffset(x *Exif, actual time.Time) (time.Duration, error) {
+	return v2.ClockOffset(x, actual)
+}
+
+func ClockOffsets(sync []*Exif) (map[string]time.Duration, error) {
+	return v2.ClockOffsets(sync)
+}
+
+func DecodeAll(r io.Reader) ([]Carved, error) {
+	return v2.DecodeAll(r)
+}
+
+func DecodeGPS(r io.Reader) (*Exif, error) {
+	return v2.DecodeGPS(r)
+}
+
+func DecodeTimes(r io.Reader) (Times, error) {
+	return v2.DecodeTimes(r)
+}
+
+func Encode(w io.Writer, x *Exif, order binary.ByteOrder) error {
+	return v2.Encode(w, x, order)
+}
+
+func Fields() []FieldInfo {
+	return v2.Fields()
+}
+
+func FieldsIn(group string) []FieldInfo {
+	return v2.FieldsIn(group)
+}
+
+func GPSFields() []FieldInfo {
+	return v2.GPSFields()
+}
+
+func GroupBursts(xs []*Exif, maxGap time.Duration) []Burst {
+	return v2.GroupBursts(xs, maxGap)
+}
+
+func IsCriticalError(err error) bool {
+	return v2.IsCriticalError(err)
+}
+
+func IsExifError(err error) bool {
+	return v2.IsExifError(err)
+}
+
+func IsGPSError(err error) bool {
+	return v2.IsGPSError(err)
+}
+
+func IsInteroperabilityError(err error) bool {
+	return v2.IsInteroperabilityError(err)
+}
+
+func IsShortReadTagValueError(err error) bool {
+	return v2.IsShortReadTagValueError(err)
+}
+
+func IsTagNotPresentError(err error) bool {
+	return v2.IsTagNotPresentError(err)
+}
+
+func LabelFor(name FieldName, lang string) Label {
+	return v2.LabelFor(name, lang)
+}
+
+func LookupField(name FieldName) (FieldInfo, bool) {
+	return v2.LookupField(name)
+}
+
+func Namespaced(ns string, name FieldName) FieldName {
+	return v2.Namespaced(ns, name)
+}
+
+func NewDecoder(opts ...Option) *Decoder {
+	return v2.NewDecoder(opts...)
+}
+
+func NewHTTPRangeReader(client *http.Client, url string) (*RangeReader, error) {
+	return v2.NewHTTPRangeReader(client, url)
+}
+
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return v2.NewJSONEncoder(w)
+}
+
+func NewRangeReader(fetch RangeFunc, size int64) *RangeReader {
+	return v2.NewRangeReader(fetch, size)
+}
+
+func ParseFilter(expr string) (*Filter, error) {
+	return v2.ParseFilter(expr)
+}
+
+func ParseOpcodeList(b []byte) ([]Opcode, error) {
+	return v2.ParseOpcodeList(b)
+}
+
+func ParsePolicy(data []byte) (*Policy, error) {
+	return v2.ParsePolicy(data)
+}
+
+func Qualified(group string, name FieldName) FieldName {
+	return v2.Qualified(group, name)
+}
+
+func RegisterLabels(lang string, ls map[FieldName]Label) {
+	v2.RegisterLabels(lang, ls)
+}
+
+func RegisterParsers(ps ...Parser) {
+	v2.RegisterParsers(ps...)
+}
+
+func Scan(r io.Reader) ([]Carved, error) {
+	return v2.Scan(r)
+}
+
+func SetOrientationInPlace(rw ReadWriterAt, orientation int) error {
+	return v2.SetOrientationInPlace(rw, orientation)
+}
+
+func Strip(src io.Reader, dst io.Writer) error {
+	return v2.Strip(src, dst)
+}
+
+func StripGPS(src io.Reader, dst io.Writer) error {
+	return v2.StripGPS(src, dst)
+}
+
+func Timeline(xs []*Exif, offsets map[string]time.Duration) []TimelineEntry {
+	return v2.Timeline(xs, offsets)
+}
+
+func Trailers(r io.ReaderAt, size int64) ([]Trailer, error) {
+	return v2.Trailers(r, size)
+}
+
+func WithContext(ctx context.Context) Option {
+	return v2.WithContext(ctx)
+}
+
+func WithDropImplausible() Option {
+	return v2.WithDropImplausible()
+}
+
+func WithDuplicates(policy DuplicatePolicy) Option {
+	return v2.WithDuplicates(policy)
+}
+
+func WithFingerprint() Option {
+	return v2.WithFingerprint()
+}
+
+func WithJSONStrings(mode tiff.StringMode) Option {
+	return v2.WithJSONStrings(mode)
+}
+
+func WithKeepOnly(names ...FieldName) Option {
+	return v2.WithKeepOnly(names...)
+}
+
+func WithMakerNoteParsers(names ...string) Option {
+	return v2.WithMakerNoteParsers(names...)
+}
+
+func WithPermissive(permissive bool) Option {
+	return v2.WithPermissive(permissive)
+}
+
+func WithProgress(fn func(stage Stage, n int64) error) Option {
+	return v2.WithProgress(fn)
+}
+
+func WithRationals(format RationalFormat) Option {
+	return v2.WithRationals(format)
+}
+
+func WithSubIFDLimits(limits tiff.SubIFDLimits) Option {
+	return v2.WithSubIFDLimits(limits)
+}
+
+func WriteJPEG(dst io.Writer, src io.Reader, x *Exif) error {
+	return v2.WriteJPEG(dst, src, x)
+}
+
+func WriteJPEGRaw(dst io.Writer, src io.Reader, data []byte) error {
+	return v2.WriteJPEGRaw(dst, src, data)
+}
+
+func X3FProperties(r io.ReaderAt, size int64) (map[string]string, error) {
+	return v2.X3FProperties(r, size)
+}
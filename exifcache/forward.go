@@ -0,0 +1,15 @@
+// Code generated by gencompat; DO NOT EDIT.
+
+package exifcache
+
+import (
+	v2 "github.com/rwcarlsen/goexif/v2/exifcache"
+)
+
+type (
+	Cache = v2.Cache
+)
+
+func New(maxEntries int, maxBytes int) *Cache {
+	return v2.New(maxEntries, maxBytes)
+}
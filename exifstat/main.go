@@ -1,41 +1,83 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/rwcarlsen/goexif/exif"
-	"github.com/rwcarlsen/goexif/mknote"
-	"github.com/rwcarlsen/goexif/tiff"
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/mknote"
+	"github.com/rwcarlsen/goexif/v2/tiff"
 )
 
 var mnote = flag.Bool("mknote", false, "try to parse makernote data")
+var mnoteParsers = flag.String("mknote-parsers", "", "comma separated makernote parsers to try, in priority order (implies -mknote)")
+var debug = flag.Bool("debug", false, "print an annotated hex dump of the EXIF data")
+var validate = flag.Bool("validate", false, "report overlapping, misaligned and unreferenced areas of the EXIF data")
+var watchDir = flag.String("watch", "", "watch a directory and print the metadata of new files as they appear")
+var watchInterval = flag.Duration("watch-interval", time.Second, "polling interval for -watch")
+var utf8Strings = flag.Bool("utf8", false, "print string values as UTF-8 text rather than ASCII")
 var thumb = flag.Bool("thumb", false, "dump thumbail data to stdout (for first listed image file)")
+var jsonOut = flag.Bool("json", false, "print the metadata of all files as a JSON array, streamed as files are decoded")
+var where = flag.String("where", "", "only print images matching a filter expression, e.g. 'ISO > 3200 && has(GPSLatitude)'")
+
+// filter is the compiled -where expression, or nil.
+var filter *exif.Filter
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		os.Exit(verify(os.Args[2:]))
+	}
+
 	flag.Parse()
 	fnames := flag.Args()
 
+	dec := &exif.Decoder{}
+	if *mnoteParsers != "" {
+		*mnote = true
+		dec.MakerNoteParsers = strings.Split(*mnoteParsers, ",")
+	}
 	if *mnote {
 		exif.RegisterParsers(mknote.All...)
 	}
-
-	for _, name := range fnames {
-		f, err := os.Open(name)
-		if err != nil {
-			log.Printf("err on %v: %v", name, err)
-			continue
+	if *where != "" {
+		var err error
+		if filter, err = exif.ParseFilter(*where); err != nil {
+			log.Fatal(err)
 		}
+	}
 
-		x, err := exif.Decode(f)
-		if err != nil {
-			log.Printf("err on %v: %v", name, err)
-			continue
+	if *watchDir != "" {
+		watch(*watchDir, *watchInterval, func(name string) {
+			printImage(dec, name)
+		})
+		return
+	}
+
+	if *jsonOut {
+		if err := printJSON(dec, fnames); err != nil {
+			log.Fatal(err)
 		}
+		return
+	}
 
+	for _, name := range fnames {
 		if *thumb {
+			f, err := os.Open(name)
+			if err != nil {
+				log.Printf("err on %v: %v", name, err)
+				continue
+			}
+			x, err := dec.Decode(f)
+			f.Close()
+			if err != nil {
+				log.Printf("err on %v: %v", name, err)
+				continue
+			}
 			data, err := x.JpegThumbnail()
 			if err != nil {
 				log.Fatal("no thumbnail present")
@@ -45,16 +87,90 @@ func main() {
 			}
 			return
 		}
+		printImage(dec, name)
+	}
+}
+
+// printImage decodes the named image file and prints its fields.
+func printImage(dec *exif.Decoder, name string) {
+	f, err := os.Open(name)
+	if err != nil {
+		log.Printf("err on %v: %v", name, err)
+		return
+	}
+	defer f.Close()
+
+	x, err := dec.Decode(f)
+	if err != nil {
+		log.Printf("err on %v: %v", name, err)
+		return
+	}
+	if filter != nil && !filter.Match(x) {
+		return
+	}
+
+	fmt.Printf("\n---- Image '%v' ----\n", name)
+	if *debug {
+		if err := x.HexDump(os.Stdout); err != nil {
+			log.Printf("err on %v: %v", name, err)
+		}
+	}
+	if *validate {
+		for _, w := range x.ValidateLayout() {
+			fmt.Printf("    layout: %v\n", w)
+		}
+	}
+	if *mnote {
+		mp := x.MakerNoteParser()
+		if mp == "" {
+			mp = "none"
+		}
+		fmt.Printf("    (makernote parser: %v)\n", mp)
+	}
+	x.Walk(Walker{})
+}
 
-		fmt.Printf("\n---- Image '%v' ----\n", name)
-		x.Walk(Walker{})
+// printJSON decodes the named files concurrently and prints their
+// metadata as a JSON array, in the order the files complete.
+func printJSON(dec *exif.Decoder, fnames []string) error {
+	names := make(chan string)
+	go func() {
+		for _, name := range fnames {
+			names <- name
+		}
+		close(names)
+	}()
+
+	w := bufio.NewWriter(os.Stdout)
+	enc := exif.NewJSONEncoder(w)
+	b := &exif.BatchDecoder{Decoder: dec}
+	for res := range b.Run(names) {
+		if res.Exif != nil {
+			if filter != nil && !filter.Match(res.Exif) {
+				continue
+			}
+			if *utf8Strings {
+				res.Exif.SetJSONStrings(tiff.StringsUTF8)
+			}
+		}
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
 	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
 }
 
 type Walker struct{}
 
 func (_ Walker) Walk(name exif.FieldName, tag *tiff.Tag) error {
-	data, _ := tag.MarshalJSON()
+	mode := tiff.StringsASCII
+	if *utf8Strings {
+		mode = tiff.StringsUTF8
+	}
+	data, _ := tag.MarshalJSONStrings(mode)
 	fmt.Printf("    %v: %v\n", name, string(data))
 	return nil
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/mknote"
+)
+
+// verify implements the verify subcommand:
+//
+//	exifstat verify [-policy file.json] [-require f1,f2] [-forbid f3,f4] [-mknote] path...
+//
+// Every file in the given files and directories (recursively) is checked
+// against the rules of the policy file (see exif.ParsePolicy) and the
+// required and forbidden fields.  Violations are printed, one per line, and
+// verify returns a non-zero exit status if there were any.
+func verify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	require := fs.String("require", "", "comma separated fields every image must have")
+	forbid := fs.String("forbid", "", "comma separated fields no image may have")
+	policyFile := fs.String("policy", "", "JSON file of the policy every image must satisfy")
+	mnote := fs.Bool("mknote", false, "try to parse makernote data")
+	fs.Parse(args)
+
+	if *mnote {
+		exif.RegisterParsers(mknote.All...)
+	}
+	policy := &exif.Policy{}
+	if *policyFile != "" {
+		data, err := ioutil.ReadFile(*policyFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if policy, err = exif.ParsePolicy(data); err != nil {
+			log.Fatal(err)
+		}
+	}
+	policy.Require = append(policy.Require, fieldList(*require)...)
+	policy.Forbid = append(policy.Forbid, fieldList(*forbid)...)
+
+	violations := 0
+	check := func(path string) {
+		for _, msg := range verifyFile(path, policy) {
+			fmt.Printf("%v: %v\n", path, msg)
+			violations++
+		}
+	}
+	for _, root := range fs.Args() {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				check(path)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("err on %v: %v", root, err)
+			violations++
+		}
+	}
+
+	if violations > 0 {
+		return 1
+	}
+	return 0
+}
+
+// verifyFile returns the policy violations of the image at path.
+func verifyFile(path string, policy *exif.Policy) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	defer f.Close()
+
+	x, err := exif.Decode(f)
+	if x == nil {
+		if len(policy.Check(&exif.Exif{})) == 0 {
+			// no EXIF data, so no forbidden fields either
+			return nil
+		}
+		return []string{fmt.Sprintf("no EXIF data (%v)", err)}
+	}
+
+	var msgs []string
+	for _, v := range policy.Check(x) {
+		msgs = append(msgs, v.String())
+	}
+	return msgs
+}
+
+func fieldList(s string) []exif.FieldName {
+	var names []exif.FieldName
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, exif.FieldName(name))
+		}
+	}
+	return names
+}
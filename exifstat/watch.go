@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// watch polls dir every interval and calls handle for each file added to
+// it after watch started.  A new file is only handled once its size has
+// stopped changing between two polls, so files still being copied (e.g. by
+// tethering software) are not read half-written.  watch never returns.
+func watch(dir string, interval time.Duration, handle func(path string)) {
+	seen := map[string]bool{}
+	pending := map[string]int64{}
+	first := true
+	for {
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			log.Printf("err on %v: %v", dir, err)
+		}
+		var ready []string
+		for _, info := range infos {
+			name := info.Name()
+			if !info.Mode().IsRegular() || seen[name] {
+				continue
+			}
+			if first {
+				// only report files added after watching started
+				seen[name] = true
+				continue
+			}
+			if size, ok := pending[name]; ok && size == info.Size() {
+				delete(pending, name)
+				seen[name] = true
+				ready = append(ready, name)
+				continue
+			}
+			pending[name] = info.Size()
+		}
+		first = false
+
+		sort.Strings(ready)
+		for _, name := range ready {
+			handle(filepath.Join(dir, name))
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,8 @@
+// Package exiftest is the v1 API of package github.com/rwcarlsen/goexif/v2/exiftest,
+// which new code should import instead.  Its types are aliases of the v2
+// types and its functions call the v2 functions, so values pass freely
+// between code using either version while it migrates.  See the v2 package
+// for the documentation.
+package exiftest
+
+//go:generate go run ../internal/gencompat github.com/rwcarlsen/goexif/v2/exiftest
@@ -0,0 +1,57 @@
+// Code generated by gencompat; DO NOT EDIT.
+
+package exiftest
+
+import (
+	"io"
+	"testing"
+
+	v2 "github.com/rwcarlsen/goexif/v2/exiftest"
+)
+
+type (
+	Builder    = v2.Builder
+	Corruption = v2.Corruption
+	Golden     = v2.Golden
+	Mismatch   = v2.Mismatch
+)
+
+var (
+	Update = v2.Update
+)
+
+func Compare(dir string, g Golden) []Mismatch {
+	return v2.Compare(dir, g)
+}
+
+func EntryCount(n uint16) Corruption {
+	return v2.EntryCount(n)
+}
+
+func NewJPEG() *Builder {
+	return v2.NewJPEG()
+}
+
+func NewTIFF() *Builder {
+	return v2.NewTIFF()
+}
+
+func PatchEntry(id uint16, val uint32) Corruption {
+	return v2.PatchEntry(id, val)
+}
+
+func ReadGolden(r io.Reader) (Golden, error) {
+	return v2.ReadGolden(r)
+}
+
+func Run(t testing.TB, dir string, golden string) {
+	v2.Run(t, dir, golden)
+}
+
+func Snapshot(dir string) (Golden, error) {
+	return v2.Snapshot(dir)
+}
+
+func Truncate(n int) Corruption {
+	return v2.Truncate(n)
+}
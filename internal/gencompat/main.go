@@ -0,0 +1,278 @@
+// Command gencompat writes the declarations of a v1 package forwarding to
+// the v2 package it was moved to: aliases of its types, copies of its
+// constants and variables and functions calling its functions.
+//
+// Usage, from the directory of the v1 package:
+//
+//	go run ../internal/gencompat [-skip name,...] importpath
+//
+// The declarations are written to forward.go, and those of v2 files with
+// build constraints to a forward_*.go file with the same constraints.
+// Names listed with -skip are left out, to be written by hand where the v1
+// API differs.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/build/constraint"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	v1Prefix = "github.com/rwcarlsen/goexif/"
+	v2Prefix = v1Prefix + "v2/"
+)
+
+var skip = flag.String("skip", "", "comma separated names not to forward")
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gencompat: ")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: gencompat [-skip name,...] importpath")
+	}
+	path := flag.Arg(0)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil).(types.ImporterFrom)
+	pkg, err := imp.ImportFrom(path, wd, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	skipped := map[string]bool{}
+	for _, name := range strings.Split(*skip, ",") {
+		skipped[name] = true
+	}
+	files := map[string]*file{}
+	for _, name := range pkg.Scope().Names() {
+		obj := pkg.Scope().Lookup(name)
+		if !obj.Exported() || skipped[name] {
+			continue
+		}
+		cons, err := buildConstraint(fset.File(obj.Pos()).Name())
+		if err != nil {
+			log.Fatal(err)
+		}
+		f := files[cons]
+		if f == nil {
+			f = &file{pkg: pkg, constraint: cons, imports: map[string]string{}}
+			files[cons] = f
+		}
+		if err := f.add(obj); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	for cons, f := range files {
+		name := "forward.go"
+		if cons != "" {
+			name = "forward_" + strings.Map(func(r rune) rune {
+				if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, cons) + ".go"
+		}
+		src, err := f.source()
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		if err := ioutil.WriteFile(name, src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// buildConstraint returns the //go:build expression of the named Go file,
+// or "" if it has none.
+func buildConstraint(name string) (string, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if constraint.IsGoBuild(line) {
+			expr, err := constraint.Parse(line)
+			if err != nil {
+				return "", fmt.Errorf("%s: %v", name, err)
+			}
+			return expr.String(), nil
+		}
+	}
+	return "", nil
+}
+
+// file collects the declarations of an output file.
+type file struct {
+	pkg        *types.Package
+	constraint string
+	imports    map[string]string // import path to package name
+
+	types, consts, vars []string
+	funcs               bytes.Buffer
+}
+
+// qualifier refers to the types of the v2 package by their v1 aliases, and
+// to those of the other v2 packages of the module through their v1
+// packages.
+func (f *file) qualifier(p *types.Package) string {
+	if p == f.pkg {
+		return ""
+	}
+	f.imports[strings.Replace(p.Path(), v2Prefix, v1Prefix, 1)] = p.Name()
+	return p.Name()
+}
+
+func (f *file) add(obj types.Object) error {
+	name := obj.Name()
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+			return fmt.Errorf("cannot alias generic type %s", name)
+		}
+		f.types = append(f.types, fmt.Sprintf("%s = v2.%s", name, name))
+	case *types.Const:
+		f.consts = append(f.consts, fmt.Sprintf("%s = v2.%s", name, name))
+	case *types.Var:
+		f.vars = append(f.vars, fmt.Sprintf("%s = v2.%s", name, name))
+	case *types.Func:
+		f.addFunc(obj)
+	default:
+		return fmt.Errorf("unexpected declaration of %s", name)
+	}
+	return nil
+}
+
+func (f *file) addFunc(fn *types.Func) {
+	sig := fn.Type().(*types.Signature)
+	var tparams, targs []string
+	for i := 0; i < sig.TypeParams().Len(); i++ {
+		tp := sig.TypeParams().At(i)
+		tparams = append(tparams, tp.Obj().Name()+" "+types.TypeString(tp.Constraint(), f.qualifier))
+		targs = append(targs, tp.Obj().Name())
+	}
+	var params, args []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		name := p.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("p%d", i)
+		}
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			elem := p.Type().(*types.Slice).Elem()
+			params = append(params, name+" ..."+types.TypeString(elem, f.qualifier))
+			args = append(args, name+"...")
+			continue
+		}
+		params = append(params, name+" "+types.TypeString(p.Type(), f.qualifier))
+		args = append(args, name)
+	}
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, types.TypeString(sig.Results().At(i).Type(), f.qualifier))
+	}
+
+	w := &f.funcs
+	fmt.Fprintf(w, "\nfunc %s", fn.Name())
+	call := "v2." + fn.Name()
+	if len(tparams) > 0 {
+		fmt.Fprintf(w, "[%s]", strings.Join(tparams, ", "))
+		call += "[" + strings.Join(targs, ", ") + "]"
+	}
+	fmt.Fprintf(w, "(%s)", strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+		fmt.Fprintf(w, " {\n\t%s(%s)\n}\n", call, strings.Join(args, ", "))
+		return
+	case 1:
+		fmt.Fprintf(w, " %s", results[0])
+	default:
+		fmt.Fprintf(w, " (%s)", strings.Join(results, ", "))
+	}
+	fmt.Fprintf(w, " {\n\treturn %s(%s)\n}\n", call, strings.Join(args, ", "))
+}
+
+func (f *file) source() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gencompat; DO NOT EDIT.\n\n")
+	if f.constraint != "" {
+		fmt.Fprintf(&b, "//go:build %s\n", f.constraint)
+		expr, _ := constraint.Parse("//go:build " + f.constraint)
+		lines, err := constraint.PlusBuildLines(expr)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			fmt.Fprintln(&b, line)
+		}
+		fmt.Fprintln(&b)
+	}
+	fmt.Fprintf(&b, "package %s\n\n", f.pkg.Name())
+
+	f.imports[f.pkg.Path()] = "v2"
+	var paths []string
+	for path := range f.imports {
+		paths = append(paths, path)
+	}
+	// Standard library packages first, as goimports groups them.
+	sort.Slice(paths, func(i, j int) bool {
+		if si, sj := isStd(paths[i]), isStd(paths[j]); si != sj {
+			return si
+		}
+		return paths[i] < paths[j]
+	})
+	fmt.Fprintln(&b, "import (")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			fmt.Fprintln(&b)
+		}
+		if name := f.imports[path]; name == "v2" {
+			fmt.Fprintf(&b, "\tv2 %q\n", path)
+		} else {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	fmt.Fprintln(&b, ")")
+
+	block := func(kw string, decls []string) {
+		if len(decls) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (\n", kw)
+		for _, d := range decls {
+			fmt.Fprintf(&b, "\t%s\n", d)
+		}
+		fmt.Fprintln(&b, ")")
+	}
+	block("type", f.types)
+	block("const", f.consts)
+	block("var", f.vars)
+	b.Write(f.funcs.Bytes())
+	return format.Source(b.Bytes())
+}
+
+// isStd reports whether path is the import path of a standard library
+// package, whose first element has no dot.
+func isStd(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
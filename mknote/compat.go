@@ -0,0 +1,8 @@
+// Package mknote is the v1 API of package github.com/rwcarlsen/goexif/v2/mknote,
+// which new code should import instead.  Its types are aliases of the v2
+// types and its functions call the v2 functions, so values pass freely
+// between code using either version while it migrates.  See the v2 package
+// for the documentation.
+package mknote
+
+//go:generate go run ../internal/gencompat github.com/rwcarlsen/goexif/v2/mknote
@@ -0,0 +1,375 @@
+// Code generated by gencompat; DO NOT EDIT.
+
+package mknote
+
+import (
+	"io"
+	"time"
+
+	"github.com/rwcarlsen/goexif/exif"
+	v2 "github.com/rwcarlsen/goexif/v2/mknote"
+)
+
+type (
+	AFPoint      = v2.AFPoint
+	FirmwareInfo = v2.FirmwareInfo
+	GPMFEntry    = v2.GPMFEntry
+	Layout       = v2.Layout
+	LayoutField  = v2.LayoutField
+	Stack        = v2.Stack
+	StackKind    = v2.StackKind
+)
+
+const (
+	AFResponse                     = v2.AFResponse
+	ActiveDLighting                = v2.ActiveDLighting
+	AutoBracketRelease             = v2.AutoBracketRelease
+	AuxiliaryLens                  = v2.AuxiliaryLens
+	CameraInfo                     = v2.CameraInfo
+	Canon_0x0000                   = v2.Canon_0x0000
+	Canon_0x0003                   = v2.Canon_0x0003
+	Canon_0x00b5                   = v2.Canon_0x00b5
+	Canon_0x00c0                   = v2.Canon_0x00c0
+	Canon_0x00c1                   = v2.Canon_0x00c1
+	Canon_AFInfo                   = v2.Canon_AFInfo
+	Canon_CameraSettings           = v2.Canon_CameraSettings
+	Canon_ShotInfo                 = v2.Canon_ShotInfo
+	Canon_TimeInfo                 = v2.Canon_TimeInfo
+	CaptureData                    = v2.CaptureData
+	CaptureOffsets                 = v2.CaptureOffsets
+	CaptureOutput                  = v2.CaptureOutput
+	CaptureVersion                 = v2.CaptureVersion
+	Casio_Contrast                 = v2.Casio_Contrast
+	Casio_FirmwareDate             = v2.Casio_FirmwareDate
+	Casio_FlashIntensity           = v2.Casio_FlashIntensity
+	Casio_FocalLength              = v2.Casio_FocalLength
+	Casio_FocusMode                = v2.Casio_FocusMode
+	Casio_ImageSize                = v2.Casio_ImageSize
+	Casio_ObjectDistance           = v2.Casio_ObjectDistance
+	Casio_PreviewImage             = v2.Casio_PreviewImage
+	Casio_PreviewImageSize         = v2.Casio_PreviewImageSize
+	Casio_RecordingMode            = v2.Casio_RecordingMode
+	Casio_Saturation               = v2.Casio_Saturation
+	Casio_WhiteBalance             = v2.Casio_WhiteBalance
+	ColorData                      = v2.ColorData
+	ColorHue                       = v2.ColorHue
+	ColorMode                      = v2.ColorMode
+	ContrastCurve                  = v2.ContrastCurve
+	CropHiSpeed                    = v2.CropHiSpeed
+	CustomFunctions                = v2.CustomFunctions
+	DataDump                       = v2.DataDump
+	DeletedImageCount              = v2.DeletedImageCount
+	DigitalZoom                    = v2.DigitalZoom
+	DustRemovalData                = v2.DustRemovalData
+	ExposureBracketComp            = v2.ExposureBracketComp
+	ExposureDiff                   = v2.ExposureDiff
+	ExposureTuning                 = v2.ExposureTuning
+	FeatureAFPoints                = v2.FeatureAFPoints
+	FeatureContrastDetect          = v2.FeatureContrastDetect
+	FeatureFocusBracket            = v2.FeatureFocusBracket
+	FeatureGPS                     = v2.FeatureGPS
+	FeaturePixelShift              = v2.FeaturePixelShift
+	FeatureStabilization           = v2.FeatureStabilization
+	FileNumber                     = v2.FileNumber
+	FirmwareVersion                = v2.FirmwareVersion
+	FlashBracketComp               = v2.FlashBracketComp
+	FlashComp                      = v2.FlashComp
+	FlashDevice                    = v2.FlashDevice
+	FlashExposureComp              = v2.FlashExposureComp
+	FlashMode                      = v2.FlashMode
+	FlashSetting                   = v2.FlashSetting
+	Focus                          = v2.Focus
+	FocusDistance                  = v2.FocusDistance
+	GoPro_AutoRotation             = v2.GoPro_AutoRotation
+	GoPro_ColorMode                = v2.GoPro_ColorMode
+	GoPro_DiagonalFieldOfView      = v2.GoPro_DiagonalFieldOfView
+	GoPro_DigitalZoom              = v2.GoPro_DigitalZoom
+	GoPro_ElectronicStabilization  = v2.GoPro_ElectronicStabilization
+	GoPro_ExposureCompensation     = v2.GoPro_ExposureCompensation
+	GoPro_ExposureType             = v2.GoPro_ExposureType
+	GoPro_FieldOfView              = v2.GoPro_FieldOfView
+	GoPro_ISOMax                   = v2.GoPro_ISOMax
+	GoPro_ISOMin                   = v2.GoPro_ISOMin
+	GoPro_LensProjection           = v2.GoPro_LensProjection
+	GoPro_MediaUniqueID            = v2.GoPro_MediaUniqueID
+	GoPro_Model                    = v2.GoPro_Model
+	GoPro_Protune                  = v2.GoPro_Protune
+	GoPro_Sharpness                = v2.GoPro_Sharpness
+	GoPro_WhiteBalance             = v2.GoPro_WhiteBalance
+	Hasselblad_CameraModelID       = v2.Hasselblad_CameraModelID
+	Hasselblad_CameraModelName     = v2.Hasselblad_CameraModelName
+	Hasselblad_CoatingCode         = v2.Hasselblad_CoatingCode
+	Hasselblad_SensorCode          = v2.Hasselblad_SensorCode
+	HighISONoiseReduction          = v2.HighISONoiseReduction
+	HueAdjustment                  = v2.HueAdjustment
+	ICCProfile                     = v2.ICCProfile
+	ISOSelection                   = v2.ISOSelection
+	ISOSettings                    = v2.ISOSettings
+	ISOSpeed                       = v2.ISOSpeed
+	ImageAdjustment                = v2.ImageAdjustment
+	ImageAuthentication            = v2.ImageAuthentication
+	ImageBoundary                  = v2.ImageBoundary
+	ImageCount                     = v2.ImageCount
+	ImageDataSize                  = v2.ImageDataSize
+	ImageOptimization              = v2.ImageOptimization
+	ImageProcessing                = v2.ImageProcessing
+	ImageStabilization             = v2.ImageStabilization
+	ImageType                      = v2.ImageType
+	InternalSerialNumber           = v2.InternalSerialNumber
+	Kodak_BurstMode                = v2.Kodak_BurstMode
+	Kodak_DateTimeStamp            = v2.Kodak_DateTimeStamp
+	Kodak_ExposureCompensation     = v2.Kodak_ExposureCompensation
+	Kodak_ExposureTime             = v2.Kodak_ExposureTime
+	Kodak_FNumber                  = v2.Kodak_FNumber
+	Kodak_FlashFired               = v2.Kodak_FlashFired
+	Kodak_FocusMode                = v2.Kodak_FocusMode
+	Kodak_ImageHeight              = v2.Kodak_ImageHeight
+	Kodak_ImageWidth               = v2.Kodak_ImageWidth
+	Kodak_MeteringMode             = v2.Kodak_MeteringMode
+	Kodak_Model                    = v2.Kodak_Model
+	Kodak_MonthDayCreated          = v2.Kodak_MonthDayCreated
+	Kodak_SequenceNumber           = v2.Kodak_SequenceNumber
+	Kodak_ShutterMode              = v2.Kodak_ShutterMode
+	Kodak_TimeCreated              = v2.Kodak_TimeCreated
+	Kodak_TotalZoom                = v2.Kodak_TotalZoom
+	Kodak_WhiteBalance             = v2.Kodak_WhiteBalance
+	Kodak_YearCreated              = v2.Kodak_YearCreated
+	Lens                           = v2.Lens
+	LensFStops                     = v2.LensFStops
+	LensModel                      = v2.LensModel
+	LensType                       = v2.LensType
+	LinearizationTable             = v2.LinearizationTable
+	MeasuredColor                  = v2.MeasuredColor
+	Minolta_BracketStep            = v2.Minolta_BracketStep
+	Minolta_Brightness             = v2.Minolta_Brightness
+	Minolta_CameraSettings         = v2.Minolta_CameraSettings
+	Minolta_ColorBalanceBlue       = v2.Minolta_ColorBalanceBlue
+	Minolta_ColorBalanceGreen      = v2.Minolta_ColorBalanceGreen
+	Minolta_ColorBalanceRed        = v2.Minolta_ColorBalanceRed
+	Minolta_ColorTemperature       = v2.Minolta_ColorTemperature
+	Minolta_CompressedImageSize    = v2.Minolta_CompressedImageSize
+	Minolta_Contrast               = v2.Minolta_Contrast
+	Minolta_Date                   = v2.Minolta_Date
+	Minolta_DriveMode              = v2.Minolta_DriveMode
+	Minolta_ExposureCompensation   = v2.Minolta_ExposureCompensation
+	Minolta_ExposureMode           = v2.Minolta_ExposureMode
+	Minolta_ExposureTime           = v2.Minolta_ExposureTime
+	Minolta_FNumber                = v2.Minolta_FNumber
+	Minolta_FileNumberMemory       = v2.Minolta_FileNumberMemory
+	Minolta_FlashExposureComp      = v2.Minolta_FlashExposureComp
+	Minolta_FlashFired             = v2.Minolta_FlashFired
+	Minolta_FocalLength            = v2.Minolta_FocalLength
+	Minolta_FocusArea              = v2.Minolta_FocusArea
+	Minolta_FocusMode              = v2.Minolta_FocusMode
+	Minolta_ImageSize              = v2.Minolta_ImageSize
+	Minolta_ImageStabilizationMode = v2.Minolta_ImageStabilizationMode
+	Minolta_IntervalLength         = v2.Minolta_IntervalLength
+	Minolta_IntervalNumber         = v2.Minolta_IntervalNumber
+	Minolta_LastFileNumber         = v2.Minolta_LastFileNumber
+	Minolta_MacroMode              = v2.Minolta_MacroMode
+	Minolta_MakerNoteVersion       = v2.Minolta_MakerNoteVersion
+	Minolta_MaxAperture            = v2.Minolta_MaxAperture
+	Minolta_MeteringMode           = v2.Minolta_MeteringMode
+	Minolta_PreviewImageLength     = v2.Minolta_PreviewImageLength
+	Minolta_PreviewImageStart      = v2.Minolta_PreviewImageStart
+	Minolta_Quality                = v2.Minolta_Quality
+	Minolta_Saturation             = v2.Minolta_Saturation
+	Minolta_SceneMode              = v2.Minolta_SceneMode
+	Minolta_SubjectProgram         = v2.Minolta_SubjectProgram
+	Minolta_Time                   = v2.Minolta_Time
+	Minolta_WhiteBalance           = v2.Minolta_WhiteBalance
+	Minolta_ZoneMatching           = v2.Minolta_ZoneMatching
+	ModelID                        = v2.ModelID
+	NEFCompression                 = v2.NEFCompression
+	Nikon3_0x000a                  = v2.Nikon3_0x000a
+	Nikon3_0x009b                  = v2.Nikon3_0x009b
+	Nikon3_0x009f                  = v2.Nikon3_0x009f
+	Nikon3_0x00a3                  = v2.Nikon3_0x00a3
+	Nikon_AFInfo                   = v2.Nikon_AFInfo
+	Nikon_AFInfo2                  = v2.Nikon_AFInfo2
+	Nikon_AFTune                   = v2.Nikon_AFTune
+	Nikon_ColorBalance             = v2.Nikon_ColorBalance
+	Nikon_ColorSpace               = v2.Nikon_ColorSpace
+	Nikon_FileInfo                 = v2.Nikon_FileInfo
+	Nikon_FlashInfo                = v2.Nikon_FlashInfo
+	Nikon_ISOInfo                  = v2.Nikon_ISOInfo
+	Nikon_LensData                 = v2.Nikon_LensData
+	Nikon_LightSource              = v2.Nikon_LightSource
+	Nikon_MultiExposure            = v2.Nikon_MultiExposure
+	Nikon_PictureControl           = v2.Nikon_PictureControl
+	Nikon_Saturation               = v2.Nikon_Saturation
+	Nikon_SerialNO                 = v2.Nikon_SerialNO
+	Nikon_ShotInfo                 = v2.Nikon_ShotInfo
+	Nikon_VRInfo                   = v2.Nikon_VRInfo
+	Nikon_Version                  = v2.Nikon_Version
+	Nikon_WhiteBalance             = v2.Nikon_WhiteBalance
+	Nikon_WorldTime                = v2.Nikon_WorldTime
+	NoiseReduction                 = v2.NoiseReduction
+	Olympus_CameraSettings         = v2.Olympus_CameraSettings
+	Olympus_CameraType             = v2.Olympus_CameraType
+	Olympus_DriveMode              = v2.Olympus_DriveMode
+	Olympus_FocusMode              = v2.Olympus_FocusMode
+	Olympus_MakerNoteVersion       = v2.Olympus_MakerNoteVersion
+	Olympus_StackedImage           = v2.Olympus_StackedImage
+	OriginalDecisionDataOffset     = v2.OriginalDecisionDataOffset
+	OwnerName                      = v2.OwnerName
+	Panorama                       = v2.Panorama
+	PhaseOne_ApertureValue         = v2.PhaseOne_ApertureValue
+	PhaseOne_BlackLevel            = v2.PhaseOne_BlackLevel
+	PhaseOne_CameraModel           = v2.PhaseOne_CameraModel
+	PhaseOne_CameraOrientation     = v2.PhaseOne_CameraOrientation
+	PhaseOne_ColorMatrix1          = v2.PhaseOne_ColorMatrix1
+	PhaseOne_ColorMatrix2          = v2.PhaseOne_ColorMatrix2
+	PhaseOne_DateTimeOriginal      = v2.PhaseOne_DateTimeOriginal
+	PhaseOne_ExposureCompensation  = v2.PhaseOne_ExposureCompensation
+	PhaseOne_FirmwareVersions      = v2.PhaseOne_FirmwareVersions
+	PhaseOne_FocalLength           = v2.PhaseOne_FocalLength
+	PhaseOne_ISO                   = v2.PhaseOne_ISO
+	PhaseOne_ImageHeight           = v2.PhaseOne_ImageHeight
+	PhaseOne_ImageNumber           = v2.PhaseOne_ImageNumber
+	PhaseOne_ImageWidth            = v2.PhaseOne_ImageWidth
+	PhaseOne_LensModel             = v2.PhaseOne_LensModel
+	PhaseOne_MaxApertureValue      = v2.PhaseOne_MaxApertureValue
+	PhaseOne_MinApertureValue      = v2.PhaseOne_MinApertureValue
+	PhaseOne_RawFormat             = v2.PhaseOne_RawFormat
+	PhaseOne_SensorHeight          = v2.PhaseOne_SensorHeight
+	PhaseOne_SensorLeftMargin      = v2.PhaseOne_SensorLeftMargin
+	PhaseOne_SensorTemperature     = v2.PhaseOne_SensorTemperature
+	PhaseOne_SensorTemperature2    = v2.PhaseOne_SensorTemperature2
+	PhaseOne_SensorTopMargin       = v2.PhaseOne_SensorTopMargin
+	PhaseOne_SensorWidth           = v2.PhaseOne_SensorWidth
+	PhaseOne_ShutterSpeedValue     = v2.PhaseOne_ShutterSpeedValue
+	PhaseOne_Software              = v2.PhaseOne_Software
+	PhaseOne_System                = v2.PhaseOne_System
+	PhaseOne_WB_RGBLevels          = v2.PhaseOne_WB_RGBLevels
+	PictureInfo                    = v2.PictureInfo
+	Preview                        = v2.Preview
+	PrintIM                        = v2.PrintIM
+	ProcessingInfo                 = v2.ProcessingInfo
+	ProgramShift                   = v2.ProgramShift
+	Quality                        = v2.Quality
+	RawImageCenter                 = v2.RawImageCenter
+	RetouchHistory                 = v2.RetouchHistory
+	Ricoh_ImageInfo                = v2.Ricoh_ImageInfo
+	Ricoh_MakerNoteType            = v2.Ricoh_MakerNoteType
+	Ricoh_Sharpness                = v2.Ricoh_Sharpness
+	Ricoh_Subdir                   = v2.Ricoh_Subdir
+	Ricoh_ThetaSubdir              = v2.Ricoh_ThetaSubdir
+	SaturationText                 = v2.SaturationText
+	ScanIFD                        = v2.ScanIFD
+	SceneAssist                    = v2.SceneAssist
+	SceneMode                      = v2.SceneMode
+	SensorInfo                     = v2.SensorInfo
+	SensorPixelSize                = v2.SensorPixelSize
+	SerialNumber                   = v2.SerialNumber
+	SerialNumberFormat             = v2.SerialNumberFormat
+	Sharpening                     = v2.Sharpening
+	ShootingMode                   = v2.ShootingMode
+	ShutterCount                   = v2.ShutterCount
+	Sigma_AFMode                   = v2.Sigma_AFMode
+	Sigma_AdjustmentMode           = v2.Sigma_AdjustmentMode
+	Sigma_AutoBracket              = v2.Sigma_AutoBracket
+	Sigma_ColorAdjustment          = v2.Sigma_ColorAdjustment
+	Sigma_ColorSpace               = v2.Sigma_ColorSpace
+	Sigma_Contrast                 = v2.Sigma_Contrast
+	Sigma_DriveMode                = v2.Sigma_DriveMode
+	Sigma_ExposureCompensation     = v2.Sigma_ExposureCompensation
+	Sigma_ExposureMode             = v2.Sigma_ExposureMode
+	Sigma_FocusSetting             = v2.Sigma_FocusSetting
+	Sigma_Highlight                = v2.Sigma_Highlight
+	Sigma_LensFocalRange           = v2.Sigma_LensFocalRange
+	Sigma_MeteringMode             = v2.Sigma_MeteringMode
+	Sigma_Quality                  = v2.Sigma_Quality
+	Sigma_ResolutionMode           = v2.Sigma_ResolutionMode
+	Sigma_Saturation               = v2.Sigma_Saturation
+	Sigma_Shadow                   = v2.Sigma_Shadow
+	Sigma_Sharpness                = v2.Sigma_Sharpness
+	Sigma_Software                 = v2.Sigma_Software
+	Sigma_WhiteBalance             = v2.Sigma_WhiteBalance
+	Sigma_X3FillLight              = v2.Sigma_X3FillLight
+	Sony_FileFormat                = v2.Sony_FileFormat
+	Sony_ModelID                   = v2.Sony_ModelID
+	Sony_PixelShiftInfo            = v2.Sony_PixelShiftInfo
+	StackFocusBracket              = v2.StackFocusBracket
+	StackFocusStacked              = v2.StackFocusStacked
+	StackHighRes                   = v2.StackHighRes
+	StackNone                      = v2.StackNone
+	StackPixelShift                = v2.StackPixelShift
+	SuperMacro                     = v2.SuperMacro
+	Theta_Accelerometer            = v2.Theta_Accelerometer
+	Theta_Compass                  = v2.Theta_Compass
+	Theta_ExposureTime             = v2.Theta_ExposureTime
+	Theta_FNumber                  = v2.Theta_FNumber
+	Theta_ISO                      = v2.Theta_ISO
+	Theta_SerialNumber             = v2.Theta_SerialNumber
+	Theta_TimeZone                 = v2.Theta_TimeZone
+	ThumbnailImageValidArea        = v2.ThumbnailImageValidArea
+	ToneComp                       = v2.ToneComp
+	ToningEffect                   = v2.ToningEffect
+	VRDOffset                      = v2.VRDOffset
+	VariProgram                    = v2.VariProgram
+	VignetteControl                = v2.VignetteControl
+	WB_RBLevels                    = v2.WB_RBLevels
+	WhiteBalanceBias               = v2.WhiteBalanceBias
+	WhiteBalanceTable              = v2.WhiteBalanceTable
+)
+
+var (
+	All           = v2.All
+	Canon         = v2.Canon
+	Casio         = v2.Casio
+	ErrNoAFPoints = v2.ErrNoAFPoints
+	ErrNoBulb     = v2.ErrNoBulb
+	ErrNoFirmware = v2.ErrNoFirmware
+	ErrNoStack    = v2.ErrNoStack
+	GoPro         = v2.GoPro
+	Hasselblad    = v2.Hasselblad
+	Kodak         = v2.Kodak
+	Minolta       = v2.Minolta
+	NikonV3       = v2.NikonV3
+	Olympus       = v2.Olympus
+	PhaseOne      = v2.PhaseOne
+	Ricoh         = v2.Ricoh
+	Sigma         = v2.Sigma
+	Sony          = v2.Sony
+)
+
+func AFPoints(x *exif.Exif) ([]AFPoint, error) {
+	return v2.AFPoints(x)
+}
+
+func BulbDuration(x *exif.Exif) (time.Duration, error) {
+	return v2.BulbDuration(x)
+}
+
+func EffectiveExposure(x *exif.Exif) (time.Duration, error) {
+	return v2.EffectiveExposure(x)
+}
+
+func ExtractGPMF(r io.Reader) ([]byte, error) {
+	return v2.ExtractGPMF(r)
+}
+
+func Firmware(x *exif.Exif) (FirmwareInfo, error) {
+	return v2.Firmware(x)
+}
+
+func LoadGPMF(x *exif.Exif, entries []GPMFEntry) error {
+	return v2.LoadGPMF(x, entries)
+}
+
+func ParseGPMF(data []byte) ([]GPMFEntry, error) {
+	return v2.ParseGPMF(data)
+}
+
+func StackInfo(x *exif.Exif) (Stack, error) {
+	return v2.StackInfo(x)
+}
+
+func ThetaCompass(x *exif.Exif) (float64, error) {
+	return v2.ThetaCompass(x)
+}
+
+func ThetaZenith(x *exif.Exif) (float64, float64, error) {
+	return v2.ThetaZenith(x)
+}
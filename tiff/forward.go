@@ -0,0 +1,113 @@
+// Code generated by gencompat; DO NOT EDIT.
+
+package tiff
+
+import (
+	"encoding/binary"
+	"io"
+
+	v2 "github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type (
+	DataType     = v2.DataType
+	Dir          = v2.Dir
+	Format       = v2.Format
+	IndexError   = v2.IndexError
+	Interner     = v2.Interner
+	JSONOptions  = v2.JSONOptions
+	RatMode      = v2.RatMode
+	ReadAtReader = v2.ReadAtReader
+	Reader       = v2.Reader
+	StringMode   = v2.StringMode
+	SubDir       = v2.SubDir
+	SubIFDLimits = v2.SubIFDLimits
+	Tag          = v2.Tag
+	TagDiff      = v2.TagDiff
+	Tiff         = v2.Tiff
+)
+
+const (
+	DTAscii               = v2.DTAscii
+	DTByte                = v2.DTByte
+	DTDouble              = v2.DTDouble
+	DTFloat               = v2.DTFloat
+	DTIFD                 = v2.DTIFD
+	DTLong                = v2.DTLong
+	DTRational            = v2.DTRational
+	DTSByte               = v2.DTSByte
+	DTSLong               = v2.DTSLong
+	DTSRational           = v2.DTSRational
+	DTSShort              = v2.DTSShort
+	DTShort               = v2.DTShort
+	DTUTF8                = v2.DTUTF8
+	DTUndefined           = v2.DTUndefined
+	DefaultMaxSubIFDDepth = v2.DefaultMaxSubIFDDepth
+	DefaultMaxSubIFDs     = v2.DefaultMaxSubIFDs
+	FloatVal              = v2.FloatVal
+	IntVal                = v2.IntVal
+	OtherVal              = v2.OtherVal
+	RatVal                = v2.RatVal
+	RatsDecimal           = v2.RatsDecimal
+	RatsFraction          = v2.RatsFraction
+	RatsSimplified        = v2.RatsSimplified
+	StringVal             = v2.StringVal
+	StringsASCII          = v2.StringsASCII
+	StringsBase64         = v2.StringsBase64
+	StringsUTF8           = v2.StringsUTF8
+	UndefVal              = v2.UndefVal
+)
+
+var (
+	ErrShortReadTagValue = v2.ErrShortReadTagValue
+	ErrSubIFDDepth       = v2.ErrSubIFDDepth
+	ErrTooManySubIFDs    = v2.ErrTooManySubIFDs
+)
+
+func ConvertOrder(t *Tiff, order binary.ByteOrder) {
+	v2.ConvertOrder(t, order)
+}
+
+func Decode(r io.Reader) (*Tiff, error) {
+	return v2.Decode(r)
+}
+
+func DecodeDir(r ReadAtReader, order binary.ByteOrder) (*Dir, int32, error) {
+	return v2.DecodeDir(r, order)
+}
+
+func DecodeDirFunc(r ReadAtReader, order binary.ByteOrder, keep func(id uint16) bool) (*Dir, int32, error) {
+	return v2.DecodeDirFunc(r, order, keep)
+}
+
+func DecodeEntry(entry []byte, r io.ReaderAt, order binary.ByteOrder) (*Tag, error) {
+	return v2.DecodeEntry(entry, r, order)
+}
+
+func DecodeSubIFDs(r io.ReaderAt, order binary.ByteOrder, d *Dir, lim SubIFDLimits) ([]*SubDir, error) {
+	return v2.DecodeSubIFDs(r, order, d, lim)
+}
+
+func DecodeTag(r ReadAtReader, order binary.ByteOrder) (*Tag, error) {
+	return v2.DecodeTag(r, order)
+}
+
+func DiffDirs(a *Dir, b *Dir) []TagDiff {
+	return v2.DiffDirs(a, b)
+}
+
+func DiffTiff(a *Tiff, b *Tiff) []TagDiff {
+	return v2.DiffTiff(a, b)
+}
+
+func NewInterner() *Interner {
+	return v2.NewInterner()
+}
+
+func NewReader(r io.ReaderAt) (*Reader, error) {
+	return v2.NewReader(r)
+}
+
+func NewTag(id uint16, typ DataType, order binary.ByteOrder, vals ...interface{}) (*Tag, error) {
+	return v2.NewTag(id, typ, order, vals...)
+}
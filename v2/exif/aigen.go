@@ -0,0 +1,140 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// AIVerdict is how strongly the metadata of an image indicates that it was
+// generated by AI.
+type AIVerdict int
+
+const (
+	AINone     AIVerdict = iota // no indication
+	AIPossible                  // circumstantial evidence only
+	AILikely                    // written by a known generator
+	AIDeclared                  // declared as AI generated
+)
+
+var aiVerdictNames = map[AIVerdict]string{
+	AINone:     "none",
+	AIPossible: "possible",
+	AILikely:   "likely",
+	AIDeclared: "declared",
+}
+
+func (v AIVerdict) String() string {
+	if n, ok := aiVerdictNames[v]; ok {
+		return n
+	}
+	return fmt.Sprintf("AIVerdict(%d)", int(v))
+}
+
+// AIDetection is the result of DetectAI.
+type AIDetection struct {
+	Verdict AIVerdict
+	// SourceType is the IPTC digital source type declared in the XMP data,
+	// e.g. "trainedAlgorithmicMedia", or empty.
+	SourceType string
+	// Generator is the AI generator named by the Software field or the XMP
+	// creator tool, or empty.
+	Generator string
+	// C2PA is true if the image carries a C2PA (content credentials)
+	// manifest.
+	C2PA bool
+	// Reasons lists the evidence the verdict is based on.
+	Reasons []string
+}
+
+// aiSourceTypes are the IPTC digital source types of AI generated media.
+var aiSourceTypes = []string{"trainedAlgorithmicMedia", "compositeWithTrainedAlgorithmicMedia"}
+
+// aiGenerators are name fragments (lower case) of AI image generators as
+// they appear in Software fields.
+var aiGenerators = []string{
+	"dall-e", "dall·e", "midjourney", "stable diffusion", "stablediffusion",
+	"firefly", "novelai", "comfyui", "automatic1111", "invokeai",
+	"leonardo.ai", "ideogram",
+}
+
+// DetectAI looks for markers of AI generated images: an IPTC digital source
+// type declared in the XMP data or in a C2PA manifest, the Software field
+// or XMP creator tool naming a known generator, and, as supporting
+// evidence, the absence of the fields cameras write.  It only reads
+// metadata, which is easily removed or forged: AINone does not mean the
+// image is not AI generated.
+func (x *Exif) DetectAI() AIDetection {
+	var d AIDetection
+
+	d.SourceType = xmpSourceType(x.xmp)
+	d.C2PA = bytes.Contains(x.jumbf, []byte("c2pa"))
+	for _, typ := range aiSourceTypes {
+		if d.SourceType == typ {
+			d.Verdict = AIDeclared
+			d.Reasons = append(d.Reasons, "XMP digital source type is "+typ)
+		}
+		if d.C2PA && bytes.Contains(x.jumbf, []byte("digitalsourcetype/"+typ)) {
+			d.Verdict = AIDeclared
+			d.Reasons = append(d.Reasons, "C2PA manifest declares digital source type "+typ)
+		}
+	}
+
+	software, _ := x.stringVal(Software)
+	for _, tool := range []string{software, xmpValue(x.xmp, "xmp:CreatorTool")} {
+		if tool != "" && containsAny(strings.ToLower(tool), aiGenerators) && d.Generator == "" {
+			d.Generator = tool
+			d.Reasons = append(d.Reasons, fmt.Sprintf("generated by %q", tool))
+			if d.Verdict < AILikely {
+				d.Verdict = AILikely
+			}
+		}
+	}
+
+	camera := false
+	for _, name := range cameraFields {
+		if _, err := x.Get(name); err == nil {
+			camera = true
+		}
+	}
+	if d.C2PA && d.Verdict == AINone && !camera {
+		d.Verdict = AIPossible
+		d.Reasons = append(d.Reasons, "C2PA manifest without camera fields")
+	}
+	if d.Verdict != AINone && !camera {
+		d.Reasons = append(d.Reasons, "no camera fields")
+	}
+	return d
+}
+
+// xmpSourceType returns the last path element of the IPTC digital source
+// type of the XMP packet, if any.
+func xmpSourceType(xmp []byte) string {
+	v := xmpValue(xmp, "Iptc4xmpExt:DigitalSourceType")
+	if i := strings.LastIndex(v, "/"); i >= 0 {
+		v = v[i+1:]
+	}
+	return v
+}
+
+// xmpValue returns the value of the property name of the XMP packet,
+// written as an attribute or a simple element, or "" if there is none.
+func xmpValue(xmp []byte, name string) string {
+	s := string(xmp)
+	for _, pat := range []struct{ start, end string }{
+		{name + `="`, `"`},
+		{name + `='`, `'`},
+		{"<" + name + ">", "<"},
+		{"<" + name + ` rdf:resource="`, `"`},
+	} {
+		i := strings.Index(s, pat.start)
+		if i < 0 {
+			continue
+		}
+		rest := s[i+len(pat.start):]
+		if j := strings.Index(rest, pat.end); j >= 0 {
+			return strings.TrimSpace(rest[:j])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package exif
+
+import "testing"
+
+func TestDetectAI(t *testing.T) {
+	const iptc = "http://cv.iptc.org/newscodes/digitalsourcetype/"
+	tests := []struct {
+		name     string
+		xmp      string
+		jumbf    string
+		software string
+		camera   bool
+		want     AIVerdict
+	}{
+		{name: "camera", camera: true, want: AINone},
+		{name: "bare", want: AINone},
+		{
+			name: "declared",
+			xmp:  `<rdf:Description Iptc4xmpExt:DigitalSourceType="` + iptc + `trainedAlgorithmicMedia"/>`,
+			want: AIDeclared,
+		},
+		{
+			name: "declared element",
+			xmp:  `<Iptc4xmpExt:DigitalSourceType>` + iptc + `compositeWithTrainedAlgorithmicMedia</Iptc4xmpExt:DigitalSourceType>`,
+			want: AIDeclared,
+		},
+		{
+			name: "photo source type",
+			xmp:  `<rdf:Description Iptc4xmpExt:DigitalSourceType="` + iptc + `digitalCapture"/>`,
+			want: AINone,
+		},
+		{name: "c2pa declared", jumbf: "jumd c2pa ... " + iptc + "trainedAlgorithmicMedia", want: AIDeclared},
+		{name: "c2pa", jumbf: "jumd c2pa c2pa.actions", want: AIPossible},
+		{name: "c2pa camera", jumbf: "jumd c2pa c2pa.actions", camera: true, want: AINone},
+		{name: "generator", software: "Midjourney v6", want: AILikely},
+		{name: "creator tool", xmp: `<rdf:Description xmp:CreatorTool="Adobe Firefly"/>`, want: AILikely},
+	}
+	for _, tt := range tests {
+		x := &Exif{xmp: []byte(tt.xmp), jumbf: []byte(tt.jumbf)}
+		if tt.software != "" {
+			x.setTag(Software, testString(t, tt.software))
+		}
+		if tt.camera {
+			x.setTag(ExposureTime, testRational(t, 1, 250))
+		}
+		d := x.DetectAI()
+		if d.Verdict != tt.want {
+			t.Errorf("%s: got %v (%v), want %v", tt.name, d.Verdict, d.Reasons, tt.want)
+		}
+		if d.Verdict != AINone && len(d.Reasons) == 0 {
+			t.Errorf("%s: no reasons", tt.name)
+		}
+	}
+}
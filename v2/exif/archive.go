@@ -0,0 +1,101 @@
+package exif
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path"
+	"strings"
+)
+
+// archiveExts are the extensions (lower case) of the archive members
+// decoded by DecodeTar and DecodeZip.
+var archiveExts = map[string]bool{
+	".jpg": true, ".jpeg": true, ".jpe": true,
+	".tif": true, ".tiff": true, ".dng": true,
+	".cr2": true, ".cr3": true, ".nef": true, ".nrw": true, ".arw": true,
+	".orf": true, ".rw2": true, ".pef": true, ".raf": true, ".srw": true,
+	".x3f": true, ".psd": true, ".webp": true,
+	".mp4": true, ".mov": true, ".avi": true,
+}
+
+// isArchiveImage reports whether the archive member name is decoded.
+func isArchiveImage(name string) bool {
+	return archiveExts[strings.ToLower(path.Ext(name))]
+}
+
+// DecodeTar decodes the images of the tar archive read from r, which may be
+// gzip compressed, without extracting it: each member is decoded as it
+// streams past.  Members are selected by their extension (JPEG, TIFF, raw,
+// Photoshop and video files).  A result is sent on the returned channel
+// for each, named after the member, in archive order; an error reading
+// the archive itself is sent last, with an empty Name.  The channel is
+// closed at the end of the archive and must be drained.
+func (d *Decoder) DecodeTar(r io.Reader) <-chan BatchResult {
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		br := bufio.NewReader(r)
+		if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1F, 0x8B}) {
+			zr, err := gzip.NewReader(br)
+			if err != nil {
+				out <- BatchResult{Err: err}
+				return
+			}
+			defer zr.Close()
+			r = zr
+		} else {
+			r = br
+		}
+
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return
+			} else if err != nil {
+				out <- BatchResult{Err: err}
+				return
+			}
+			if hdr.Typeflag != tar.TypeReg || !isArchiveImage(hdr.Name) {
+				continue
+			}
+			res := BatchResult{Name: hdr.Name}
+			res.Exif, res.Err = d.Decode(tr)
+			out <- res
+		}
+	}()
+	return out
+}
+
+// DecodeZip decodes the images of the zip archive of size bytes in r, as
+// DecodeTar does.  Only the start of each member is decompressed.
+func (d *Decoder) DecodeZip(r io.ReaderAt, size int64) <-chan BatchResult {
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		zr, err := zip.NewReader(r, size)
+		if err != nil {
+			out <- BatchResult{Err: err}
+			return
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() || !isArchiveImage(f.Name) {
+				continue
+			}
+			res := BatchResult{Name: f.Name}
+			rc, err := f.Open()
+			if err != nil {
+				res.Err = err
+			} else {
+				res.Exif, res.Err = d.Decode(rc)
+				rc.Close()
+			}
+			out <- res
+		}
+	}()
+	return out
+}
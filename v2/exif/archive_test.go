@@ -0,0 +1,85 @@
+package exif
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// archiveFiles are the members of the test archives: two images, one
+// without EXIF data, and a text file that is skipped.
+func archiveFiles(t *testing.T) map[string][]byte {
+	jpg, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string][]byte{
+		"a/IMG_0001.JPG": jpg,
+		"a/blank.jpg":    jpegNoExif(16),
+		"a/notes.txt":    []byte("not an image"),
+	}
+}
+
+func checkArchive(t *testing.T, results <-chan BatchResult) {
+	got := map[string]BatchResult{}
+	for res := range results {
+		got[res.Name] = res
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d results, want 2", len(got))
+	}
+	if res := got["a/IMG_0001.JPG"]; res.Err != nil || res.Exif == nil {
+		t.Errorf("IMG_0001.JPG: %v", res.Err)
+	} else if s, _ := res.Exif.stringVal(Model); s != "Synth 1" {
+		t.Errorf("IMG_0001.JPG: Model = %q", s)
+	}
+	if res := got["a/blank.jpg"]; res.Err != ErrNoExif {
+		t.Errorf("blank.jpg: got error %v, want ErrNoExif", res.Err)
+	}
+}
+
+func TestDecodeTar(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	tw.WriteHeader(&tar.Header{Name: "a/", Typeflag: tar.TypeDir, Mode: 0755})
+	for name, data := range archiveFiles(t) {
+		tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data))})
+		tw.Write(data)
+	}
+	tw.Close()
+	zw.Close()
+
+	d := &Decoder{}
+	checkArchive(t, d.DecodeTar(&buf))
+
+	var errs int
+	for res := range d.DecodeTar(bytes.NewReader([]byte("not a tar archive"))) {
+		if res.Err != nil && res.Name == "" {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("got %d archive errors, want 1", errs)
+	}
+}
+
+func TestDecodeZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range archiveFiles(t) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	zw.Close()
+
+	d := &Decoder{}
+	checkArchive(t, d.DecodeZip(bytes.NewReader(buf.Bytes()), int64(buf.Len())))
+}
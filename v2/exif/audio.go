@@ -0,0 +1,34 @@
+package exif
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNoAudio is returned by Audio for images without embedded audio.
+var ErrNoAudio = errors.New("exif: no embedded audio")
+
+// RelatedSoundFile returns the name of the audio file recorded with the
+// image, e.g. a voice memo "DSC00001.WAV" stored next to it.  It returns a
+// TagNotPresentError if the field is missing or blank.
+func (x *Exif) RelatedSoundFile() (string, error) {
+	s, err := x.stringVal(RelatedSoundFile)
+	if err != nil {
+		return "", err
+	}
+	if s = strings.TrimSpace(s); s == "" {
+		return "", TagNotPresentError(RelatedSoundFile)
+	}
+	return s, nil
+}
+
+// Audio returns the WAV file (a RIFF WAVE file) of the voice memo some
+// cameras embed in JPEG APPn segments, split across several segments of the
+// same marker if needed.  Only the segments before the image data are
+// read.
+func (x *Exif) Audio() ([]byte, error) {
+	if x.audio == nil {
+		return nil, ErrNoAudio
+	}
+	return x.audio, nil
+}
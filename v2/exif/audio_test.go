@@ -0,0 +1,52 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestAudio(t *testing.T) {
+	exifData, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := func(marker byte, data []byte) []byte {
+		return append([]byte{0xFF, marker, byte((len(data) + 2) >> 8), byte(len(data) + 2)}, data...)
+	}
+	wav := []byte("RIFF\x00\x00\x00\x00WAVEfmt ")
+	wav = append(wav, make([]byte, 88)...)
+	binary.LittleEndian.PutUint32(wav[4:], uint32(len(wav)-8))
+	intro := "AUDIO\x00"
+
+	// The WAV file split across two APP5 segments, the last one padded,
+	// followed by an unrelated APP5 segment.
+	app1End := 4 + int(exifData[4])<<8 + int(exifData[5])
+	jpg := append([]byte{}, exifData[:app1End]...)
+	jpg = append(jpg, seg(0xE5, append([]byte(intro), wav[:60]...))...)
+	jpg = append(jpg, seg(0xE5, append([]byte(intro), append(wav[60:], 0, 0)...))...)
+	jpg = append(jpg, seg(0xE5, []byte(intro+"other"))...)
+	jpg = append(jpg, exifData[app1End:]...)
+
+	x, err := Decode(bytes.NewReader(jpg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := x.Audio(); err != nil || !bytes.Equal(got, wav) {
+		t.Errorf("Audio() = %q, %v", got, err)
+	}
+	if _, err := x.RelatedSoundFile(); !IsTagNotPresentError(err) {
+		t.Errorf("RelatedSoundFile() of image without it: %v", err)
+	}
+
+	x = &Exif{}
+	if _, err := x.Audio(); err != ErrNoAudio {
+		t.Errorf("Audio() of empty Exif: %v", err)
+	}
+	x.setTag(RelatedSoundFile, testString(t, "DSC00001.WAV"))
+	if s, err := x.RelatedSoundFile(); err != nil || s != "DSC00001.WAV" {
+		t.Errorf("RelatedSoundFile() = %q, %v", s, err)
+	}
+}
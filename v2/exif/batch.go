@@ -0,0 +1,130 @@
+package exif
+
+import (
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is the error of files whose decoding exceeded
+// BatchDecoder.Timeout.
+var ErrTimeout = errors.New("exif: decode timed out")
+
+// BatchResult is the outcome of decoding one file of a batch.
+type BatchResult struct {
+	Name string
+	Exif *Exif
+	Err  error
+}
+
+// BatchDecoder decodes many files concurrently with a bounded number of
+// workers.
+type BatchDecoder struct {
+	// Decoder is used to decode each file; nil means the zero Decoder.
+	// Its options (e.g. Permissive or InternStrings) apply to all files.
+	Decoder *Decoder
+
+	// Workers is the number of files decoded concurrently.  Zero means
+	// runtime.NumCPU().
+	Workers int
+
+	// Timeout, if positive, limits the time spent decoding each file.  It
+	// is checked as the file is read and before each parser is run, so a
+	// single blocked read is not interrupted.
+	Timeout time.Duration
+
+	// Interval, if positive, is the minimum time between starting to decode
+	// two files, limiting the rate of file accesses.
+	Interval time.Duration
+
+	// Open opens the named file; nil means os.Open.
+	Open func(name string) (io.ReadCloser, error)
+
+	// Stats, if non-nil, is called after each file is decoded with the
+	// result and the time taken.  It may be called concurrently.
+	Stats func(res BatchResult, elapsed time.Duration)
+}
+
+// Run decodes the files named on names, sending a result for each on the
+// returned channel, which is closed once names is closed and all files are
+// done.  Results are sent as files complete, which need not be the order of
+// names.
+func (b *BatchDecoder) Run(names <-chan string) <-chan BatchResult {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if b.Interval > 0 {
+		ticker = time.NewTicker(b.Interval)
+		tick = ticker.C
+	}
+
+	out := make(chan BatchResult)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if tick != nil {
+					<-tick
+				}
+				out <- b.decodeFile(name)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(out)
+	}()
+	return out
+}
+
+func (b *BatchDecoder) decodeFile(name string) BatchResult {
+	start := time.Now()
+	res := BatchResult{Name: name}
+	defer func() {
+		if b.Stats != nil {
+			b.Stats(res, time.Since(start))
+		}
+	}()
+
+	open := b.Open
+	if open == nil {
+		open = func(name string) (io.ReadCloser, error) { return os.Open(name) }
+	}
+	f, err := open(name)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer f.Close()
+
+	d := b.Decoder
+	if d == nil {
+		d = &Decoder{}
+	}
+	progress := d.Progress
+	if b.Timeout > 0 {
+		deadline := start.Add(b.Timeout)
+		progress = func(stage Stage, n int64) error {
+			if time.Now().After(deadline) {
+				return ErrTimeout
+			}
+			if d.Progress != nil {
+				return d.Progress(stage, n)
+			}
+			return nil
+		}
+	}
+	res.Exif, res.Err = d.decodeProgress(f, progress)
+	return res
+}
@@ -0,0 +1,79 @@
+package exif
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchDecoder(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join(*dataDir, "testdata", "synth", "*"))
+	if err != nil || len(names) == 0 {
+		t.Fatalf("no samples: %v", err)
+	}
+
+	var mu sync.Mutex
+	stats := 0
+	b := &BatchDecoder{
+		Decoder: &Decoder{Permissive: true},
+		Workers: 3,
+		Stats: func(res BatchResult, elapsed time.Duration) {
+			mu.Lock()
+			stats++
+			mu.Unlock()
+		},
+	}
+	in := make(chan string)
+	go func() {
+		for _, name := range names {
+			in <- name
+		}
+		close(in)
+	}()
+
+	seen := map[string]bool{}
+	for res := range b.Run(in) {
+		seen[res.Name] = true
+		critical := strings.Contains(res.Name, "corrupt_entry_count") || strings.Contains(res.Name, "truncated")
+		if !critical && res.Exif == nil {
+			t.Errorf("%v: %v", res.Name, res.Err)
+		}
+	}
+	if len(seen) != len(names) || stats != len(names) {
+		t.Errorf("got %d results and %d stats calls for %d files", len(seen), stats, len(names))
+	}
+}
+
+// slowReader delays every read.
+type slowReader struct {
+	io.Reader
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(5 * time.Millisecond)
+	if len(p) > 16 {
+		p = p[:16]
+	}
+	return r.Reader.Read(p)
+}
+
+func TestBatchDecoderTimeout(t *testing.T) {
+	b := &BatchDecoder{
+		Timeout: 20 * time.Millisecond,
+		Open: func(name string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(slowReader{strings.NewReader(strings.Repeat("\xFF", 4096))}), nil
+		},
+	}
+	in := make(chan string, 1)
+	in <- "slow"
+	close(in)
+	for res := range b.Run(in) {
+		if res.Err != ErrTimeout {
+			t.Errorf("got error %v, want ErrTimeout", res.Err)
+		}
+	}
+}
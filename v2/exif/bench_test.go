@@ -0,0 +1,111 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+// synthTIFF returns a little endian TIFF structure of a chain of ifds IFDs
+// holding tags fields each: Make and Model, then ASCII fields of valSize
+// bytes with unknown tag IDs.  The content depends on the arguments only,
+// so benchmarks are reproducible without sample files.
+func synthTIFF(ifds, tags, valSize int) []byte {
+	order := binary.LittleEndian
+	b := []byte("II*\x00\x08\x00\x00\x00")
+	val := bytes.Repeat([]byte("x"), valSize-1)
+	for d := 0; d < ifds; d++ {
+		off := len(b)
+		dirSize := 2 + 12*tags + 4
+		vals := off + dirSize
+		b = append(b, make([]byte, dirSize)...)
+		order.PutUint16(b[off:], uint16(tags))
+		for i := 0; i < tags; i++ {
+			e := off + 2 + 12*i
+			id, v := uint16(0x1000+i), append(val, 0)
+			switch i {
+			case 0:
+				id, v = 0x010F, []byte("Synth\x00")
+			case 1:
+				id, v = 0x0110, []byte(fmt.Sprintf("Synth %d\x00", d))
+			}
+			order.PutUint16(b[e:], id)
+			order.PutUint16(b[e+2:], 2)
+			order.PutUint32(b[e+4:], uint32(len(v)))
+			if len(v) <= 4 {
+				copy(b[e+8:], v)
+				continue
+			}
+			order.PutUint32(b[e+8:], uint32(vals))
+			v = append(v, make([]byte, len(v)%2)...)
+			b = append(b, v...)
+			vals += len(v)
+		}
+		if d < ifds-1 {
+			order.PutUint32(b[off+dirSize-4:], uint32(len(b)))
+		}
+	}
+	return b
+}
+
+var synthSizes = []struct{ ifds, tags, valSize int }{
+	{1, 10, 16},
+	{1, 1000, 16},
+	{2, 100, 1024},
+	{16, 100, 16},
+}
+
+func BenchmarkDecodeSynthetic(b *testing.B) {
+	for _, s := range synthSizes {
+		data := synthTIFF(s.ifds, s.tags, s.valSize)
+		b.Run(fmt.Sprintf("ifds=%d/tags=%d/size=%d", s.ifds, s.tags, s.valSize), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Decode(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeIndexSynthetic(b *testing.B) {
+	for _, s := range synthSizes {
+		data := synthTIFF(s.ifds, s.tags, s.valSize)
+		b.Run(fmt.Sprintf("ifds=%d/tags=%d/size=%d", s.ifds, s.tags, s.valSize), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				x, err := new(Decoder).DecodeIndex(bytes.NewReader(data), int64(len(data)))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := x.Get(Model); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSynthTIFF(t *testing.T) {
+	data := synthTIFF(2, 50, 32)
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(x.Tiff.Dirs); n != 2 {
+		t.Errorf("%d IFDs, want 2", n)
+	}
+	for _, d := range x.Tiff.Dirs {
+		if len(d.Tags) != 50 {
+			t.Errorf("IFD of %d tags, want 50", len(d.Tags))
+		}
+	}
+	if tag, err := x.Get(Model); err != nil || tag.String() != `"Synth 0"` {
+		t.Errorf("Model = %v, %v", tag, err)
+	}
+	if ws := x.ValidateLayout(); len(ws) > 0 {
+		t.Errorf("layout warnings: %v", ws)
+	}
+}
@@ -0,0 +1,171 @@
+package exif
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Burst is a group of images that were captured in quick succession by the
+// same camera.
+type Burst struct {
+	// Indices holds the positions (into the slice passed to GroupBursts) of
+	// the images in this group, in capture order.
+	Indices []int
+	// Bracketed is true when the images in the group were taken with
+	// differing exposure compensation (e.g. an HDR bracket).
+	Bracketed bool
+	// Biases holds the ExposureBiasValue, in stops, of each image in the
+	// group.  It is only filled in if Bracketed is true.
+	Biases []float64
+}
+
+// burstFrame caches the per-image values used while grouping.
+type burstFrame struct {
+	idx    int
+	t      time.Time
+	camera string
+	seq    int // -1 if unknown
+	bias   float64
+}
+
+// GroupBursts groups images into bursts and exposure brackets.  Images are
+// ordered by capture time and consecutive images from the same camera are
+// placed in one group if they were taken no more than maxGap apart.  A group
+// is additionally split when a makernote sequence number restarts, or when
+// the exposure bias pattern of a bracket starts repeating (e.g. 0,-2,+2,
+// 0,-2,+2 yields two brackets).
+//
+// Images without a usable capture time are returned in single-image groups
+// after all other groups.
+func GroupBursts(xs []*Exif, maxGap time.Duration) []Burst {
+	var frames []burstFrame
+	var untimed []int
+	for i, x := range xs {
+		t, err := x.captureTime()
+		if err != nil {
+			untimed = append(untimed, i)
+			continue
+		}
+		f := burstFrame{idx: i, t: t, camera: x.camera(), seq: x.sequenceNumber()}
+		f.bias, _ = x.ratFloat(ExposureBiasValue)
+		frames = append(frames, f)
+	}
+	sort.SliceStable(frames, func(i, j int) bool {
+		if frames[i].t.Equal(frames[j].t) {
+			return frames[i].seq < frames[j].seq
+		}
+		return frames[i].t.Before(frames[j].t)
+	})
+
+	var groups [][]burstFrame
+	var cur []burstFrame
+	for _, f := range frames {
+		if len(cur) > 0 && !continuesBurst(cur, f, maxGap) {
+			groups = append(groups, cur)
+			cur = nil
+		}
+		cur = append(cur, f)
+	}
+	if len(cur) > 0 {
+		groups = append(groups, cur)
+	}
+
+	var bursts []Burst
+	for _, g := range groups {
+		b := Burst{}
+		for _, f := range g {
+			b.Indices = append(b.Indices, f.idx)
+			if f.bias != g[0].bias {
+				b.Bracketed = true
+			}
+		}
+		if b.Bracketed {
+			for _, f := range g {
+				b.Biases = append(b.Biases, f.bias)
+			}
+		}
+		bursts = append(bursts, b)
+	}
+	for _, i := range untimed {
+		bursts = append(bursts, Burst{Indices: []int{i}})
+	}
+	return bursts
+}
+
+// continuesBurst reports whether f belongs to the same group as the frames
+// already collected in cur.
+func continuesBurst(cur []burstFrame, f burstFrame, maxGap time.Duration) bool {
+	prev := cur[len(cur)-1]
+	if f.camera != prev.camera || f.t.Sub(prev.t) > maxGap {
+		return false
+	}
+	if f.seq >= 0 && prev.seq >= 0 && f.seq <= prev.seq {
+		return false
+	}
+
+	// A bracket repeats when its first bias value comes around again after
+	// the bias has already varied within the group.
+	if f.bias == cur[0].bias {
+		for _, c := range cur[1:] {
+			if c.bias != cur[0].bias {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// captureTime returns DateTime() refined with the sub-second field, if any.
+func (x *Exif) captureTime() (time.Time, error) {
+	t, err := x.DateTime()
+	if err != nil {
+		return t, err
+	}
+	sub := SubSecTimeOriginal
+	if _, err := x.Get(DateTimeOriginal); err != nil {
+		sub = SubSecTime
+	}
+	tag, err := x.Get(sub)
+	if err != nil {
+		return t, nil
+	}
+	s, err := tag.StringVal()
+	if err != nil {
+		return t, nil
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return t, nil
+	}
+	frac, err := strconv.ParseFloat("0."+s, 64)
+	if err != nil {
+		return t, nil
+	}
+	return t.Add(time.Duration(frac * float64(time.Second))), nil
+}
+
+// camera returns a string identifying the capturing camera body.
+func (x *Exif) camera() string {
+	var parts []string
+	for _, name := range []FieldName{Make, Model} {
+		if tag, err := x.Get(name); err == nil {
+			s, _ := tag.StringVal()
+			parts = append(parts, strings.TrimSpace(s))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// sequenceNumber returns the in-burst frame number recorded in the
+// makernote, or -1 if none is available.
+func (x *Exif) sequenceNumber() int {
+	// Canon stores the sequence number at index 9 of the ShotInfo array.
+	if tag, err := x.Get("Canon.ShotInfo"); err == nil && tag.Count > 9 {
+		if n, err := tag.Int(9); err == nil {
+			return n
+		}
+	}
+	return -1
+}
@@ -0,0 +1,81 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// testTag builds a big endian encoded tiff tag holding val and decodes it.
+func testTag(t *testing.T, typ tiff.DataType, count uint32, val []byte) *tiff.Tag {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint16(0))
+	binary.Write(&buf, binary.BigEndian, typ)
+	binary.Write(&buf, binary.BigEndian, count)
+	if len(val) > 4 {
+		binary.Write(&buf, binary.BigEndian, uint32(12))
+		buf.Write(val)
+	} else {
+		buf.Write(append(val, make([]byte, 4-len(val))...))
+	}
+	tag, err := tiff.DecodeTag(bytes.NewReader(buf.Bytes()), binary.BigEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tag
+}
+
+func testString(t *testing.T, s string) *tiff.Tag {
+	return testTag(t, tiff.DTAscii, uint32(len(s)+1), append([]byte(s), 0))
+}
+
+func testSRational(t *testing.T, num, den int32) *tiff.Tag {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, num)
+	binary.Write(&buf, binary.BigEndian, den)
+	return testTag(t, tiff.DTSRational, 1, buf.Bytes())
+}
+
+func burstExif(t *testing.T, tm time.Time, bias int32) *Exif {
+	x := &Exif{}
+	x.setTag(Make, testString(t, "Canon"))
+	x.setTag(Model, testString(t, "Canon EOS 5D"))
+	x.setTag(DateTimeOriginal, testString(t, tm.Format("2006:01:02 15:04:05")))
+	x.setTag(SubSecTimeOriginal, testString(t, fmt.Sprintf("%02d", tm.Nanosecond()/1e7)))
+	x.setTag(ExposureBiasValue, testSRational(t, bias, 1))
+	return x
+}
+
+func TestGroupBursts(t *testing.T) {
+	t0 := time.Date(2020, 5, 1, 12, 0, 0, 0, time.Local)
+	ms := func(n int) time.Time { return t0.Add(time.Duration(n) * time.Millisecond) }
+	xs := []*Exif{
+		burstExif(t, ms(0), 0),
+		burstExif(t, ms(200), -2),
+		burstExif(t, ms(400), 2),
+		burstExif(t, ms(600), 0),
+		burstExif(t, ms(800), -2),
+		burstExif(t, ms(1000), 2),
+		burstExif(t, ms(60000), 0),
+		burstExif(t, ms(60300), 0),
+		burstExif(t, ms(90000), 0),
+	}
+	// shuffle input order; grouping must sort by time
+	xs[0], xs[8] = xs[8], xs[0]
+
+	got := GroupBursts(xs, time.Second)
+	want := []Burst{
+		{Indices: []int{8, 1, 2}, Bracketed: true, Biases: []float64{0, -2, 2}},
+		{Indices: []int{3, 4, 5}, Bracketed: true, Biases: []float64{0, -2, 2}},
+		{Indices: []int{6, 7}},
+		{Indices: []int{0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
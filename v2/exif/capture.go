@@ -0,0 +1,145 @@
+package exif
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// CustomRendered values written by Apple devices for special capture modes
+// (beyond 0, normal, and 1, custom, of the EXIF specification).
+const (
+	renderedHDR          = 2 // HDR, original not saved
+	renderedHDROriginal  = 3 // HDR, original saved
+	renderedPanorama     = 6
+	renderedPortraitHDR  = 7
+	renderedPortrait     = 8
+	renderedPortraitHDR2 = 9
+)
+
+// MP (multi-picture format) image types, the low 24 bits of the attribute
+// of each MP entry.
+const (
+	mpPanorama  = 0x020001
+	mpDisparity = 0x020002
+)
+
+// IsPanorama reports whether x describes a panorama or photo sphere: a
+// Google photo sphere (GPano XMP), a panorama shot of an Apple device, or
+// a multi-picture panorama.
+func (x *Exif) IsPanorama() bool {
+	return x.customRendered(renderedPanorama) ||
+		xmpHas(x.xmp, "GPano:ProjectionType", "equirectangular") ||
+		xmpHas(x.xmp, "GPano:UsePanoramaViewer", "True") ||
+		x.hasMPType(mpPanorama)
+}
+
+// HasDepthMap reports whether the image carries depth data, as shot in
+// portrait mode: a Google depth map (GDepth XMP or a GContainer depth
+// item), a portrait shot of an Apple device, or a multi-picture disparity
+// image.
+func (x *Exif) HasDepthMap() bool {
+	return x.customRendered(renderedPortraitHDR, renderedPortrait, renderedPortraitHDR2) ||
+		xmpHas(x.xmp, "GDepth:Format", "") ||
+		xmpHas(x.xmp, "Item:Semantic", "Depth") ||
+		x.hasMPType(mpDisparity)
+}
+
+// IsHDR reports whether the image was captured in HDR mode, as recorded by
+// Apple devices, or carries an HDR gain map (Ultra HDR).
+func (x *Exif) IsHDR() bool {
+	return x.customRendered(renderedHDR, renderedHDROriginal, renderedPortraitHDR, renderedPortraitHDR2) ||
+		xmpHas(x.xmp, "hdrgm:Version", "")
+}
+
+// IsBurst reports whether the image is part of a burst, as recorded in the
+// XMP data of Google cameras.  GroupBursts groups images of bursts from
+// their capture times.
+func (x *Exif) IsBurst() bool {
+	return xmpHas(x.xmp, "GCamera:BurstID", "") ||
+		xmpHas(x.xmp, "GCamera:BurstPrimary", "")
+}
+
+// IsLivePhoto reports whether the image is a motion photo, holding or
+// linked to a short video, as recorded in its XMP data.
+func (x *Exif) IsLivePhoto() bool {
+	return xmpHas(x.xmp, "GCamera:MotionPhoto", "1") ||
+		xmpHas(x.xmp, "GCamera:MicroVideo", "1") ||
+		xmpHas(x.xmp, "Item:Semantic", "MotionPhoto")
+}
+
+// customRendered reports whether the CustomRendered field holds one of
+// vals.
+func (x *Exif) customRendered(vals ...int) bool {
+	tag, err := x.Get(CustomRendered)
+	if err != nil {
+		return false
+	}
+	v, err := tag.Int(0)
+	if err != nil {
+		return false
+	}
+	for _, want := range vals {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMPType reports whether the MPF segment of x lists an image of type
+// typ.
+func (x *Exif) hasMPType(typ uint32) bool {
+	if len(x.mpf) == 0 {
+		return false
+	}
+	tif, err := tiff.Decode(bytes.NewReader(x.mpf))
+	if err != nil || len(tif.Dirs) == 0 {
+		return false
+	}
+	for _, tag := range tif.Dirs[0].Tags {
+		// MPEntry: 16 bytes per image, starting with its attributes
+		if tag.Id != 0xB002 {
+			continue
+		}
+		for e := tag.Val; len(e) >= 16; e = e[16:] {
+			if tif.Order.Uint32(e)&0xFFFFFF == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xmpHas reports whether the XMP packet holds the property name, as an
+// attribute or element, with the value val (compared case insensitively),
+// or with any value if val is empty.
+func xmpHas(xmp []byte, name, val string) bool {
+	if len(xmp) == 0 {
+		return false
+	}
+	s := string(xmp)
+	for _, pat := range []struct{ start, end string }{
+		{name + `="`, `"`},
+		{name + `='`, `'`},
+		{"<" + name + ">", "<"},
+	} {
+		rest := s
+		for {
+			i := strings.Index(rest, pat.start)
+			if i < 0 {
+				break
+			}
+			rest = rest[i+len(pat.start):]
+			j := strings.Index(rest, pat.end)
+			if j < 0 {
+				break
+			}
+			if val == "" || strings.EqualFold(strings.TrimSpace(rest[:j]), val) {
+				return true
+			}
+		}
+	}
+	return false
+}
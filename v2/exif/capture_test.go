@@ -0,0 +1,97 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// testMPF returns an MPF payload listing images of the given types.
+func testMPF(types ...uint32) []byte {
+	le := binary.LittleEndian
+	entries := make([]byte, 16*len(types))
+	for i, typ := range types {
+		le.PutUint32(entries[16*i:], typ)
+	}
+	data := []byte("II*\x00\x08\x00\x00\x00\x01\x00\x02\xb0\x07\x00" +
+		"\x00\x00\x00\x00\x1a\x00\x00\x00\x00\x00\x00\x00")
+	le.PutUint32(data[14:], uint32(len(entries)))
+	return append(data, entries...)
+}
+
+func TestCaptureFlags(t *testing.T) {
+	tests := []struct {
+		name                          string
+		rendered                      int
+		xmp                           string
+		mpf                           []byte
+		pano, depth, hdr, burst, live bool
+	}{
+		{name: "plain"},
+		{name: "apple hdr", rendered: renderedHDR, hdr: true},
+		{name: "apple panorama", rendered: renderedPanorama, pano: true},
+		{name: "apple portrait", rendered: renderedPortraitHDR, depth: true, hdr: true},
+		{name: "photo sphere", xmp: `<rdf:Description GPano:ProjectionType="equirectangular"/>`, pano: true},
+		{name: "depth", xmp: `<GDepth:Format>RangeInverse</GDepth:Format>`, depth: true},
+		{name: "ultra hdr", xmp: `<rdf:Description hdrgm:Version='1.0'/>`, hdr: true},
+		{name: "burst", xmp: `<rdf:Description GCamera:BurstID="abc" GCamera:BurstPrimary="1"/>`, burst: true},
+		{name: "motion photo", xmp: `<rdf:Description GCamera:MotionPhoto="1"/>`, live: true},
+		{name: "no motion photo", xmp: `<rdf:Description GCamera:MotionPhoto="0"/>`},
+		{name: "mp panorama", mpf: testMPF(0x030000, mpPanorama), pano: true},
+		{name: "mp disparity", mpf: testMPF(0x030000, mpDisparity), depth: true},
+	}
+	for _, tt := range tests {
+		x := &Exif{xmp: []byte(tt.xmp), mpf: tt.mpf}
+		if tt.rendered != 0 {
+			x.setTag(CustomRendered, testTag(t, tiff.DTShort, 1, []byte{0, byte(tt.rendered)}))
+		}
+		got := []bool{x.IsPanorama(), x.HasDepthMap(), x.IsHDR(), x.IsBurst(), x.IsLivePhoto()}
+		want := []bool{tt.pano, tt.depth, tt.hdr, tt.burst, tt.live}
+		for i, name := range []string{"IsPanorama", "HasDepthMap", "IsHDR", "IsBurst", "IsLivePhoto"} {
+			if got[i] != want[i] {
+				t.Errorf("%s: %s = %v", tt.name, name, got[i])
+			}
+		}
+	}
+}
+
+func TestDecodeJPEGMeta(t *testing.T) {
+	exifData, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := func(marker byte, data string) []byte {
+		return append([]byte{0xFF, marker, byte((len(data) + 2) >> 8), byte(len(data) + 2)}, data...)
+	}
+	xmp := `<x:xmpmeta><rdf:Description GCamera:MotionPhoto="1"/></x:xmpmeta>`
+
+	// An XMP segment before the EXIF one and MPF and JUMBF segments after
+	// it.
+	jpg := append([]byte{0xFF, 0xD8}, seg(0xE1, "http://ns.adobe.com/xap/1.0/\x00"+xmp)...)
+	app1End := 4 + int(exifData[4])<<8 + int(exifData[5])
+	jpg = append(jpg, exifData[2:app1End]...)
+	jpg = append(jpg, seg(0xE2, "MPF\x00"+string(testMPF(0x030000, mpPanorama)))...)
+	jpg = append(jpg, seg(0xEB, "JP\x00\x01\x00\x00\x00\x01jumbjumd c2pa")...)
+	jpg = append(jpg, exifData[app1End:]...)
+
+	x, err := Decode(bytes.NewReader(jpg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag, err := x.Get(Model); err != nil || tag.String() != `"Synth 1"` {
+		t.Errorf("Model is %v, %v", tag, err)
+	}
+	if string(x.XMP()) != xmp {
+		t.Errorf("XMP is %q", x.XMP())
+	}
+	if !x.IsLivePhoto() || !x.IsPanorama() {
+		t.Errorf("IsLivePhoto = %v, IsPanorama = %v", x.IsLivePhoto(), x.IsPanorama())
+	}
+	if !x.DetectAI().C2PA {
+		t.Errorf("C2PA manifest not found")
+	}
+}
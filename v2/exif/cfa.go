@@ -0,0 +1,150 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CFAColor is a color of the filters of a color filter array.
+type CFAColor byte
+
+const (
+	CFARed CFAColor = iota
+	CFAGreen
+	CFABlue
+	CFACyan
+	CFAMagenta
+	CFAYellow
+	CFAWhite
+)
+
+func (c CFAColor) String() string {
+	if int(c) < len("RGBCMYW") {
+		return "RGBCMYW"[c : c+1]
+	}
+	return fmt.Sprintf("CFAColor(%d)", int(c))
+}
+
+// Sensing methods recorded by the SensingMethod field.
+const (
+	SensingUndefined     = 1
+	SensingOneChipColor  = 2
+	SensingTwoChipColor  = 3
+	SensingThreeChip     = 4
+	SensingColorSequence = 5
+	SensingTrilinear     = 7
+	SensingSequential    = 8
+)
+
+// CFALayout describes the color filter array of an image sensor: a pattern
+// of Rows by Cols filters repeated over the sensor.
+type CFALayout struct {
+	Rows, Cols int
+	// Colors are the colors of the pattern, row by row.
+	Colors []CFAColor
+	// SensingMethod is the value of the SensingMethod field, e.g.
+	// SensingOneChipColor, or 0 if the field is not present.
+	SensingMethod int
+}
+
+// At returns the color of the filter of the pixel at row, col.
+func (l CFALayout) At(row, col int) CFAColor {
+	return l.Colors[row%l.Rows*l.Cols+col%l.Cols]
+}
+
+// IsBayer reports whether l is a 2x2 Bayer pattern of red, blue and two
+// greens.
+func (l CFALayout) IsBayer() bool {
+	if l.Rows != 2 || l.Cols != 2 {
+		return false
+	}
+	var n [CFAWhite + 1]int
+	for _, c := range l.Colors {
+		if c <= CFAWhite {
+			n[c]++
+		}
+	}
+	return n[CFARed] == 1 && n[CFAGreen] == 2 && n[CFABlue] == 1 && l.At(0, 0) != l.At(1, 1)
+}
+
+// IsXTrans reports whether l is a 6x6 pattern of red, green and blue
+// filters in the proportions of the Fujifilm X-Trans sensors.
+func (l CFALayout) IsXTrans() bool {
+	if l.Rows != 6 || l.Cols != 6 {
+		return false
+	}
+	var n [CFAWhite + 1]int
+	for _, c := range l.Colors {
+		if c <= CFAWhite {
+			n[c]++
+		}
+	}
+	return n[CFARed] == 8 && n[CFAGreen] == 20 && n[CFABlue] == 8
+}
+
+// String returns the colors of the pattern, row by row, e.g. "RGGB".
+func (l CFALayout) String() string {
+	var b strings.Builder
+	for _, c := range l.Colors {
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
+
+// CFA returns the layout of the color filter array of the sensor that took
+// the image, from the CFAPattern field or, in raw files, the TIFF/EP
+// CFARepeatPatternDim and CFAPattern2 fields.
+func (x *Exif) CFA() (CFALayout, error) {
+	var l CFALayout
+	if v, err := x.intVal(SensingMethod); err == nil {
+		l.SensingMethod = v
+	}
+
+	if tag, err := x.Get(CFAPattern); err == nil && len(tag.Val) >= 4 {
+		// The dimensions are two shorts in the byte order of the file,
+		// though some cameras write them big endian regardless.
+		var order binary.ByteOrder = binary.BigEndian
+		if x.Tiff != nil {
+			order = x.Tiff.Order
+		}
+		for _, o := range []binary.ByteOrder{order, swapOrder(order)} {
+			cols, rows := int(o.Uint16(tag.Val)), int(o.Uint16(tag.Val[2:]))
+			if rows > 0 && cols > 0 && rows*cols == len(tag.Val)-4 {
+				return l.set(rows, cols, tag.Val[4:]), nil
+			}
+		}
+		return l, errors.New("exif: invalid CFAPattern")
+	}
+
+	dim, err := x.Get(CFARepeatPatternDim)
+	if err != nil {
+		return l, err
+	}
+	pat, err := x.Get(CFAPattern2)
+	if err != nil {
+		return l, err
+	}
+	rows, err := dim.Int(0)
+	if err != nil {
+		return l, err
+	}
+	cols, err := dim.Int(1)
+	if err != nil {
+		return l, err
+	}
+	if rows <= 0 || cols <= 0 || rows*cols != len(pat.Val) {
+		return l, errors.New("exif: CFAPattern2 does not match CFARepeatPatternDim")
+	}
+	return l.set(rows, cols, pat.Val), nil
+}
+
+func (l CFALayout) set(rows, cols int, colors []byte) CFALayout {
+	l.Rows, l.Cols = rows, cols
+	l.Colors = make([]CFAColor, len(colors))
+	for i, c := range colors {
+		l.Colors[i] = CFAColor(c)
+	}
+	return l
+}
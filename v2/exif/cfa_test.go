@@ -0,0 +1,49 @@
+package exif
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestCFA(t *testing.T) {
+	x := &Exif{Tiff: &tiff.Tiff{Order: binary.LittleEndian}}
+	if _, err := x.CFA(); !IsTagNotPresentError(err) {
+		t.Errorf("CFA() of empty Exif: %v", err)
+	}
+
+	// dimensions written big endian in a little endian file
+	x.setTag(CFAPattern, testTag(t, tiff.DTUndefined, 8, []byte{0, 2, 0, 2, 0, 1, 1, 2}))
+	x.setTag(SensingMethod, testTag(t, tiff.DTShort, 1, []byte{0, 2}))
+	l, err := x.CFA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.String() != "RGGB" || !l.IsBayer() || l.IsXTrans() || l.SensingMethod != SensingOneChipColor {
+		t.Errorf("got %v %+v", l, l)
+	}
+	if c := l.At(3, 2); c != CFAGreen {
+		t.Errorf("At(3, 2) = %v, want G", c)
+	}
+
+	xtrans := []byte{
+		1, 1, 0, 1, 1, 2,
+		1, 1, 2, 1, 1, 0,
+		2, 0, 1, 0, 2, 1,
+		1, 1, 2, 1, 1, 0,
+		1, 1, 0, 1, 1, 2,
+		0, 2, 1, 2, 0, 1,
+	}
+	x = &Exif{}
+	x.setTag(CFARepeatPatternDim, testTag(t, tiff.DTShort, 2, []byte{0, 6, 0, 6}))
+	x.setTag(CFAPattern2, testTag(t, tiff.DTByte, 36, xtrans))
+	if l, err = x.CFA(); err != nil || !l.IsXTrans() || l.IsBayer() || l.At(0, 5) != CFABlue {
+		t.Errorf("got %v, %v", l, err)
+	}
+
+	x.setTag(CFAPattern2, testTag(t, tiff.DTByte, 4, []byte{0, 1, 1, 2}))
+	if _, err := x.CFA(); err == nil {
+		t.Errorf("decoded pattern not matching its dimensions")
+	}
+}
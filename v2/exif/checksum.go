@@ -0,0 +1,99 @@
+package exif
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// volatileFields hold offsets into the file, or data laid out relative to
+// them, which change when a file is rewritten without its metadata
+// changing.  They are left out of the canonical form of the metadata.
+var volatileFields = map[FieldName]bool{
+	ExifIFDPointer:             true,
+	GPSInfoIFDPointer:          true,
+	InteroperabilityIFDPointer: true,
+	ThumbJPEGInterchangeFormat: true,
+	StripOffsets:               true,
+	MakerNote:                  true,
+}
+
+// Canonical returns the canonical encoding of the fields of x, as hashed by
+// Checksum and signed by Sign: for each field, in name order, the name, a
+// NUL, then the big endian type (2 bytes), count (4 bytes), value length (4
+// bytes) and value.  Numeric values are encoded big endian, so the encoding
+// does not depend on the byte order or layout of the TIFF structure.
+// Offsets and the raw makernote, whose layout depends on its position in
+// the file, are left out, as are the fields listed in exclude.
+func (x *Exif) Canonical(exclude ...FieldName) []byte {
+	x.Load()
+	var buf bytes.Buffer
+fields:
+	for _, f := range x.main {
+		if volatileFields[f.name] {
+			continue
+		}
+		for _, name := range exclude {
+			if f.name == name {
+				continue fields
+			}
+		}
+		val := canonicalValue(f.tag)
+		buf.WriteString(string(f.name))
+		buf.WriteByte(0)
+		binary.Write(&buf, binary.BigEndian, uint16(f.tag.Type))
+		binary.Write(&buf, binary.BigEndian, f.tag.Count)
+		binary.Write(&buf, binary.BigEndian, uint32(len(val)))
+		buf.Write(val)
+	}
+	return buf.Bytes()
+}
+
+// canonicalValue returns the value of tag with numbers encoded big endian.
+func canonicalValue(tag *tiff.Tag) []byte {
+	var buf bytes.Buffer
+	n := int(tag.Count)
+	switch tag.Format() {
+	case tiff.IntVal:
+		if n == 0 {
+			break
+		}
+		size := len(tag.Val) / n
+		for i := 0; i < n; i++ {
+			v, _ := tag.Int64(i)
+			var b [8]byte
+			binary.BigEndian.PutUint64(b[:], uint64(v))
+			buf.Write(b[8-size:])
+		}
+	case tiff.RatVal:
+		for i := 0; i < n; i++ {
+			num, den, _ := tag.Rat2(i)
+			binary.Write(&buf, binary.BigEndian, uint32(num))
+			binary.Write(&buf, binary.BigEndian, uint32(den))
+		}
+	case tiff.FloatVal:
+		for i := 0; i < n; i++ {
+			v, _ := tag.Float(i)
+			if tag.Type == tiff.DTFloat {
+				binary.Write(&buf, binary.BigEndian, math.Float32bits(float32(v)))
+			} else {
+				binary.Write(&buf, binary.BigEndian, math.Float64bits(v))
+			}
+		}
+	default:
+		return tag.Val
+	}
+	return buf.Bytes()
+}
+
+// Checksum returns a SHA-256 digest of the canonical encoding of x (see
+// Canonical).  It is unchanged by rewriting a file (e.g. changing the byte
+// order, the IFD layout or the image data) as long as the field values stay
+// the same, so it can be used to detect metadata changes without comparing
+// whole files.  Fields decoded from the makernote are included.
+func (x *Exif) Checksum() [sha256.Size]byte {
+	return sha256.Sum256(x.Canonical())
+}
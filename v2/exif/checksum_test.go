@@ -0,0 +1,34 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	sum := func(name string) [32]byte {
+		f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		x, err := Decode(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return x.Checksum()
+	}
+
+	// The same fields in either byte order and layout.
+	le := sum("le_basic.tif")
+	if be := sum("be_basic.tif"); be != le {
+		t.Errorf("byte order changes checksum")
+	}
+	if vf := sum("le_values_first.tif"); vf != le {
+		t.Errorf("layout changes checksum")
+	}
+	if gps := sum("be_gps.jpg"); gps == le {
+		t.Errorf("added GPS fields do not change checksum")
+	}
+}
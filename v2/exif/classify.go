@@ -0,0 +1,161 @@
+package exif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImageSource is the kind of process an image most likely comes from.
+type ImageSource int
+
+const (
+	SourceUnknown    ImageSource = iota
+	SourceCamera                 // straight out of a camera
+	SourceEdited                 // camera image modified by editing software
+	SourceScreenshot             // screen capture
+	SourceScan                   // scanned document or photo
+)
+
+var sourceNames = map[ImageSource]string{
+	SourceUnknown:    "unknown",
+	SourceCamera:     "camera original",
+	SourceEdited:     "edited",
+	SourceScreenshot: "screenshot",
+	SourceScan:       "scan",
+}
+
+func (s ImageSource) String() string {
+	if n, ok := sourceNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("ImageSource(%d)", int(s))
+}
+
+// Classification is the result of Classify.
+type Classification struct {
+	Source ImageSource
+	// Reasons lists the evidence the classification is based on.
+	Reasons []string
+}
+
+// Software name fragments (lower case) of screen capture, scanning and
+// image editing programs.
+var (
+	screenshotSoftware = []string{"screenshot", "screencapture", "screen capture", "snipping", "greenshot", "sharex", "flameshot"}
+	scanSoftware       = []string{"scan", "silverfast", "twain"}
+	editingSoftware    = []string{
+		"photoshop", "lightroom", "gimp", "capture one", "snapseed", "affinity",
+		"pixelmator", "darktable", "rawtherapee", "luminar", "paint.net",
+		"picasa", "acdsee", "dxo photolab", "corel", "canva",
+	}
+)
+
+// cameraFields are the fields only written by devices capturing light.
+var cameraFields = []FieldName{ExposureTime, FNumber, ISOSpeedRatings, FocalLength}
+
+// Classify guesses whether x describes a camera original, an edited camera
+// image, a screenshot or a scan, for routing images on ingest.  It is a
+// heuristic based on the presence of camera fields, the Software field,
+// the resolution and the time stamps; the Reasons of the result explain it.
+func (x *Exif) Classify() Classification {
+	var c Classification
+	software, _ := x.stringVal(Software)
+	sw := strings.ToLower(software)
+	var comment string
+	if tag, err := x.Get(UserComment); err == nil {
+		comment = string(tag.Val)
+	}
+	mk, _ := x.stringVal(Make)
+	model, _ := x.stringVal(Model)
+	device := strings.TrimSpace(mk + " " + model)
+
+	var camera []string
+	for _, name := range cameraFields {
+		if _, err := x.Get(name); err == nil {
+			camera = append(camera, string(name))
+		}
+	}
+
+	switch {
+	case containsAny(sw, screenshotSoftware):
+		c.Source = SourceScreenshot
+		c.Reasons = append(c.Reasons, fmt.Sprintf("Software %q is a screen capture program", software))
+	case len(camera) == 0 && strings.Contains(strings.ToLower(comment), "screenshot"):
+		c.Source = SourceScreenshot
+		c.Reasons = append(c.Reasons, "UserComment marks a screenshot")
+	case containsAny(sw, scanSoftware):
+		c.Source = SourceScan
+		c.Reasons = append(c.Reasons, fmt.Sprintf("Software %q is a scanning program", software))
+	case len(camera) == 0 && strings.Contains(strings.ToLower(device), "scan"):
+		c.Source = SourceScan
+		c.Reasons = append(c.Reasons, fmt.Sprintf("device %q is a scanner", device))
+	case len(camera) == 0 && device != "" && x.dpi() >= 200:
+		c.Source = SourceScan
+		c.Reasons = append(c.Reasons, fmt.Sprintf("no exposure fields and a resolution of %.0f dpi", x.dpi()))
+	case len(camera) > 0:
+		c.Source = SourceCamera
+		c.Reasons = append(c.Reasons, "camera fields present: "+strings.Join(camera, ", "))
+		if containsAny(sw, editingSoftware) {
+			c.Source = SourceEdited
+			c.Reasons = append(c.Reasons, fmt.Sprintf("Software %q is an image editor", software))
+		}
+		if x.modifiedAfterCapture() {
+			c.Source = SourceEdited
+			c.Reasons = append(c.Reasons, "DateTime is later than DateTimeOriginal")
+		}
+	case containsAny(sw, editingSoftware):
+		c.Source = SourceEdited
+		c.Reasons = append(c.Reasons, fmt.Sprintf("Software %q is an image editor", software))
+	default:
+		c.Reasons = append(c.Reasons, "no camera fields")
+	}
+	return c
+}
+
+// dpi returns the horizontal resolution of x in dots per inch, or 0 if
+// unknown.
+func (x *Exif) dpi() float64 {
+	res, err := x.ratFloat(XResolution)
+	if err != nil {
+		return 0
+	}
+	unit := 2 // inches
+	if tag, err := x.Get(ResolutionUnit); err == nil {
+		if u, err := tag.Int(0); err == nil {
+			unit = u
+		}
+	}
+	switch unit {
+	case 2:
+		return res
+	case 3:
+		return res * 2.54
+	}
+	return 0
+}
+
+// modifiedAfterCapture reports whether the DateTime field of x is later
+// than DateTimeOriginal, as set by software saving a modified image.
+func (x *Exif) modifiedAfterCapture() bool {
+	orig, err := x.stringVal(DateTimeOriginal)
+	if err != nil {
+		return false
+	}
+	mod, err := x.stringVal(DateTime)
+	if err != nil {
+		return false
+	}
+	orig, mod = strings.TrimSpace(orig), strings.TrimSpace(mod)
+	// The "2006:01:02 15:04:05" layout sorts like time.
+	return len(orig) == len(mod) && mod > orig
+}
+
+// containsAny reports whether s contains any of subs.
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package exif
+
+import (
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestClassify(t *testing.T) {
+	camera := func(x *Exif) {
+		x.setTag(Make, testString(t, "Canon"))
+		x.setTag(ExposureTime, testRational(t, 1, 250))
+		x.setTag(FNumber, testRational(t, 28, 10))
+	}
+	tests := []struct {
+		name  string
+		setup func(x *Exif)
+		want  ImageSource
+	}{
+		{"empty", func(x *Exif) {}, SourceUnknown},
+		{"camera", camera, SourceCamera},
+		{"edited", func(x *Exif) {
+			camera(x)
+			x.setTag(Software, testString(t, "Adobe Photoshop Lightroom Classic 12.0"))
+		}, SourceEdited},
+		{"resaved", func(x *Exif) {
+			camera(x)
+			x.setTag(DateTimeOriginal, testString(t, "2020:05:01 10:00:00"))
+			x.setTag(DateTime, testString(t, "2021:01:01 09:00:00"))
+		}, SourceEdited},
+		{"screenshot software", func(x *Exif) {
+			x.setTag(Software, testString(t, "Greenshot"))
+		}, SourceScreenshot},
+		{"macOS screenshot", func(x *Exif) {
+			x.setTag(UserComment, testTag(t, tiff.DTUndefined, 18, []byte("ASCII\x00\x00\x00Screenshot")))
+		}, SourceScreenshot},
+		{"scan software", func(x *Exif) {
+			x.setTag(Software, testString(t, "EPSON Scan"))
+		}, SourceScan},
+		{"scanner resolution", func(x *Exif) {
+			x.setTag(Make, testString(t, "EPSON"))
+			x.setTag(Model, testString(t, "Perfection V600"))
+			x.setTag(XResolution, testRational(t, 600, 1))
+		}, SourceScan},
+		{"editor only", func(x *Exif) {
+			x.setTag(Software, testString(t, "GIMP 2.10"))
+		}, SourceEdited},
+	}
+	for _, tt := range tests {
+		x := &Exif{}
+		tt.setup(x)
+		c := x.Classify()
+		if c.Source != tt.want {
+			t.Errorf("%s: got %v (%v), want %v", tt.name, c.Source, c.Reasons, tt.want)
+		}
+		if len(c.Reasons) == 0 {
+			t.Errorf("%s: no reasons", tt.name)
+		}
+	}
+}
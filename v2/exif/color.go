@@ -0,0 +1,134 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// WhiteBalanceMode is the value of the WhiteBalance field.
+type WhiteBalanceMode int
+
+const (
+	WhiteBalanceAuto   WhiteBalanceMode = 0
+	WhiteBalanceManual WhiteBalanceMode = 1
+)
+
+func (m WhiteBalanceMode) String() string {
+	switch m {
+	case WhiteBalanceAuto:
+		return "auto"
+	case WhiteBalanceManual:
+		return "manual"
+	}
+	return fmt.Sprintf("WhiteBalanceMode(%d)", int(m))
+}
+
+// ColorSpaceID is the value of the ColorSpace field.
+type ColorSpaceID int
+
+const (
+	ColorSRGB ColorSpaceID = 1
+	// ColorAdobeRGB is not part of the EXIF specification but written by
+	// some cameras; most record Adobe RGB as ColorUncalibrated with the
+	// InteroperabilityIndex "R03".
+	ColorAdobeRGB     ColorSpaceID = 2
+	ColorUncalibrated ColorSpaceID = 0xFFFF
+)
+
+func (c ColorSpaceID) String() string {
+	switch c {
+	case ColorSRGB:
+		return "sRGB"
+	case ColorAdobeRGB:
+		return "Adobe RGB"
+	case ColorUncalibrated:
+		return "uncalibrated"
+	}
+	return fmt.Sprintf("ColorSpaceID(%d)", int(c))
+}
+
+// Chromaticity is a CIE 1931 xy chromaticity.
+type Chromaticity struct {
+	X, Y float64
+}
+
+// Primaries are the chromaticities of the red, green and blue primaries of
+// an RGB color space.
+type Primaries struct {
+	Red, Green, Blue Chromaticity
+}
+
+// WhiteBalance returns the white balance mode the image was taken with.
+func (x *Exif) WhiteBalance() (WhiteBalanceMode, error) {
+	v, err := x.intVal(WhiteBalance)
+	return WhiteBalanceMode(v), err
+}
+
+// ColorSpace returns the color space of the image data.
+func (x *Exif) ColorSpace() (ColorSpaceID, error) {
+	v, err := x.intVal(ColorSpace)
+	return ColorSpaceID(v), err
+}
+
+// Gamma returns the gamma coefficient of the transfer function of the
+// image data.
+func (x *Exif) Gamma() (float64, error) {
+	return x.ratFloat(Gamma)
+}
+
+// WhitePoint returns the chromaticity of the white point of the image.
+func (x *Exif) WhitePoint() (Chromaticity, error) {
+	v, err := x.ratFloats(WhitePoint, 2)
+	if err != nil {
+		return Chromaticity{}, err
+	}
+	return Chromaticity{v[0], v[1]}, nil
+}
+
+// Primaries returns the chromaticities of the primaries of the image, from
+// the PrimaryChromaticities field.
+func (x *Exif) Primaries() (Primaries, error) {
+	v, err := x.ratFloats(PrimaryChromaticities, 6)
+	if err != nil {
+		return Primaries{}, err
+	}
+	return Primaries{
+		Red:   Chromaticity{v[0], v[1]},
+		Green: Chromaticity{v[2], v[3]},
+		Blue:  Chromaticity{v[4], v[5]},
+	}, nil
+}
+
+// intVal returns the first value of the named integer field.
+func (x *Exif) intVal(name FieldName) (int, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return 0, err
+	}
+	return tag.Int(0)
+}
+
+// ratFloats returns the n values of the named rational field as floats.
+func (x *Exif) ratFloats(name FieldName, n int) ([]float64, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Format() != tiff.RatVal || int(tag.Count) < n {
+		return nil, fmt.Errorf("exif: %v is not %d rationals", name, n)
+	}
+	v := make([]float64, n)
+	for i := range v {
+		num, den, err := tag.Rat2(i)
+		if err != nil {
+			return nil, err
+		}
+		if den == 0 {
+			return nil, errors.New("exif: " + string(name) + " has a zero denominator")
+		}
+		v[i] = ratFloat(num, den)
+	}
+	return v, nil
+}
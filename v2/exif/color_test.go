@@ -0,0 +1,41 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "samples", "2011-10-28-17-50-18-sep-2011-10-28-17-50-18a.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wb, err := x.WhiteBalance(); err != nil || wb != WhiteBalanceManual {
+		t.Errorf("WhiteBalance() = %v, %v", wb, err)
+	}
+	if cs, err := x.ColorSpace(); err != nil || cs != ColorUncalibrated {
+		t.Errorf("ColorSpace() = %v, %v", cs, err)
+	}
+	if g, err := x.Gamma(); err != nil || g != 2.2 {
+		t.Errorf("Gamma() = %v, %v", g, err)
+	}
+	if wp, err := x.WhitePoint(); err != nil || wp != (Chromaticity{0.313, 0.329}) {
+		t.Errorf("WhitePoint() = %v, %v", wp, err)
+	}
+	want := Primaries{Chromaticity{0.64, 0.33}, Chromaticity{0.21, 0.71}, Chromaticity{0.15, 0.06}}
+	if p, err := x.Primaries(); err != nil || p != want {
+		t.Errorf("Primaries() = %v, %v", p, err)
+	}
+
+	x = &Exif{}
+	if _, err := x.WhitePoint(); !IsTagNotPresentError(err) {
+		t.Errorf("WhitePoint() of empty Exif: %v", err)
+	}
+}
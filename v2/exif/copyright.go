@@ -0,0 +1,66 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// CopyrightNotice is the content of the Copyright field, which holds the
+// photographer and the editor copyright as separate NUL terminated
+// strings.
+type CopyrightNotice struct {
+	Photographer string
+	Editor       string
+}
+
+// Copyright returns the photographer and editor copyright notices of the
+// Copyright field.  A notice that is not given is empty.
+func (x *Exif) Copyright() (CopyrightNotice, error) {
+	tag, err := x.Get(Copyright)
+	if err != nil {
+		return CopyrightNotice{}, err
+	}
+	if tag.Format() != tiff.StringVal {
+		_, err := tag.StringVal()
+		return CopyrightNotice{}, err
+	}
+	parts := bytes.SplitN(bytes.TrimRight(tag.Val, "\x00"), []byte{0}, 2)
+	c := CopyrightNotice{Photographer: strings.TrimSpace(string(parts[0]))}
+	if len(parts) == 2 {
+		c.Editor = strings.TrimSpace(string(bytes.TrimRight(parts[1], "\x00")))
+	}
+	return c, nil
+}
+
+// Tag returns a Copyright tag holding c, encoded in order.  The editor
+// copyright follows the photographer's, or a single space if there is none,
+// as set out by the EXIF specification.
+func (c CopyrightNotice) Tag(order binary.ByteOrder) (*tiff.Tag, error) {
+	vals := []interface{}{c.Photographer}
+	if c.Editor != "" {
+		if c.Photographer == "" {
+			vals[0] = " "
+		}
+		vals = append(vals, c.Editor)
+	}
+	return tiff.NewTag(0x8298, tiff.DTAscii, order, vals...)
+}
+
+// Artists returns the names listed in the Artist field, which separates
+// several names with semicolons.
+func (x *Exif) Artists() ([]string, error) {
+	s, err := x.stringVal(Artist)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range strings.Split(s, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,53 @@
+package exif
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestCopyright(t *testing.T) {
+	tests := []struct {
+		val  string
+		want CopyrightNotice
+	}{
+		{"Jane Doe\x00", CopyrightNotice{"Jane Doe", ""}},
+		{"Jane Doe\x00Studio X\x00", CopyrightNotice{"Jane Doe", "Studio X"}},
+		{" \x00Studio X\x00", CopyrightNotice{"", "Studio X"}},
+		{"Jane Doe", CopyrightNotice{"Jane Doe", ""}},
+	}
+	for _, tt := range tests {
+		x := &Exif{}
+		x.setTag(Copyright, testString(t, tt.val))
+		got, err := x.Copyright()
+		if err != nil {
+			t.Errorf("%q: %v", tt.val, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.val, got, tt.want)
+		}
+
+		tag, err := got.Tag(binary.BigEndian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x.setTag(Copyright, tag)
+		if again, err := x.Copyright(); err != nil || again != got {
+			t.Errorf("%+v: round trip gave %+v, %v", got, again, err)
+		}
+	}
+}
+
+func TestArtists(t *testing.T) {
+	x := &Exif{}
+	x.setTag(Artist, testString(t, "Camera owner, John Smith; Photographer, Michael Brown;"))
+	got, err := x.Artists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Camera owner, John Smith", "Photographer, Michael Brown"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,402 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Canon CR3 files are ISO base media (QuickTime-like) containers.  The
+// metadata lives in a Canon specific uuid box inside moov, which holds four
+// small TIFF structures:
+//
+//    CMT1 - IFD0
+//    CMT2 - Exif sub-IFD
+//    CMT3 - Canon makernote
+//    CMT4 - GPS sub-IFD
+//
+// Per-frame metadata is additionally stored as CTMD (Canon Timed MetaData)
+// samples in a dedicated track.
+
+var canonCR3UUID = []byte{
+	0x85, 0xc0, 0xb6, 0x87, 0x82, 0x0f, 0x11, 0xe0,
+	0x81, 0x11, 0xf4, 0xce, 0x46, 0x2b, 0x6a, 0x48,
+}
+
+// CTMD record types that embed TIFF structures.
+const (
+	ctmdExifInfo7 = 7
+	ctmdExifInfo8 = 8
+	ctmdExifInfo9 = 9
+)
+
+// CTMDRecord is a single record from the CTMD track of a Canon CR3 file.
+type CTMDRecord struct {
+	// Type identifies the record layout (e.g. 1 is a time stamp, 5 exposure
+	// info, 7 through 9 hold TIFF encoded EXIF and makernote data).
+	Type uint16
+	// Data is the record payload without its 12 byte header.
+	Data []byte
+}
+
+// Tiffs decodes the TIFF structures embedded in ExifInfo records (types 7
+// through 9).  The map is keyed by the tag ID the block stands in for,
+// i.e. 0x8769 for Exif sub-IFD data and 0x927c for makernote data.
+func (rec CTMDRecord) Tiffs() (map[uint32]*tiff.Tiff, error) {
+	switch rec.Type {
+	case ctmdExifInfo7, ctmdExifInfo8, ctmdExifInfo9:
+	default:
+		return nil, fmt.Errorf("exif: CTMD record type %d holds no TIFF data", rec.Type)
+	}
+	tiffs := map[uint32]*tiff.Tiff{}
+	data := rec.Data
+	for len(data) >= 8 {
+		size := binary.LittleEndian.Uint32(data)
+		id := binary.LittleEndian.Uint32(data[4:])
+		if size < 8 || uint64(size) > uint64(len(data)) {
+			return tiffs, errors.New("exif: invalid CTMD ExifInfo block size")
+		}
+		t, err := tiff.Decode(bytes.NewReader(data[8:size]))
+		if err != nil {
+			return tiffs, err
+		}
+		tiffs[id] = t
+		data = data[size:]
+	}
+	return tiffs, nil
+}
+
+// CTMD returns the records of the CTMD track if x was decoded from a Canon
+// CR3 file.
+func (x *Exif) CTMD() []CTMDRecord {
+	return x.ctmd
+}
+
+// isBMFF reports whether header (the first 8 bytes of a file) looks like the
+// start of an ISO base media file.
+func isBMFF(header []byte) bool {
+	return len(header) >= 8 && string(header[4:8]) == "ftyp"
+}
+
+// bmffBox is a decoded box header.
+type bmffBox struct {
+	typ  string
+	size int64 // size of the box body, -1 if the box extends to EOF
+}
+
+// readBoxHeader reads a box header from r and returns the box and the number
+// of header bytes consumed.
+func readBoxHeader(r io.Reader) (bmffBox, int64, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return bmffBox{}, 0, err
+	}
+	b := bmffBox{typ: string(hdr[4:])}
+	n := int64(8)
+	size := int64(binary.BigEndian.Uint32(hdr[:]))
+	switch size {
+	case 0:
+		b.size = -1
+		return b, n, nil
+	case 1:
+		var large uint64
+		if err := binary.Read(r, binary.BigEndian, &large); err != nil {
+			return b, n, err
+		}
+		n += 8
+		size = int64(large)
+	}
+	if size < n {
+		return b, n, fmt.Errorf("exif: invalid size for %q box", b.typ)
+	}
+	b.size = size - n
+	return b, n, nil
+}
+
+// boxes splits data into its child boxes, keyed by type.  If a type appears
+// more than once, all instances are kept in order.
+func boxes(data []byte) (map[string][][]byte, error) {
+	m := map[string][][]byte{}
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		b, _, err := readBoxHeader(r)
+		if err != nil {
+			return m, err
+		}
+		if b.size < 0 {
+			b.size = int64(r.Len())
+		}
+		if b.size > int64(r.Len()) {
+			return m, fmt.Errorf("exif: %q box overruns its parent", b.typ)
+		}
+		body := make([]byte, b.size)
+		io.ReadFull(r, body)
+		m[b.typ] = append(m[b.typ], body)
+	}
+	return m, nil
+}
+
+// readN reads the next n bytes of r.  The buffer grows as data arrives
+// rather than being allocated up front, so a bogus size read from a file
+// fails with io.ErrUnexpectedEOF instead of exhausting memory.
+func readN(r io.Reader, n int64) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, n))
+	if err == nil && int64(len(b)) < n {
+		err = io.ErrUnexpectedEOF
+	}
+	return b, err
+}
+
+// cr3 holds the metadata pieces collected from a CR3 container.
+type cr3 struct {
+	cmt        map[string][]byte
+	ctmdOffset int64
+	ctmdSize   int64
+	ctmd       []byte
+}
+
+// decodeCR3 streams through the top-level boxes of a CR3 file, loading the
+// moov box and the CTMD sample from mdat.
+func decodeCR3(r io.Reader) (*cr3, error) {
+	c := &cr3{cmt: map[string][]byte{}, ctmdOffset: -1}
+	var pos int64
+	for {
+		b, n, err := readBoxHeader(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		pos += n
+
+		switch {
+		case b.typ == "moov":
+			if b.size < 0 {
+				return nil, errors.New("exif: unbounded moov box")
+			}
+			body, err := readN(r, b.size)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.parseMoov(body); err != nil {
+				return nil, err
+			}
+		case b.typ == "mdat" && c.ctmdOffset >= pos && c.ctmdSize > 0:
+			skip := c.ctmdOffset - pos
+			if _, err := io.CopyN(ioutil.Discard, r, skip); err != nil {
+				return nil, err
+			}
+			if c.ctmd, err = readN(r, c.ctmdSize); err != nil {
+				return nil, err
+			}
+			// The rest of mdat is image data we have no use for.
+			return c, nil
+		default:
+			if b.size < 0 {
+				return c, nil
+			}
+			if _, err := io.CopyN(ioutil.Discard, r, b.size); err != nil {
+				return nil, err
+			}
+		}
+		pos += b.size
+	}
+	if len(c.cmt) == 0 {
+		return nil, errors.New("exif: no Canon CMT boxes found")
+	}
+	return c, nil
+}
+
+func (c *cr3) parseMoov(moov []byte) error {
+	children, err := boxes(moov)
+	if err != nil {
+		return err
+	}
+	for _, u := range children["uuid"] {
+		if len(u) < 16 || !bytes.Equal(u[:16], canonCR3UUID) {
+			continue
+		}
+		cmts, err := boxes(u[16:])
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{"CMT1", "CMT2", "CMT3", "CMT4"} {
+			if bs := cmts[name]; len(bs) > 0 {
+				c.cmt[name] = bs[0]
+			}
+		}
+	}
+	for _, trak := range children["trak"] {
+		if off, size, ok := ctmdSample(trak); ok {
+			c.ctmdOffset, c.ctmdSize = off, size
+			break
+		}
+	}
+	return nil
+}
+
+// ctmdSample returns the file offset and size of the first sample of trak if
+// it is a CTMD track.
+func ctmdSample(trak []byte) (offset, size int64, ok bool) {
+	stbl := descend(trak, "mdia", "minf", "stbl")
+	if stbl == nil {
+		return 0, 0, false
+	}
+	children, err := boxes(stbl)
+	if err != nil {
+		return 0, 0, false
+	}
+	first := func(name string) []byte {
+		if bs := children[name]; len(bs) > 0 {
+			return bs[0]
+		}
+		return nil
+	}
+
+	// stsd: version/flags(4), entry count(4), then sample entries.
+	stsd := first("stsd")
+	if len(stsd) < 16 || string(stsd[12:16]) != "CTMD" {
+		return 0, 0, false
+	}
+
+	// stsz: version/flags(4), sample size(4), sample count(4), sizes...
+	stsz := first("stsz")
+	if len(stsz) < 12 {
+		return 0, 0, false
+	}
+	size = int64(binary.BigEndian.Uint32(stsz[4:]))
+	if size == 0 {
+		if len(stsz) < 16 {
+			return 0, 0, false
+		}
+		size = int64(binary.BigEndian.Uint32(stsz[12:]))
+	}
+
+	if co64 := first("co64"); len(co64) >= 16 {
+		offset = int64(binary.BigEndian.Uint64(co64[8:]))
+	} else if stco := first("stco"); len(stco) >= 12 {
+		offset = int64(binary.BigEndian.Uint32(stco[8:]))
+	} else {
+		return 0, 0, false
+	}
+	return offset, size, true
+}
+
+// descend follows the given path of nested boxes starting at data.
+func descend(data []byte, path ...string) []byte {
+	for _, name := range path {
+		children, err := boxes(data)
+		if err != nil || len(children[name]) == 0 {
+			return nil
+		}
+		data = children[name][0]
+	}
+	return data
+}
+
+// parseCTMD splits a CTMD sample into its records.
+func parseCTMD(data []byte) ([]CTMDRecord, error) {
+	var recs []CTMDRecord
+	for len(data) >= 12 {
+		size := binary.LittleEndian.Uint32(data)
+		typ := binary.LittleEndian.Uint16(data[4:])
+		if size < 12 || uint64(size) > uint64(len(data)) {
+			return recs, errors.New("exif: invalid CTMD record size")
+		}
+		recs = append(recs, CTMDRecord{Type: typ, Data: data[12:size]})
+		data = data[size:]
+	}
+	return recs, nil
+}
+
+// cr3MakerNote wraps the CMT3 TIFF structure in a MakerNote tag laid out the
+// way the makernote parsers expect: the value starts at the makernote IFD
+// and ValOffset is that IFD's offset within the CMT3 structure, so that IFD
+// value offsets resolve correctly.
+func cr3MakerNote(cmt3 []byte, t *tiff.Tiff) (*tiff.Tag, error) {
+	if len(cmt3) < 8 {
+		return nil, errors.New("exif: short CMT3 box")
+	}
+	ifd := t.Order.Uint32(cmt3[4:])
+	if ifd < 8 || uint64(ifd) >= uint64(len(cmt3)) {
+		return nil, errors.New("exif: invalid CMT3 IFD offset")
+	}
+	entry := make([]byte, 12)
+	t.Order.PutUint16(entry, MakerNoteID)
+	t.Order.PutUint16(entry[2:], uint16(tiff.DTUndefined))
+	t.Order.PutUint32(entry[4:], uint32(len(cmt3))-ifd)
+	t.Order.PutUint32(entry[8:], ifd)
+	return tiff.DecodeEntry(entry, bytes.NewReader(cmt3), t.Order)
+}
+
+// loadCR3 builds an Exif from the metadata boxes of a CR3 file.  CMT1
+// becomes x.Tiff and x.Raw; the Exif and GPS boxes are loaded with the same
+// field names as their TIFF sub-IFD counterparts and CMT3 is exposed as the
+// MakerNote field, so makernote parsers work unchanged.
+func loadCR3(c *cr3) (*Exif, error) {
+	cmt1, ok := c.cmt["CMT1"]
+	if !ok {
+		return nil, errors.New("exif: CR3 file has no CMT1 box")
+	}
+	tif, err := tiff.Decode(bytes.NewReader(cmt1))
+	if err != nil {
+		return nil, err
+	}
+	x := &Exif{
+		Tiff: tif,
+		Raw:  cmt1,
+	}
+
+	defer x.setGroup("")
+	x.setGroup(GroupExif)
+	if c.ctmd != nil {
+		x.ctmd, _ = parseCTMD(c.ctmd)
+		for _, rec := range x.ctmd {
+			tiffs, err := rec.Tiffs()
+			if err != nil {
+				continue
+			}
+			if t, ok := tiffs[uint32(ExifIFDPointerID)]; ok && len(t.Dirs) > 0 {
+				x.LoadTags(t.Dirs[0], exifFields, false)
+			}
+		}
+	}
+
+	for _, box := range []struct {
+		name   string
+		group  string
+		fields map[uint16]FieldName
+	}{
+		{"CMT2", GroupExif, exifFields},
+		{"CMT4", GroupGPS, gpsFields},
+	} {
+		data, ok := c.cmt[box.name]
+		if !ok {
+			continue
+		}
+		t, err := tiff.Decode(bytes.NewReader(data))
+		if err != nil {
+			return x, fmt.Errorf("exif: %s decode failed: %v", box.name, err)
+		}
+		if len(t.Dirs) > 0 {
+			x.setGroup(box.group)
+			x.LoadTags(t.Dirs[0], box.fields, false)
+		}
+	}
+
+	if cmt3, ok := c.cmt["CMT3"]; ok {
+		t, err := tiff.Decode(bytes.NewReader(cmt3))
+		if err == nil && t.Order == tif.Order {
+			if tag, err := cr3MakerNote(cmt3, t); err == nil {
+				x.setGroup(GroupExif)
+				x.setTag(MakerNote, tag)
+			}
+		}
+	}
+	return x, nil
+}
@@ -0,0 +1,163 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type testEntry struct {
+	id    uint16
+	typ   tiff.DataType
+	count uint32
+	val   []byte
+}
+
+// buildTIFF encodes a little endian TIFF structure with a single IFD.
+func buildTIFF(entries ...testEntry) []byte {
+	order := binary.LittleEndian
+	var hdr, ifd, vals bytes.Buffer
+	hdr.WriteString("II*\x00")
+	binary.Write(&hdr, order, uint32(8))
+
+	valStart := 8 + 2 + 12*len(entries) + 4
+	binary.Write(&ifd, order, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&ifd, order, e.id)
+		binary.Write(&ifd, order, e.typ)
+		binary.Write(&ifd, order, e.count)
+		if len(e.val) > 4 {
+			binary.Write(&ifd, order, uint32(valStart+vals.Len()))
+			vals.Write(e.val)
+		} else {
+			ifd.Write(append(e.val, make([]byte, 4-len(e.val))...))
+		}
+	}
+	binary.Write(&ifd, order, uint32(0))
+	return append(append(hdr.Bytes(), ifd.Bytes()...), vals.Bytes()...)
+}
+
+func asciiEntry(id uint16, s string) testEntry {
+	return testEntry{id, tiff.DTAscii, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+func box(typ string, body ...[]byte) []byte {
+	var b bytes.Buffer
+	size := 8
+	for _, p := range body {
+		size += len(p)
+	}
+	binary.Write(&b, binary.BigEndian, uint32(size))
+	b.WriteString(typ)
+	for _, p := range body {
+		b.Write(p)
+	}
+	return b.Bytes()
+}
+
+func fullBox(typ string, fields ...uint32) []byte {
+	body := make([]byte, 4+4*len(fields))
+	for i, f := range fields {
+		binary.BigEndian.PutUint32(body[4+4*i:], f)
+	}
+	return box(typ, body)
+}
+
+func TestDecodeCR3(t *testing.T) {
+	cmt1 := buildTIFF(asciiEntry(0x010F, "Canon"), asciiEntry(0x0110, "Canon EOS R5"))
+	cmt2 := buildTIFF(testEntry{0x8827, tiff.DTShort, 1, []byte{0x90, 0x01}})
+	cmt3 := buildTIFF(asciiEntry(0x0006, "Canon EOS R5 body"))
+	cmt4 := buildTIFF(asciiEntry(0x0001, "N"))
+
+	// A CTMD sample with a time stamp record and an ExifInfo record.
+	ctmdExif := buildTIFF(testEntry{0xA002, tiff.DTShort, 1, []byte{0x40, 0x1F}})
+	var ctmd bytes.Buffer
+	binary.Write(&ctmd, binary.LittleEndian, uint32(12+4))
+	binary.Write(&ctmd, binary.LittleEndian, uint16(1))
+	ctmd.Write(make([]byte, 6+4))
+	binary.Write(&ctmd, binary.LittleEndian, uint32(12+8+len(ctmdExif)))
+	binary.Write(&ctmd, binary.LittleEndian, uint16(ctmdExifInfo8))
+	ctmd.Write(make([]byte, 6))
+	binary.Write(&ctmd, binary.LittleEndian, uint32(8+len(ctmdExif)))
+	binary.Write(&ctmd, binary.LittleEndian, uint32(ExifIFDPointerID))
+	ctmd.Write(ctmdExif)
+
+	ftyp := box("ftyp", []byte("crx \x00\x00\x00\x01crx isom"))
+	uuid := box("uuid", canonCR3UUID, box("CMT1", cmt1), box("CMT2", cmt2), box("CMT3", cmt3), box("CMT4", cmt4))
+	stsd := box("stsd", []byte{0, 0, 0, 0, 0, 0, 0, 1}, box("CTMD", make([]byte, 8)))
+	stsz := fullBox("stsz", uint32(ctmd.Len()), 1)
+	moovFor := func(chunkOffset uint32) []byte {
+		stco := fullBox("stco", 1, chunkOffset)
+		trak := box("trak", box("mdia", box("minf", box("stbl", stsd, stsz, stco))))
+		return box("moov", uuid, trak)
+	}
+	// The chunk offset points just past the mdat header.
+	offset := uint32(len(ftyp) + len(moovFor(0)) + 8)
+	file := append(append(ftyp, moovFor(offset)...), box("mdat", ctmd.Bytes(), make([]byte, 64))...)
+
+	x, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[FieldName]string{
+		Model:           `"Canon EOS R5"`,
+		ISOSpeedRatings: `400`,
+		GPSLatitudeRef:  `"N"`,
+		PixelXDimension: `8000`,
+	} {
+		tag, err := x.Get(name)
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+			continue
+		}
+		if got := tag.String(); got != want {
+			t.Errorf("%v = %s, want %s", name, got, want)
+		}
+	}
+
+	if recs := x.CTMD(); len(recs) != 2 || recs[0].Type != 1 || recs[1].Type != ctmdExifInfo8 {
+		t.Errorf("unexpected CTMD records %+v", recs)
+	}
+
+	// The makernote must be decodable the way the mknote parsers do it.
+	m, err := x.Get(MakerNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := bytes.NewReader(append(make([]byte, m.ValOffset), m.Val...))
+	buf.Seek(int64(m.ValOffset), 0)
+	dir, _, err := tiff.DecodeDir(buf, x.Tiff.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dir.Tags) != 1 || dir.Tags[0].String() != `"Canon EOS R5 body"` {
+		t.Errorf("unexpected makernote dir %v", dir)
+	}
+}
+
+func TestDecodeCR3HugeBox(t *testing.T) {
+	ftyp := box("ftyp", []byte("crx \x00\x00\x00\x01"))
+	large := []byte("\x00\x00\x00\x01moov\x40\x00\x00\x00\x00\x00\x00\x00")
+
+	uuid := box("uuid", canonCR3UUID, box("CMT1", buildTIFF(asciiEntry(0x010F, "Canon"))))
+	stsd := box("stsd", []byte{0, 0, 0, 0, 0, 0, 0, 1}, box("CTMD", make([]byte, 8)))
+	trak := func(off uint32) []byte {
+		stbl := box("stbl", stsd, fullBox("stsz", 0xFFFFFFF0, 1), fullBox("stco", 1, off))
+		return box("trak", box("mdia", box("minf", stbl)))
+	}
+	moovLen := len(box("moov", uuid, trak(0)))
+	moov := box("moov", uuid, trak(uint32(len(ftyp)+moovLen+8)))
+
+	for name, rest := range map[string][]byte{
+		"moov largesize": large,
+		"moov size":      []byte("\xFF\xFF\xFF\xF0moov"),
+		"CTMD size":      append(moov, box("mdat", make([]byte, 16))...),
+	} {
+		file := append(append([]byte{}, ftyp...), rest...)
+		if _, err := Decode(bytes.NewReader(file)); err == nil {
+			t.Errorf("%s: truncated file decoded", name)
+		}
+	}
+}
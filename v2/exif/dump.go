@@ -0,0 +1,275 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// dumpMaxRows is the number of hex rows printed for a single value or
+// unreferenced area before the remainder is elided.
+const dumpMaxRows = 4
+
+// dumpTypeSize gives the byte size of a single value of each TIFF data type.
+var dumpTypeSize = map[tiff.DataType]uint64{
+	tiff.DTByte:      1,
+	tiff.DTAscii:     1,
+	tiff.DTShort:     2,
+	tiff.DTLong:      4,
+	tiff.DTRational:  8,
+	tiff.DTSByte:     1,
+	tiff.DTUndefined: 1,
+	tiff.DTSShort:    2,
+	tiff.DTSLong:     4,
+	tiff.DTSRational: 8,
+	tiff.DTFloat:     4,
+	tiff.DTDouble:    8,
+	tiff.DTUTF8:      1,
+}
+
+// A dumpRegion is an annotated byte range of the raw EXIF data.
+type dumpRegion struct {
+	start, end uint64
+	label      string
+	kind       regionKind
+}
+
+// regionKind classifies dumpRegions for layout validation.
+type regionKind int
+
+const (
+	regionHeader  regionKind = iota
+	regionIFD                // the entry count starting an IFD
+	regionEntry              // an IFD entry or next IFD offset
+	regionValue              // a value stored outside its entry
+	regionData               // data located by field values (the thumbnail)
+	regionInvalid            // a reference past the end of the data
+)
+
+// HexDump writes an annotated hex dump of the raw EXIF data (x.Raw) to w.
+// The dump marks the TIFF header, the IFD boundaries and entries (with
+// their field names), the value areas they point to and any bytes not
+// referenced by the IFD structure.  It is intended for troubleshooting
+// corrupt files, so it does not rely on the decoded tags: the IFDs are
+// walked again directly from the raw bytes.
+func (x *Exif) HexDump(w io.Writer) error {
+	raw := x.Raw
+	regions, err := tiffRegions(raw)
+	if err != nil {
+		return err
+	}
+
+	// Regions past the end of raw, such as an out of bounds IFD, are
+	// annotated at their offset, but only the bytes of raw are dumped.
+	size := uint64(len(raw))
+	var pos uint64
+	for _, r := range regions {
+		if start := clampOffset(r.start, size); start > pos {
+			if err := dumpRows(w, raw, pos, start, "-- unreferenced"); err != nil {
+				return err
+			}
+		}
+		label := r.label
+		if r.start < pos {
+			label += " (overlaps previous)"
+		}
+		if err := dumpRows(w, raw, r.start, r.end, label); err != nil {
+			return err
+		}
+		if end := clampOffset(r.end, size); end > pos {
+			pos = end
+		}
+	}
+	if pos < size {
+		return dumpRows(w, raw, pos, size, "-- unreferenced")
+	}
+	return nil
+}
+
+// clampOffset returns off, or size if off is past it.
+func clampOffset(off, size uint64) uint64 {
+	if off > size {
+		return size
+	}
+	return off
+}
+
+// tiffExtent returns the length of the TIFF structure at the start of raw,
+// i.e. the end of the last byte referenced by its header, IFDs and values.
+func tiffExtent(raw []byte) (uint64, error) {
+	regions, err := tiffRegions(raw)
+	if err != nil {
+		return 0, err
+	}
+	var end uint64
+	for _, r := range regions {
+		if r.end > end {
+			end = r.end
+		}
+	}
+	if end > uint64(len(raw)) {
+		end = uint64(len(raw))
+	}
+	return end, nil
+}
+
+// tiffRegions walks the TIFF structure in raw (IFD0, IFD1 and the Exif, GPS
+// and Interoperability sub-IFDs) and returns its annotated regions, sorted
+// by start offset.
+func tiffRegions(raw []byte) ([]dumpRegion, error) {
+	regions, _, err := walkTIFF(raw)
+	return regions, err
+}
+
+// tiffTruncated reports whether the TIFF structure in raw references data
+// past the end of raw.
+func tiffTruncated(raw []byte) bool {
+	_, truncated, err := walkTIFF(raw)
+	return err == nil && truncated
+}
+
+// walkTIFF implements tiffRegions, also reporting whether any IFD, value or
+// thumbnail lies (partly) past the end of raw.
+func walkTIFF(raw []byte) (regions []dumpRegion, truncated bool, err error) {
+	if len(raw) < 8 {
+		return nil, false, errors.New("exif: raw data too short for a TIFF header")
+	}
+	var order binary.ByteOrder
+	switch string(raw[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return nil, false, fmt.Errorf("exif: invalid TIFF header %q", raw[:4])
+	}
+
+	size := uint64(len(raw))
+	ifd0 := uint64(order.Uint32(raw[4:]))
+	regions = []dumpRegion{{0, 8, fmt.Sprintf("TIFF header (%v), IFD0 at %#x", order, ifd0), regionHeader}}
+	visited := map[uint64]bool{}
+
+	var walk func(off uint64, name string, fields map[uint16]FieldName, next []string)
+	walk = func(off uint64, name string, fields map[uint16]FieldName, next []string) {
+		if off == 0 || visited[off] {
+			return
+		}
+		visited[off] = true
+		if off+2 > size {
+			truncated = true
+			regions = append(regions, dumpRegion{off, off, fmt.Sprintf("%s at %#x is out of bounds", name, off), regionInvalid})
+			return
+		}
+
+		n := uint64(order.Uint16(raw[off:]))
+		regions = append(regions, dumpRegion{off, off + 2, fmt.Sprintf("%s: %d entries", name, n), regionIFD})
+		var thumbOff, thumbLen uint64
+		e := off + 2
+		for i := uint64(0); i < n; i, e = i+1, e+12 {
+			if e+12 > size {
+				truncated = true
+				regions = append(regions, dumpRegion{e, size, fmt.Sprintf("%s: truncated entry %d", name, i), regionInvalid})
+				return
+			}
+			id := order.Uint16(raw[e:])
+			typ := tiff.DataType(order.Uint16(raw[e+2:]))
+			count := uint64(order.Uint32(raw[e+4:]))
+			val := uint64(order.Uint32(raw[e+8:]))
+
+			field := fields[id]
+			if field == "" {
+				field = FieldName(fmt.Sprintf("%v%x", UnknownPrefix, id))
+			}
+			label := fmt.Sprintf("%s entry %d: %#04x %v, type %d, count %d", name, i, id, field, typ, count)
+			valSize := dumpTypeSize[typ] * count
+			if valSize > 4 {
+				label += fmt.Sprintf(", value at %#x", val)
+				if val+valSize > size {
+					truncated = true
+					label += " (out of bounds)"
+				} else {
+					regions = append(regions, dumpRegion{val, val + valSize, fmt.Sprintf("%v value", field), regionValue})
+				}
+			}
+			regions = append(regions, dumpRegion{e, e + 12, label, regionEntry})
+
+			switch id {
+			case ExifIFDPointerID:
+				walk(val, "Exif IFD", exifFields, nil)
+			case GPSIFDPointerID:
+				walk(val, "GPS IFD", gpsFields, nil)
+			case InteropIFDPointerID:
+				walk(val, "Interop IFD", interopFields, nil)
+			case 0x0201:
+				thumbOff = val
+			case 0x0202:
+				thumbLen = val
+			}
+		}
+		if thumbOff != 0 && thumbLen != 0 {
+			if thumbOff+thumbLen <= size {
+				regions = append(regions, dumpRegion{thumbOff, thumbOff + thumbLen, "JPEG thumbnail", regionData})
+			} else {
+				truncated = true
+			}
+		}
+
+		if next == nil {
+			if e+4 <= size {
+				regions = append(regions, dumpRegion{e, e + 4, fmt.Sprintf("%s: next IFD offset (unused)", name), regionEntry})
+			}
+			return
+		}
+		if e+4 > size {
+			truncated = true
+			regions = append(regions, dumpRegion{e, size, fmt.Sprintf("%s: truncated next IFD offset", name), regionInvalid})
+			return
+		}
+		nextOff := uint64(order.Uint32(raw[e:]))
+		regions = append(regions, dumpRegion{e, e + 4, fmt.Sprintf("%s: next IFD at %#x", name, nextOff), regionEntry})
+		if len(next) > 0 {
+			walk(nextOff, next[0], thumbnailFields, next[1:])
+		}
+	}
+	walk(ifd0, "IFD0", exifFields, []string{"IFD1"})
+
+	sort.SliceStable(regions, func(i, j int) bool { return regions[i].start < regions[j].start })
+	return regions, truncated, nil
+}
+
+// dumpRows writes raw[start:end] as rows of up to 16 hex bytes, annotating
+// the first row with label.  Long ranges are elided after dumpMaxRows rows.
+// The part of the range past the end of raw is not dumped; a range lying
+// entirely past it is written as its label alone.
+func dumpRows(w io.Writer, raw []byte, start, end uint64, label string) error {
+	end = clampOffset(end, uint64(len(raw)))
+	if start >= end {
+		_, err := fmt.Fprintf(w, "%08x  %-48s  %s\n", start, "", label)
+		return err
+	}
+	for row, off := 0, start; off < end; row, off = row+1, off+16 {
+		if row == dumpMaxRows {
+			_, err := fmt.Fprintf(w, "%08x  ... %d more bytes\n", off, end-off)
+			return err
+		}
+		stop := off + 16
+		if stop > end {
+			stop = end
+		}
+		var hex strings.Builder
+		for _, b := range raw[off:stop] {
+			fmt.Fprintf(&hex, "%02x ", b)
+		}
+		line := fmt.Sprintf("%08x  %-48s  %s", off, hex.String(), label)
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+		label = ""
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package exif
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestHexDump(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := x.HexDump(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dump := buf.String()
+	for _, want := range []string{
+		"00000000  49 49 2a 00 08 00 00 00",
+		"TIFF header (LittleEndian), IFD0 at 0x8",
+		"IFD0 entry 0: 0x010f Make, type 2",
+		"Exif IFD:",
+		"IFD1: next IFD at 0x0",
+		"JPEG thumbnail",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not contain %q", want)
+		}
+	}
+	if t.Failed() {
+		t.Log(dump)
+	}
+
+	x.Raw = []byte("II*")
+	if err := x.HexDump(&buf); err == nil {
+		t.Errorf("short raw data: got no error")
+	}
+}
+
+func TestHexDumpOutOfBounds(t *testing.T) {
+	data := buildTIFF(
+		asciiEntry(0x0110, "Synth 1"),
+		testEntry{ExifIFDPointerID, tiff.DTLong, 1, []byte{0, 0x10, 0, 0}},
+	)
+	// Spare capacity past the data must not be dumped.
+	buf := bytes.Repeat([]byte{0xAB}, 512)
+	for _, raw := range [][]byte{data[:len(data):len(data)], buf[:copy(buf, data)]} {
+		var out strings.Builder
+		if err := (&Exif{Raw: raw}).HexDump(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), "Exif IFD at 0x1000 is out of bounds") {
+			t.Errorf("out of bounds IFD not annotated:\n%s", out.String())
+		}
+		if strings.Contains(out.String(), "ab ab") {
+			t.Errorf("bytes past the data dumped:\n%s", out.String())
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package exif
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// DuplicatePolicy selects which tag is loaded when a tag ID appears more
+// than once in the same IFD, as written by some firmwares.  Each repeated
+// tag is recorded as a warning.
+type DuplicatePolicy int
+
+const (
+	DuplicatesLast    DuplicatePolicy = iota // the last tag wins
+	DuplicatesFirst                          // the first tag wins
+	DuplicatesKeepAll                        // the last tag wins, all are kept for GetAll
+)
+
+var duplicateNames = map[DuplicatePolicy]string{
+	DuplicatesLast:    "last",
+	DuplicatesFirst:   "first",
+	DuplicatesKeepAll: "keep all",
+}
+
+func (p DuplicatePolicy) String() string {
+	if n, ok := duplicateNames[p]; ok {
+		return n
+	}
+	return fmt.Sprintf("DuplicatePolicy(%d)", int(p))
+}
+
+// GetAll returns every instance of the field name: for a plain name, the
+// field of each group it was loaded from (IFD0, Exif, GPS, Interop, IFD1,
+// then makernotes), where Get only returns one; for a qualified name, the
+// field of that group.  With DuplicatesKeepAll, the tags repeated within
+// an IFD come before the one loaded.  It returns nil if Get fails.
+func (x *Exif) GetAll(name FieldName) []*tiff.Tag {
+	if _, err := x.Get(name); err != nil {
+		return nil
+	}
+	group, bare := name.Group()
+	var insts []field
+	if group != "" {
+		if f, ok := x.main.qualified(name); ok {
+			insts = append(insts, f)
+		} else if f, ok := x.shadowed.get(name); ok {
+			insts = append(insts, field{bare, f.group, f.tag})
+		}
+	} else {
+		f, _ := x.main.get(name)
+		insts = append(insts, f)
+		for _, s := range x.shadowed {
+			if g, b := s.name.Group(); b == name {
+				insts = append(insts, field{b, g, s.tag})
+			}
+		}
+		sort.SliceStable(insts, func(i, j int) bool {
+			return groupRank(insts[i].group) < groupRank(insts[j].group)
+		})
+	}
+
+	var tags []*tiff.Tag
+	for _, inst := range insts {
+		for _, f := range x.dups {
+			if f.group == inst.group && f.name == inst.name {
+				tags = append(tags, f.tag)
+			}
+		}
+		tags = append(tags, inst.tag)
+	}
+	if x.lazy != nil {
+		var pending []*tiff.Tag
+		for _, tag := range tags {
+			if x.lazy.pending[tag] != nil {
+				pending = append(pending, tag)
+			}
+		}
+		x.loadTags(pending)
+	}
+	return tags
+}
+
+// groupRank orders field groups as they appear in a file, makernotes last.
+func groupRank(group string) int {
+	if r, ok := groupOrder[group]; ok {
+		return r
+	}
+	return len(groupOrder)
+}
+
+// dropDups drops the repeated instances of the field name of group.
+func (x *Exif) dropDups(group string, name FieldName) {
+	kept := x.dups[:0]
+	for _, f := range x.dups {
+		if f.group != group || f.name != name {
+			kept = append(kept, f)
+		}
+	}
+	x.dups = kept
+}
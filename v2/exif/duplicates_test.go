@@ -0,0 +1,80 @@
+package exif
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestDuplicatePolicy(t *testing.T) {
+	order := binary.BigEndian
+	first, _ := tiff.NewTag(0x0112, tiff.DTShort, order, 1)
+	second, _ := tiff.NewTag(0x0112, tiff.DTShort, order, 6)
+	dir := &tiff.Dir{Tags: []*tiff.Tag{first, second}}
+
+	for _, tt := range []struct {
+		policy DuplicatePolicy
+		get    *tiff.Tag
+		all    []*tiff.Tag
+		warn   string
+	}{
+		{DuplicatesLast, second, []*tiff.Tag{second}, "Orientation: duplicate tag replaces the earlier one"},
+		{DuplicatesFirst, first, []*tiff.Tag{first}, "Orientation: duplicate tag ignored"},
+		{DuplicatesKeepAll, second, []*tiff.Tag{first, second}, "Orientation: duplicate tag replaces the earlier one"},
+	} {
+		x := &Exif{duplicates: tt.policy}
+		x.setGroup(GroupIFD0)
+		x.LoadTags(dir, exifFields, false)
+		x.setGroup("")
+
+		if tag, _ := x.Get(Orientation); tag != tt.get {
+			t.Errorf("%v: Get = %v, want %v", tt.policy, tag, tt.get)
+		}
+		for _, name := range []FieldName{Orientation, Qualified(GroupIFD0, Orientation)} {
+			all := x.GetAll(name)
+			if len(all) != len(tt.all) {
+				t.Errorf("%v: GetAll(%s) = %v, want %v", tt.policy, name, all, tt.all)
+				continue
+			}
+			for i := range all {
+				if all[i] != tt.all[i] {
+					t.Errorf("%v: GetAll(%s)[%d] = %v, want %v", tt.policy, name, i, all[i], tt.all[i])
+				}
+			}
+		}
+		if ws := x.Warnings(); len(ws) != 1 || ws[0].String() != tt.warn {
+			t.Errorf("%v: warnings %v, want %q", tt.policy, ws, tt.warn)
+		}
+
+		x.Delete(Orientation)
+		if all := x.GetAll(Orientation); all != nil {
+			t.Errorf("%v: GetAll after Delete = %v", tt.policy, all)
+		}
+	}
+}
+
+func TestGetAllGroups(t *testing.T) {
+	order := binary.BigEndian
+	exifTag, _ := tiff.NewTag(0x0110, tiff.DTAscii, order, "Exif model")
+	ifd0Tag, _ := tiff.NewTag(0x0110, tiff.DTAscii, order, "IFD0 model")
+	ifd1Tag, _ := tiff.NewTag(0x0110, tiff.DTAscii, order, "IFD1 model")
+
+	x := &Exif{}
+	for _, f := range []field{{Model, GroupExif, exifTag}, {Model, GroupIFD1, ifd1Tag}, {Model, GroupIFD0, ifd0Tag}} {
+		x.setGroup(f.group)
+		x.setTag(f.name, f.tag)
+	}
+	x.setGroup("")
+
+	all := x.GetAll(Model)
+	if len(all) != 3 || all[0] != ifd0Tag || all[1] != exifTag || all[2] != ifd1Tag {
+		t.Errorf("GetAll(Model) = %v", all)
+	}
+	if all := x.GetAll(Qualified(GroupExif, Model)); len(all) != 1 || all[0] != exifTag {
+		t.Errorf("GetAll(Exif/Model) = %v", all)
+	}
+	if all := x.GetAll(Make); all != nil {
+		t.Errorf("GetAll(Make) = %v", all)
+	}
+}
@@ -0,0 +1,89 @@
+package exif
+
+import "github.com/rwcarlsen/goexif/v2/tiff"
+
+// ChangeFunc is called by Set and Delete with the name of the field changed
+// and its tag before and after the change.  old is nil if the field was not
+// present and new is nil if it was deleted.
+type ChangeFunc func(name FieldName, old, new *tiff.Tag)
+
+// OnChange registers fn to be called on every change made to the fields of
+// x by Set and Delete, e.g. to record an undo stack or an audit log.  A nil
+// fn removes the hook.  Fields loaded by Decode are not reported.
+func (x *Exif) OnChange(fn ChangeFunc) {
+	x.onChange = fn
+}
+
+// Set stores tag as the field name, replacing any present.  name may be a
+// qualified name (see Qualified); otherwise the field is recorded in the
+// group it was loaded from or, for a new field, the group the EXIF
+// specification places it in.  Set changes the decoded fields only: x.Tiff
+// and x.Raw are unaffected.
+func (x *Exif) Set(name FieldName, tag *tiff.Tag) {
+	group, bare := name.Group()
+	if group == "" {
+		if f, ok := x.main.get(name); ok {
+			group = f.group
+		} else if spec, ok := fieldSpecs[name]; ok {
+			group = spec.group
+		}
+	}
+	old, _ := x.Get(name)
+
+	if f, ok := x.main.get(bare); ok && f.group != group && f.group != "" && group != "" {
+		// The field of the same name from another group stays accessible
+		// by qualified name, as when loading.
+		x.shadowed.set(field{Qualified(f.group, f.name), f.group, f.tag})
+	}
+	x.main.set(field{bare, group, tag})
+	x.shadowed.delete(Qualified(group, bare))
+	x.dropDups(group, bare)
+	x.changed(name, old, tag)
+}
+
+// Delete removes the field name, which may be a qualified name, from x.
+// Like Set, it does not change x.Tiff or x.Raw.
+func (x *Exif) Delete(name FieldName) {
+	old, err := x.Get(name)
+	if err != nil {
+		return
+	}
+	group, bare := name.Group()
+	if f, ok := x.main.get(bare); ok && (group == "" || f.group == group) {
+		x.main.delete(bare)
+		x.dropDups(f.group, bare)
+	}
+	x.shadowed.delete(name)
+	if group != "" {
+		x.dropDups(group, bare)
+	}
+	x.changed(name, old, nil)
+}
+
+func (x *Exif) changed(name FieldName, old, new *tiff.Tag) {
+	if x.onChange != nil {
+		x.onChange(name, old, new)
+	}
+}
+
+// Remove removes every instance of the field name from x: unlike Delete,
+// which removes the field Get returns, an unqualified name also removes the
+// fields of the same name from the other groups (e.g. the Orientation of
+// both IFD0 and IFD1) and their repeated instances.  A qualified name is
+// removed as by Delete.
+func (x *Exif) Remove(name FieldName) {
+	if group, _ := name.Group(); group != "" {
+		x.Delete(name)
+		return
+	}
+	var names []FieldName
+	for _, f := range x.shadowed {
+		if _, bare := f.name.Group(); bare == name {
+			names = append(names, f.name)
+		}
+	}
+	x.Delete(name)
+	for _, qname := range names {
+		x.Delete(qname)
+	}
+}
@@ -0,0 +1,69 @@
+package exif
+
+import (
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestSetDelete(t *testing.T) {
+	type change struct {
+		name     FieldName
+		old, new string
+	}
+	var changes []change
+	str := func(tag *tiff.Tag) string {
+		if tag == nil {
+			return ""
+		}
+		return tag.String()
+	}
+
+	x := &Exif{}
+	x.OnChange(func(name FieldName, old, new *tiff.Tag) {
+		changes = append(changes, change{name, str(old), str(new)})
+	})
+	x.Set(Artist, testString(t, "A"))
+	x.Set(Artist, testString(t, "B"))
+	x.Delete(Artist)
+	x.Delete(Artist)
+	x.Set(Make, testString(t, "Canon"))
+
+	want := []change{
+		{Artist, "", `"A"`},
+		{Artist, `"A"`, `"B"`},
+		{Artist, `"B"`, ""},
+		{Make, "", `"Canon"`},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got changes %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d is %v, want %v", i, changes[i], want[i])
+		}
+	}
+
+	if _, err := x.Get(Artist); err == nil {
+		t.Errorf("deleted field present")
+	}
+	if got := x.QualifiedName(Make); got != Qualified(GroupIFD0, Make) {
+		t.Errorf("new field recorded as %v", got)
+	}
+
+	// Setting a field of another group keeps the first by qualified name.
+	x.Set(Qualified(GroupIFD1, Make), testString(t, "Nikon"))
+	if tag, err := x.Get(Qualified(GroupIFD0, Make)); err != nil || tag.String() != `"Canon"` {
+		t.Errorf("IFD0 Make is %v, %v", tag, err)
+	}
+	if tag, err := x.Get(Make); err != nil || tag.String() != `"Nikon"` {
+		t.Errorf("Make is %v, %v", tag, err)
+	}
+	x.Delete(Qualified(GroupIFD0, Make))
+	if _, err := x.Get(Qualified(GroupIFD0, Make)); err == nil {
+		t.Errorf("deleted IFD0 Make present")
+	}
+	if _, err := x.Get(Make); err != nil {
+		t.Errorf("IFD1 Make deleted: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// encodeGroups are the IFDs Encode writes, in file order.  Each sub-IFD is
+// referenced by the pointer field of its parent.
+var encodeGroups = []struct {
+	group  string
+	parent string
+	ptr    uint16
+}{
+	{GroupIFD0, "", 0},
+	{GroupExif, GroupIFD0, ExifIFDPointerID},
+	{GroupInterop, GroupExif, InteropIFDPointerID},
+	{GroupGPS, GroupIFD0, GPSIFDPointerID},
+	{GroupIFD1, "", 0},
+}
+
+// Tag IDs of the thumbnail fields, which Encode rewrites.
+const (
+	thumbOffsetID = 0x0201
+	thumbLengthID = 0x0202
+)
+
+// notEncoded are the IDs of fields locating data Encode does not copy.
+var notEncoded = map[uint16]bool{
+	0x0111: true, // StripOffsets
+	0x0117: true, // StripByteCounts
+	0x0144: true, // TileOffsets
+	0x0145: true, // TileByteCounts
+	0x014A: true, // SubIFDs
+}
+
+// Encode writes the fields of x to w as a TIFF structure in the byte order
+// order, as found in the APP1 segment of JPEG files after the "Exif\x00\x00"
+// header: IFD0 with the Exif (and Interoperability) and GPS sub-IFDs, then
+// IFD1 with the JPEG thumbnail, if any.  Changes made with Set and Delete
+// are included.
+//
+// Fields are written to the IFD of their group, in the order of their tag
+// IDs; makernote fields are only written as part of the MakerNote field.
+// The sub-IFD pointers and thumbnail offset are computed anew.  Fields
+// locating image data not held by x (StripOffsets, TileOffsets and
+// SubIFDs, with their byte counts) are left out.  Makernotes whose values
+// are located relative to the start of the TIFF structure, rather than to
+// the makernote, may no longer be readable.
+func Encode(w io.Writer, x *Exif, order binary.ByteOrder) error {
+	b, err := x.encode(order)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface,
+// encoding x like Encode in the byte order of the data x was decoded from,
+// or big endian for an Exif not decoded from a TIFF structure.
+func (x *Exif) MarshalBinary() ([]byte, error) {
+	order := x.ByteOrder()
+	if order == nil {
+		order = binary.BigEndian
+	}
+	return x.encode(order)
+}
+
+func (x *Exif) encode(order binary.ByteOrder) ([]byte, error) {
+	if err := x.Load(); err != nil {
+		return nil, err
+	}
+
+	dirs := map[string][]*tiff.Tag{}
+	seen := map[string]map[uint16]bool{}
+	add := func(group string, tag *tiff.Tag) {
+		if seen[group] == nil {
+			seen[group] = map[uint16]bool{}
+		}
+		if seen[group][tag.Id] {
+			return
+		}
+		seen[group][tag.Id] = true
+		t := *tag
+		t.ConvertOrder(order)
+		dirs[group] = append(dirs[group], &t)
+	}
+	for _, fs := range []fields{x.main, x.shadowed} {
+		for _, f := range fs {
+			group := f.group
+			if group == "" {
+				_, bare := f.name.Group()
+				group = fieldSpecs[bare].group
+			}
+			switch {
+			case f.tag == nil || notEncoded[f.tag.Id]:
+				continue
+			case f.tag.Id == ExifIFDPointerID, f.tag.Id == GPSIFDPointerID, f.tag.Id == InteropIFDPointerID:
+				continue
+			case group == GroupIFD1 && (f.tag.Id == thumbOffsetID || f.tag.Id == thumbLengthID):
+				continue
+			}
+			if _, ok := groupOrder[group]; ok {
+				add(group, f.tag)
+			}
+		}
+	}
+
+	// Pointers are added as placeholders, set once the layout is known.
+	ptrs := map[string]*tiff.Tag{}
+	for i := len(encodeGroups) - 1; i >= 0; i-- {
+		g := encodeGroups[i]
+		if g.parent == "" || len(dirs[g.group]) == 0 {
+			continue
+		}
+		ptr, err := tiff.NewTag(g.ptr, tiff.DTLong, order, uint32(0))
+		if err != nil {
+			return nil, err
+		}
+		ptrs[g.group] = ptr
+		dirs[g.parent] = append(dirs[g.parent], ptr)
+	}
+	thumb, _ := x.JpegThumbnail()
+	var thumbOff *tiff.Tag
+	if len(thumb) > 0 {
+		var err error
+		if thumbOff, err = tiff.NewTag(thumbOffsetID, tiff.DTLong, order, uint32(0)); err != nil {
+			return nil, err
+		}
+		length, err := tiff.NewTag(thumbLengthID, tiff.DTLong, order, uint32(len(thumb)))
+		if err != nil {
+			return nil, err
+		}
+		dirs[GroupIFD1] = append(dirs[GroupIFD1], thumbOff, length)
+	}
+
+	// Lay out the IFDs, each followed by its values, then the thumbnail.
+	offs := map[string]uint32{}
+	end := int64(8)
+	for _, g := range encodeGroups {
+		tags := dirs[g.group]
+		if len(tags) == 0 && g.group != GroupIFD0 {
+			continue
+		}
+		sort.SliceStable(tags, func(i, j int) bool { return tags[i].Id < tags[j].Id })
+		offs[g.group] = uint32(end)
+		end += dirSize(tags)
+	}
+	if end+int64(len(thumb)) > math.MaxUint32 {
+		return nil, errors.New("exif: encoded data exceeds 4 GiB")
+	}
+	for group, ptr := range ptrs {
+		order.PutUint32(ptr.Val, offs[group])
+	}
+	if thumbOff != nil {
+		order.PutUint32(thumbOff.Val, uint32(end))
+	}
+
+	b := make([]byte, 8, end+int64(len(thumb)))
+	if order == binary.LittleEndian {
+		copy(b, "II*\x00")
+	} else {
+		copy(b, "MM\x00*")
+	}
+	order.PutUint32(b[4:], offs[GroupIFD0])
+	for _, g := range encodeGroups {
+		off, ok := offs[g.group]
+		if !ok {
+			continue
+		}
+		var next uint32
+		if g.group == GroupIFD0 {
+			next = offs[GroupIFD1]
+		}
+		b = appendDir(b, off, dirs[g.group], next, order)
+	}
+	return append(b, thumb...), nil
+}
+
+// dirSize returns the size of the IFD holding tags, including its values.
+func dirSize(tags []*tiff.Tag) int64 {
+	n := int64(2 + 12*len(tags) + 4)
+	for _, t := range tags {
+		if len(t.Val) > 4 {
+			n += int64(len(t.Val) + len(t.Val)%2)
+		}
+	}
+	return n
+}
+
+// appendDir appends to b the IFD holding tags, at offset off, followed by
+// the values that do not fit in its entries, word aligned.
+func appendDir(b []byte, off uint32, tags []*tiff.Tag, next uint32, order binary.ByteOrder) []byte {
+	val := off + uint32(2+12*len(tags)+4)
+	var entry [12]byte
+	var vals []byte
+	order.PutUint16(entry[:], uint16(len(tags)))
+	b = append(b, entry[:2]...)
+	for _, t := range tags {
+		order.PutUint16(entry[:], t.Id)
+		order.PutUint16(entry[2:], uint16(t.Type))
+		order.PutUint32(entry[4:], t.Count)
+		order.PutUint32(entry[8:], 0)
+		if len(t.Val) > 4 {
+			order.PutUint32(entry[8:], val+uint32(len(vals)))
+			vals = append(vals, t.Val...)
+			if len(t.Val)%2 != 0 {
+				vals = append(vals, 0)
+			}
+		} else {
+			copy(entry[8:], t.Val)
+		}
+		b = append(b, entry[:]...)
+	}
+	order.PutUint32(entry[:], next)
+	b = append(b, entry[:4]...)
+	return append(b, vals...)
+}
@@ -0,0 +1,113 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// encodedValues returns the values of the fields of x Encode copies, by
+// qualified name.
+func encodedValues(x *Exif) map[FieldName]string {
+	vals := map[FieldName]string{}
+	for _, fs := range []fields{x.main, x.shadowed} {
+		for _, f := range fs {
+			if notEncoded[f.tag.Id] || f.tag.Id == ExifIFDPointerID || f.tag.Id == GPSIFDPointerID ||
+				f.tag.Id == InteropIFDPointerID || f.tag.Id == thumbOffsetID {
+				continue
+			}
+			_, bare := f.name.Group()
+			vals[Qualified(f.group, bare)] = f.tag.String()
+		}
+	}
+	return vals
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join(*dataDir, "samples", "*.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	synth, _ := filepath.Glob(filepath.Join(*dataDir, "testdata", "synth", "??_*"))
+	names = append(names, synth...)
+	if len(names) == 0 {
+		t.Skip("no sample images")
+	}
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := Decode(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		want := encodedValues(x)
+		thumb, _ := x.JpegThumbnail()
+
+		for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			var buf bytes.Buffer
+			if err := Encode(&buf, x, order); err != nil {
+				t.Errorf("%s: Encode(%v): %v", name, order, err)
+				continue
+			}
+			y, err := Decode(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Errorf("%s: decode of %v encoding: %v", name, order, err)
+				continue
+			}
+			if y.ByteOrder() != order {
+				t.Errorf("%s: encoded in %v, want %v", name, y.ByteOrder(), order)
+			}
+			got := encodedValues(y)
+			for field, v := range want {
+				if got[field] != v {
+					t.Errorf("%s (%v): %v = %s, want %s", name, order, field, got[field], v)
+				}
+			}
+			if len(got) != len(want) {
+				t.Errorf("%s (%v): %d fields, want %d", name, order, len(got), len(want))
+			}
+			if th, _ := y.JpegThumbnail(); !bytes.Equal(th, thumb) {
+				t.Errorf("%s (%v): thumbnail of %d bytes, want %d", name, order, len(th), len(thumb))
+			}
+			if ws := y.ValidateLayout(); len(ws) > 0 {
+				t.Errorf("%s (%v): layout warnings %v", name, order, ws)
+			}
+		}
+	}
+}
+
+func TestMarshalBinarySet(t *testing.T) {
+	x := &Exif{}
+	for name, s := range map[FieldName]string{Model: "Synth 2", DateTimeOriginal: "2024:01:02 03:04:05", GPSMapDatum: "WGS-84"} {
+		info, _ := LookupField(name)
+		tag, err := tiff.NewTag(info.ID, tiff.DTAscii, binary.BigEndian, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x.Set(name, tag)
+	}
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []FieldName{Model, DateTimeOriginal, GPSMapDatum} {
+		want, _ := x.Get(name)
+		if got, err := y.Get(name); err != nil || got.String() != want.String() {
+			t.Errorf("%v = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := y.Get(ExifIFDPointer); err != nil {
+		t.Error("no Exif IFD pointer")
+	}
+}
@@ -0,0 +1,67 @@
+package exif
+
+import (
+	"errors"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// The environmental fields of EXIF 2.31 record the conditions of the shot,
+// as measured by sensors of the camera.  Their accessors return an error
+// for values the camera recorded as unknown (with a denominator of
+// 0xFFFFFFFF).
+
+// Temperature returns the ambient temperature, in degrees Celsius.
+func (x *Exif) Temperature() (float64, error) {
+	return x.envFloat(Temperature)
+}
+
+// Humidity returns the ambient relative humidity, in percent.
+func (x *Exif) Humidity() (float64, error) {
+	return x.envFloat(Humidity)
+}
+
+// Pressure returns the air (or water) pressure, in hectopascals.
+func (x *Exif) Pressure() (float64, error) {
+	return x.envFloat(Pressure)
+}
+
+// WaterDepth returns the depth under water, in meters; it is negative
+// above the water surface.
+func (x *Exif) WaterDepth() (float64, error) {
+	return x.envFloat(WaterDepth)
+}
+
+// Acceleration returns the acceleration of the camera, in milligals
+// (10^-5 m/s²).
+func (x *Exif) Acceleration() (float64, error) {
+	return x.envFloat(Acceleration)
+}
+
+// CameraElevationAngle returns the elevation angle of the optical axis of
+// the camera from the horizontal, in degrees.
+func (x *Exif) CameraElevationAngle() (float64, error) {
+	return x.envFloat(CameraElevationAngle)
+}
+
+// envFloat returns the value of the environmental field name.
+func (x *Exif) envFloat(name FieldName) (float64, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return 0, err
+	}
+	if tag.Format() != tiff.RatVal {
+		return 0, errors.New("exif: " + string(name) + " is not a rational")
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case den == 0xFFFFFFFF || (den == -1 && tag.Type == tiff.DTSRational):
+		return 0, errors.New("exif: " + string(name) + " is unknown")
+	case den == 0:
+		return 0, errors.New("exif: " + string(name) + " has a zero denominator")
+	}
+	return ratFloat(num, den), nil
+}
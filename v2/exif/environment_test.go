@@ -0,0 +1,48 @@
+package exif
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestEnvironment(t *testing.T) {
+	x := &Exif{}
+	set := func(name FieldName, typ tiff.DataType, num, den int64) {
+		tag, err := tiff.NewTag(0, typ, binary.BigEndian, [2]int64{num, den})
+		if err != nil {
+			t.Fatal(err)
+		}
+		x.setTag(name, tag)
+	}
+	set(Temperature, tiff.DTSRational, -55, 10)
+	set(Humidity, tiff.DTRational, 65, 1)
+	set(Pressure, tiff.DTRational, 10132, 10)
+	set(WaterDepth, tiff.DTSRational, 125, 10)
+	set(Acceleration, tiff.DTRational, 0xFFFFFFFF, 0xFFFFFFFF)
+	set(CameraElevationAngle, tiff.DTSRational, -1, -1)
+
+	for _, tt := range []struct {
+		name string
+		fn   func() (float64, error)
+		want float64
+	}{
+		{"Temperature", x.Temperature, -5.5},
+		{"Humidity", x.Humidity, 65},
+		{"Pressure", x.Pressure, 1013.2},
+		{"WaterDepth", x.WaterDepth, 12.5},
+	} {
+		if v, err := tt.fn(); err != nil || v != tt.want {
+			t.Errorf("%s() = %v, %v; want %v", tt.name, v, err, tt.want)
+		}
+	}
+	for name, fn := range map[string]func() (float64, error){"Acceleration": x.Acceleration, "CameraElevationAngle": x.CameraElevationAngle} {
+		if v, err := fn(); err == nil {
+			t.Errorf("%s() of unknown value = %v", name, v)
+		}
+	}
+	if _, err := (&Exif{}).Temperature(); !IsTagNotPresentError(err) {
+		t.Errorf("Temperature() of empty Exif: %v", err)
+	}
+}
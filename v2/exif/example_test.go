@@ -5,8 +5,8 @@ import (
 	"log"
 	"os"
 
-	"github.com/rwcarlsen/goexif/exif"
-	"github.com/rwcarlsen/goexif/mknote"
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/mknote"
 )
 
 func ExampleDecode() {
@@ -26,12 +26,19 @@ func ExampleDecode() {
 		log.Fatal(err)
 	}
 
-	camModel, _ := x.Get(exif.Model) // normally, don't ignore errors!
-	fmt.Println(camModel.StringVal())
+	// Get returns an error if the field is absent, and the value accessors
+	// if it has another type or fewer values: check them before use.
+	if camModel, err := x.Get(exif.Model); err == nil {
+		model, _ := camModel.StringVal()
+		fmt.Println(model)
+	}
 
-	focal, _ := x.Get(exif.FocalLength)
-	numer, denom, _ := focal.Rat2(0) // retrieve first (only) rat. value
-	fmt.Printf("%v/%v", numer, denom)
+	if focal, err := x.Get(exif.FocalLength); err == nil {
+		numer, denom, err := focal.Rat2(0) // retrieve first (only) rat. value
+		if err == nil {
+			fmt.Printf("%v/%v", numer, denom)
+		}
+	}
 
 	// Two convenience functions exist for date/time taken and GPS coords:
 	tm, _ := x.DateTime()
@@ -0,0 +1,1370 @@
+// Package exif implements decoding of EXIF data as defined in the EXIF 2.2
+// specification (http://www.exif.org/Exif2-2.PDF).
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+const (
+	jpeg_APP1 = 0xE1
+
+	// xmpNamespace starts JPEG APP1 segments holding XMP data.
+	xmpNamespace = "http://ns.adobe.com/"
+)
+
+// Tag IDs of the pointers to the sub-IFDs and of the makernote, for code
+// that reads or builds the tiff structure directly.
+const (
+	ExifIFDPointerID    uint16 = 0x8769
+	GPSIFDPointerID     uint16 = 0x8825
+	InteropIFDPointerID uint16 = 0xA005
+	MakerNoteID         uint16 = 0x927C
+)
+
+// ErrNoExif is returned by Decode for JPEG images holding no EXIF data.
+var ErrNoExif = errors.New("exif: no EXIF data found")
+
+// A decodeError is returned when the image cannot be decoded as a tiff image.
+type decodeError struct {
+	cause error
+}
+
+func (de decodeError) Error() string {
+	return fmt.Sprintf("exif: decode failed (%v) ", de.cause.Error())
+}
+
+// IsShortReadTagValueError identifies a ErrShortReadTagValue error.
+func IsShortReadTagValueError(err error) bool {
+	de, ok := err.(decodeError)
+	if ok {
+		return de.cause == tiff.ErrShortReadTagValue
+	}
+	return false
+}
+
+// A TagNotPresentError is returned when the requested field is not
+// present in the EXIF.
+type TagNotPresentError FieldName
+
+func (tag TagNotPresentError) Error() string {
+	return fmt.Sprintf("exif: tag %q is not present", string(tag))
+}
+
+func IsTagNotPresentError(err error) bool {
+	_, ok := err.(TagNotPresentError)
+	return ok
+}
+
+// Parser allows the registration of custom parsing and field loading
+// in the Decode function.
+type Parser interface {
+	// Parse should read data from x and insert parsed fields into x via
+	// LoadTags.
+	Parse(x *Exif) error
+}
+
+// MakerNoteParser is implemented by Parsers that decode a single vendor's
+// makernote.  Decode calls CanParse for each registered MakerNoteParser (in
+// order of registration) and runs only the first one that accepts the
+// makernote.  Parsers not implementing this interface are always run.
+type MakerNoteParser interface {
+	Parser
+	// Name returns a short identifier for the parser (e.g. "Canon").
+	Name() string
+	// CanParse reports whether the parser can decode a makernote that
+	// starts with header, found in an image with the given Make field
+	// value.  It must be cheap: it is called for every decoded image.
+	CanParse(make string, header []byte) bool
+}
+
+var parsers []Parser
+
+func init() {
+	RegisterParsers(&parser{})
+}
+
+// RegisterParsers registers one or more parsers to be automatically called
+// when decoding EXIF data via the Decode function.
+func RegisterParsers(ps ...Parser) {
+	parsers = append(parsers, ps...)
+}
+
+type parser struct{}
+
+type tiffErrors map[tiffError]string
+
+func (te tiffErrors) Error() string {
+	var allErrors []string
+	for k, v := range te {
+		allErrors = append(allErrors, fmt.Sprintf("%s: %v\n", stagePrefix[k], v))
+	}
+	return strings.Join(allErrors, "\n")
+}
+
+// IsCriticalError, given the error returned by Decode, reports whether the
+// returned *Exif may contain usable information.
+func IsCriticalError(err error) bool {
+	_, ok := err.(tiffErrors)
+	return !ok
+}
+
+// IsExifError reports whether the error happened while decoding the EXIF
+// sub-IFD.
+func IsExifError(err error) bool {
+	if te, ok := err.(tiffErrors); ok {
+		_, isExif := te[loadExif]
+		return isExif
+	}
+	return false
+}
+
+// IsGPSError reports whether the error happened while decoding the GPS sub-IFD.
+func IsGPSError(err error) bool {
+	if te, ok := err.(tiffErrors); ok {
+		_, isGPS := te[loadExif]
+		return isGPS
+	}
+	return false
+}
+
+// IsInteroperabilityError reports whether the error happened while decoding the
+// Interoperability sub-IFD.
+func IsInteroperabilityError(err error) bool {
+	if te, ok := err.(tiffErrors); ok {
+		_, isInterop := te[loadInteroperability]
+		return isInterop
+	}
+	return false
+}
+
+type tiffError int
+
+const (
+	loadExif tiffError = iota
+	loadGPS
+	loadInteroperability
+)
+
+var stagePrefix = map[tiffError]string{
+	loadExif:             "loading EXIF sub-IFD",
+	loadGPS:              "loading GPS sub-IFD",
+	loadInteroperability: "loading Interoperability sub-IFD",
+}
+
+// Parse reads data from the tiff data in x and populates the tags
+// in x. If parsing a sub-IFD fails, the error is recorded and
+// parsing continues with the remaining sub-IFDs.
+func (p *parser) Parse(x *Exif) error {
+	if len(x.Tiff.Dirs) == 0 {
+		return errors.New("Invalid exif data")
+	}
+	defer x.setGroup("")
+	x.setGroup(GroupIFD0)
+	x.LoadTags(x.Tiff.Dirs[0], exifFields, false)
+
+	// thumbnails
+	if len(x.Tiff.Dirs) >= 2 {
+		x.setGroup(GroupIFD1)
+		x.LoadTags(x.Tiff.Dirs[1], thumbnailFields, false)
+	}
+
+	te := make(tiffErrors)
+
+	// recurse into exif, gps, and interop sub-IFDs
+	if err := loadSubDir(x, GroupExif, ExifIFDPointer, exifFields); err != nil {
+		te[loadExif] = err.Error()
+	}
+	if err := loadSubDir(x, GroupGPS, GPSInfoIFDPointer, gpsFields); err != nil {
+		te[loadGPS] = err.Error()
+	}
+
+	if err := loadSubDir(x, GroupInterop, InteroperabilityIFDPointer, interopFields); err != nil {
+		te[loadInteroperability] = err.Error()
+	}
+	if len(te) > 0 {
+		return te
+	}
+	return nil
+}
+
+func loadSubDir(x *Exif, group string, ptr FieldName, fieldMap map[uint16]FieldName) error {
+	r := bytes.NewReader(x.Raw)
+
+	tag, err := x.Get(ptr)
+	if err != nil {
+		return nil
+	}
+	offset, err := tag.Int64(0)
+	if err != nil {
+		return nil
+	}
+
+	_, err = r.Seek(offset, 0)
+	if err != nil {
+		return fmt.Errorf("exif: seek to sub-IFD %s failed: %v", ptr, err)
+	}
+	keep := x.keepFunc(group, fieldMap)
+	subDir, _, err := tiff.DecodeDirFunc(r, x.Tiff.Order, keep)
+	if x.permissive && (err != nil || !plausibleDir(subDir, fieldMap)) {
+		// Some firmwares write sub-IFDs in the opposite byte order from
+		// the TIFF header.
+		r.Seek(offset, 0)
+		swapped, _, err2 := tiff.DecodeDirFunc(r, swapOrder(x.Tiff.Order), keep)
+		if err2 == nil && plausibleDir(swapped, fieldMap) {
+			subDir, err = swapped, nil
+			x.warnings = append(x.warnings, Warning{ptr, "sub-IFD stored in swapped byte order"})
+		}
+	}
+	if err != nil {
+		x.warnings = append(x.warnings, Warning{ptr, "sub-IFD skipped: " + err.Error()})
+		return fmt.Errorf("exif: sub-IFD %s decode failed: %v", ptr, err)
+	}
+	x.setGroup(group)
+	x.LoadTags(subDir, fieldMap, false)
+	return nil
+}
+
+// plausibleDir reports whether most tags of d, decoded from an IFD with the
+// tag ID to field name mapping fieldMap, have a known ID and data type.  An
+// IFD decoded with the wrong byte order has neither.
+func plausibleDir(d *tiff.Dir, fieldMap map[uint16]FieldName) bool {
+	known := 0
+	for _, tag := range d.Tags {
+		if _, ok := fieldMap[tag.Id]; ok && tag.Format() != tiff.OtherVal {
+			known++
+		}
+	}
+	return known > 0 && 2*known >= len(d.Tags)
+}
+
+// swapOrder returns the byte order opposite to order.
+func swapOrder(order binary.ByteOrder) binary.ByteOrder {
+	if order == binary.BigEndian {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+// Exif provides access to decoded EXIF metadata fields and values.
+type Exif struct {
+	Tiff *tiff.Tiff
+	main fields
+	Raw  []byte
+
+	ctmd         []CTMDRecord
+	xmp          []byte
+	jpegFP       *JPEGFingerprint
+	comments     []string
+	mpf          []byte
+	jumbf        []byte
+	audio        []byte
+	mknoteParser string
+	container    Container
+	warnings     []Warning
+	permissive   bool
+	jsonStrings  tiff.StringMode
+	rationals    RationalFormat
+	subLimits    tiff.SubIFDLimits
+	onChange     ChangeFunc
+	lazy         *lazyValues
+
+	// keep holds the fields to keep, or is nil to keep all fields.
+	keep map[FieldName]bool
+
+	// group is the field group tags are currently loaded into.  shadowed
+	// holds, by qualified name, fields replaced in main by a same-named
+	// field from another group.
+	group    string
+	shadowed fields
+
+	// duplicates selects how repeated tags of an IFD are loaded.  dups
+	// holds the instances replaced under DuplicatesKeepAll.
+	duplicates DuplicatePolicy
+	dups       []field
+}
+
+// Decode parses EXIF data from r (a TIFF, JPEG, Photoshop, Canon CR3, MP4/MOV
+// or AVI video, or raw EXIF block)
+// and returns a queryable Exif object. After the EXIF data section is
+// called and the TIFF structure is decoded, each registered parser is
+// called (in order of registration). If one parser returns an error,
+// decoding terminates and the remaining parsers are not called.
+//
+// opts configure the decoding, e.g. WithMakerNoteParsers or WithContext;
+// with none, Decode is the zero Decoder.
+//
+// The error can be inspected with functions such as IsCriticalError
+// to determine whether the returned object might still be usable.
+func Decode(r io.Reader, opts ...Option) (*Exif, error) {
+	return NewDecoder(opts...).Decode(r)
+}
+
+// A Decoder decodes EXIF data like Decode, with options controlling which
+// registered parsers are run.  The zero value behaves like Decode.
+type Decoder struct {
+	// MakerNoteParsers, if non-nil, lists the names of the registered
+	// MakerNoteParsers to try, in priority order.  Registered makernote
+	// parsers not listed are not run; an empty, non-nil slice disables
+	// makernote parsing altogether.  Parsers that do not implement
+	// MakerNoteParser are unaffected.
+	MakerNoteParsers []string
+
+	// Fingerprint enables fingerprinting of the quantization and Huffman
+	// tables of JPEG images (see Exif.JPEGFingerprint).  It requires
+	// reading the JPEG header up to the start of the image data.
+	Fingerprint bool
+
+	// InternStrings makes the Decoder share the values of identical ASCII
+	// fields (e.g. Make and Model) across the files it decodes, reducing
+	// the memory held by many decoded Exif objects.  Shared tag values must
+	// not be modified.
+	InternStrings bool
+
+	// Permissive makes the Decoder accept malformed data it can make sense
+	// of, such as JPEG APP1 segments with a damaged "Exif\0\0" intro or
+	// sub-IFDs written in the opposite byte order from the TIFF header,
+	// recording each deviation as a warning (see Exif.Warnings).
+	Permissive bool
+
+	// KeepOnly, if non-nil, lists the only fields (by plain or qualified
+	// name) to keep in decoded Exif objects.  The values of other tags in
+	// the Exif, GPS and Interoperability sub-IFDs are not read at all; x.Tiff
+	// and x.Raw are unaffected.  The makernote is only loaded if MakerNote
+	// or a namespaced makernote field is listed.
+	KeepOnly []FieldName
+
+	// Progress, if non-nil, is called as decoding proceeds with the stage
+	// reached and the number of bytes read from the input so far.  If it
+	// returns an error, decoding stops and Decode returns that error, so
+	// it can be used to cancel the decoding of large files.
+	Progress func(stage Stage, n int64) error
+
+	// JSONStrings selects how decoded Exif objects render ASCII and
+	// undefined values as JSON (see Exif.SetJSONStrings).
+	JSONStrings tiff.StringMode
+
+	// Rationals selects how decoded Exif objects render rational values
+	// (see Exif.SetRationals).
+	Rationals RationalFormat
+
+	// DropImplausible makes the Decoder delete fields holding obviously
+	// invalid values from decoded Exif objects, recording each as a warning
+	// (see Exif.DropImplausible).
+	DropImplausible bool
+
+	// SubIFDLimits bounds the trees of IFDs read by Exif.SubIFDs.  The
+	// zero value applies the tiff package defaults.
+	SubIFDLimits tiff.SubIFDLimits
+
+	// Duplicates selects which of the tags repeated within an IFD is
+	// loaded.  By default the last one is.
+	Duplicates DuplicatePolicy
+
+	mu       sync.Mutex
+	interner *tiff.Interner
+	ctx      context.Context // set by WithContext
+}
+
+// InternStats returns the number of distinct strings held by d and the
+// number of field values that were replaced by a shared copy.  Both are
+// zero unless d.InternStrings is set.
+func (d *Decoder) InternStats() (distinct, interned int) {
+	d.mu.Lock()
+	in := d.interner
+	d.mu.Unlock()
+	if in == nil {
+		return 0, 0
+	}
+	return in.Stats()
+}
+
+// intern shares the ASCII values of the fields of x with those of
+// previously decoded files.
+func (d *Decoder) intern(x *Exif) {
+	d.mu.Lock()
+	if d.interner == nil {
+		d.interner = tiff.NewInterner()
+	}
+	in := d.interner
+	d.mu.Unlock()
+
+	for _, f := range x.main {
+		in.Intern(f.tag)
+	}
+	for _, f := range x.shadowed {
+		in.Intern(f.tag)
+	}
+}
+
+// parsers returns the registered parsers to run, honoring
+// d.MakerNoteParsers.  The selected makernote parsers take the place of the
+// first registered one.
+func (d *Decoder) parsers() ([]Parser, error) {
+	if d.MakerNoteParsers == nil {
+		return parsers, nil
+	}
+
+	byName := map[string]Parser{}
+	for _, p := range parsers {
+		if mp, ok := p.(MakerNoteParser); ok {
+			byName[mp.Name()] = p
+		}
+	}
+	var selected []Parser
+	for _, name := range d.MakerNoteParsers {
+		p, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("exif: makernote parser %q is not registered", name)
+		}
+		selected = append(selected, p)
+	}
+
+	var ps []Parser
+	for _, p := range parsers {
+		if _, ok := p.(MakerNoteParser); !ok {
+			ps = append(ps, p)
+		} else if selected != nil {
+			ps = append(ps, selected...)
+			selected = nil
+		}
+	}
+	return ps, nil
+}
+
+// Decode parses EXIF data from r, running the parsers selected by d.
+func (d *Decoder) Decode(r io.Reader) (*Exif, error) {
+	return d.decodeProgress(r, d.Progress)
+}
+
+// decodeProgress is Decode, reporting progress to fn instead of
+// d.Progress.
+func (d *Decoder) decodeProgress(r io.Reader, fn func(Stage, int64) error) (*Exif, error) {
+	if d.ctx != nil {
+		fn = contextProgress(d.ctx, fn)
+	}
+	if fn == nil {
+		return d.decode(r, nil)
+	}
+	pr := &progressReader{r: r, fn: fn}
+	x, err := d.decode(pr, pr)
+	if pr.err != nil {
+		return nil, pr.err
+	}
+	return x, err
+}
+
+func (d *Decoder) decode(r io.Reader, pr *progressReader) (*Exif, error) {
+	parsers, err := d.parsers()
+	if err != nil {
+		return nil, err
+	}
+
+	// EXIF data in JPEG is stored in the APP1 marker. EXIF data uses the TIFF
+	// format to store data.
+	// If we're parsing a TIFF image, we don't need to strip away any data.
+	// If we're parsing a JPEG image, we need to strip away the JPEG APP1
+	// marker and also the EXIF header.
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(r, header)
+	if err != nil {
+		return nil, fmt.Errorf("exif: error reading 4 byte header, got %d, %v", n, err)
+	}
+
+	var isTiff bool
+	var isRawExif bool
+	var isCR3 bool
+	var isVideo bool
+	var isAVIFile bool
+	var isPSDFile bool
+	var assumeJPEG bool
+	switch string(header) {
+	case "II*\x00":
+		// TIFF - Little endian (Intel)
+		isTiff = true
+	case "MM\x00*":
+		// TIFF - Big endian (Motorola)
+		isTiff = true
+	case "Exif":
+		isRawExif = true
+	case "8BPS":
+		isPSDFile = true
+	default:
+		// Not TIFF, assume JPEG
+		assumeJPEG = true
+
+		// ISO base media files (e.g. Canon CR3) are identified by the
+		// ftyp box type in bytes 4-8.
+		more := make([]byte, 4)
+		n, _ := io.ReadFull(r, more)
+		header = append(header, more[:n]...)
+		switch {
+		case isBMFF(header):
+			// The major brand tells Canon CR3 stills from MP4/MOV video.
+			brand := make([]byte, 4)
+			n, _ := io.ReadFull(r, brand)
+			header = append(header, brand[:n]...)
+			if string(brand[:n]) == "crx " {
+				isCR3, assumeJPEG = true, false
+			} else {
+				isVideo, assumeJPEG = true, false
+			}
+		case isQuickTime(header):
+			isVideo, assumeJPEG = true, false
+		case string(header[:4]) == "RIFF":
+			form := make([]byte, 4)
+			n, _ := io.ReadFull(r, form)
+			header = append(header, form[:n]...)
+			if isAVI(header) {
+				isAVIFile, assumeJPEG = true, false
+			}
+		}
+	}
+
+	// Put the header bytes back into the reader.
+	r = io.MultiReader(bytes.NewReader(header), r)
+	var (
+		er  *bytes.Reader
+		tif *tiff.Tiff
+		sec *appSec
+		x   *Exif
+		fp  *JPEGFingerprint
+		ws  []Warning
+	)
+
+	switch {
+	case isCR3:
+		var c *cr3
+		c, err = decodeCR3(r)
+		if err == nil {
+			x, err = loadCR3(c)
+		}
+	case isVideo:
+		var v *video
+		v, err = decodeVideo(r)
+		if err == nil {
+			x, err = loadVideo(v)
+		}
+	case isAVIFile:
+		var a *avi
+		a, err = decodeAVI(r)
+		if err == nil {
+			x, err = loadAVI(a)
+		}
+	case isPSDFile:
+		var p *psd
+		p, err = decodePSD(r)
+		if err == nil {
+			x, err = loadPSD(p)
+		}
+	case isRawExif:
+		var header [6]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, fmt.Errorf("exif: unexpected raw exif header read error")
+		}
+		if got, want := string(header[:]), "Exif\x00\x00"; got != want {
+			return nil, fmt.Errorf("exif: unexpected raw exif header; got %q, want %q", got, want)
+		}
+		fallthrough
+	case isTiff:
+		// Functions below need the IFDs from the TIFF data to be stored in a
+		// *bytes.Reader.  We use TeeReader to get a copy of the bytes as a
+		// side-effect of tiff.Decode() doing its work.
+		b := &bytes.Buffer{}
+		tr := io.TeeReader(r, b)
+		tif, err = tiff.Decode(tr)
+		er = bytes.NewReader(b.Bytes())
+	case assumeJPEG:
+		var head *bytes.Buffer
+		if d.Fingerprint {
+			// Keep a copy of the JPEG header for fingerprinting.
+			head = &bytes.Buffer{}
+			r = bufio.NewReader(io.TeeReader(r, head))
+		}
+		br, ok := r.(*bufio.Reader)
+		if !ok {
+			br = bufio.NewReader(r)
+			r = br
+		}
+		// Locate the JPEG APP1 header.
+		sec, err = newAppSec(jpeg_APP1, br)
+		if err == io.EOF {
+			err = ErrNoExif
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n := sec.appendContinuations(br); n > 0 {
+			ws = append(ws, Warning{Msg: fmt.Sprintf("EXIF data continued in %d more APP1 segments", n)})
+		}
+		sec.readMeta(br)
+		if head != nil {
+			fp = readJPEGTables(head, r)
+		}
+		// Strip away EXIF header.
+		var intro string
+		er, intro, err = sec.exifReader(d.Permissive)
+		if err != nil {
+			return nil, err
+		}
+		if intro != "" {
+			ws = append(ws, Warning{Msg: fmt.Sprintf("malformed Exif intro %q", intro)})
+		}
+		tif, err = tiff.Decode(er)
+	}
+
+	if err != nil {
+		return nil, decodeError{cause: err}
+	}
+
+	if x == nil {
+		er.Seek(0, 0)
+		raw, err := ioutil.ReadAll(er)
+		if err != nil {
+			return nil, decodeError{cause: err}
+		}
+
+		// build an exif structure from the tiff
+		x = &Exif{
+			Tiff: tif,
+			Raw:  raw,
+		}
+	}
+	x.jpegFP = fp
+	if sec != nil {
+		x.comments = sec.meta.comments
+		x.xmp = sec.meta.xmp
+		x.mpf = sec.meta.mpf
+		x.jumbf = sec.meta.jumbf
+		if n := wavSize(sec.meta.audio); n > 0 && n <= len(sec.meta.audio) {
+			x.audio = sec.meta.audio[:n]
+		}
+	}
+	x.permissive = d.Permissive
+	x.duplicates = d.Duplicates
+	x.jsonStrings = d.JSONStrings
+	x.rationals = d.Rationals
+	x.subLimits = d.SubIFDLimits
+	x.warnings = append(x.warnings, ws...)
+	switch {
+	case isCR3:
+		x.container = ContainerCR3
+	case isVideo:
+		x.container = ContainerVideo
+	case isAVIFile:
+		x.container = ContainerAVI
+	case isPSDFile:
+		x.container = ContainerPSD
+	case isRawExif:
+		x.container = ContainerRawExif
+	case isTiff:
+		x.container = ContainerTIFF
+	case assumeJPEG:
+		x.container = ContainerJPEG
+	}
+	if d.InternStrings {
+		defer d.intern(x)
+	}
+	defer x.compact()
+	if d.KeepOnly != nil {
+		x.keep = map[FieldName]bool{}
+		for _, name := range d.KeepOnly {
+			x.keep[name] = true
+		}
+		defer x.prune()
+	}
+	if d.DropImplausible {
+		defer x.DropImplausible()
+	}
+
+	for i, p := range parsers {
+		if err := pr.report(StageParse); err != nil {
+			return nil, err
+		}
+		var name interface{} = i
+		if mp, ok := p.(MakerNoteParser); ok {
+			// Only the first makernote parser that claims the makernote
+			// is run.
+			if x.mknoteParser != "" || !mp.CanParse(x.makerNoteHeader()) {
+				continue
+			}
+			x.mknoteParser = mp.Name()
+			name = mp.Name()
+			x.setGroup(GroupMakerNote + NamespaceSep + mp.Name())
+		}
+		err := p.Parse(x)
+		x.setGroup("")
+		if err != nil {
+			if _, ok := err.(tiffErrors); ok {
+				return x, err
+			}
+			// This should never happen, as Parse always returns a tiffError
+			// for now, but that could change.
+			return x, fmt.Errorf("exif: parser %v failed (%v)", name, err)
+		}
+	}
+
+	return x, nil
+}
+
+// makerNoteHeader returns the Make field value and the MakerNote bytes of x
+// (either may be empty).
+func (x *Exif) makerNoteHeader() (make string, header []byte) {
+	if tag, err := x.Get(Make); err == nil {
+		make, _ = tag.StringVal()
+	}
+	if tag, err := x.Get(MakerNote); err == nil {
+		header = tag.Val
+	}
+	return make, header
+}
+
+// MakerNoteParser returns the name of the registered MakerNoteParser that
+// decoded the makernote of x, or "" if none did.
+func (x *Exif) MakerNoteParser() string {
+	return x.mknoteParser
+}
+
+// LoadTags loads tags into the available fields from the tiff Directory
+// using the given tagid-fieldname mapping.  Used to load makernote and
+// other meta-data.  If showMissing is true, tags in d that are not in the
+// fieldMap will be loaded with the FieldName UnknownPrefix followed by the
+// tag ID (in hex format).  A tag repeated in d is loaded according to the
+// DuplicatePolicy of x, and is recorded as a warning like known fields with
+// an unexpected number of values.
+func (x *Exif) LoadTags(d *tiff.Dir, fieldMap map[uint16]FieldName, showMissing bool) {
+	seen := make(map[uint16]bool, len(d.Tags))
+	for _, tag := range d.Tags {
+		name := fieldMap[tag.Id]
+		if name == "" {
+			if !showMissing {
+				continue
+			}
+			name = FieldName(fmt.Sprintf("%v%x", UnknownPrefix, tag.Id))
+		}
+		if seen[tag.Id] {
+			if x.duplicates == DuplicatesFirst {
+				x.warnings = append(x.warnings, Warning{name, "duplicate tag ignored"})
+				continue
+			}
+			if f, ok := x.main.get(name); ok && x.duplicates == DuplicatesKeepAll {
+				x.dups = append(x.dups, f)
+			}
+			x.warnings = append(x.warnings, Warning{name, "duplicate tag replaces the earlier one"})
+		}
+		seen[tag.Id] = true
+		if spec, ok := fieldSpecs[name]; ok && spec.count != 0 && tag.Count != spec.count &&
+			tag.Type != tiff.DTAscii && tag.Type != tiff.DTUTF8 {
+			x.warnings = append(x.warnings, Warning{name, fmt.Sprintf("%d values, want %d", tag.Count, spec.count)})
+		}
+		x.setTag(name, tag)
+	}
+}
+
+// Get retrieves the EXIF tag for the given field name.
+//
+// If the tag is not known or not present, an error is returned. If the
+// tag name is known, the error will be a TagNotPresentError.
+//
+// name may also be a qualified name (see Qualified), e.g. "GPS/GPSVersion"
+// or "MakerNote.Canon/LensType".
+//
+// For an Exif returned by DecodeIndex, Get loads the value of the field
+// if it was not loaded yet.
+func (x *Exif) Get(name FieldName) (*tiff.Tag, error) {
+	tag, ok := x.lookup(name)
+	if !ok {
+		return nil, TagNotPresentError(name)
+	}
+	if x.lazy != nil && x.lazy.pending[tag] != nil {
+		if err := x.loadTags([]*tiff.Tag{tag}); err != nil {
+			return nil, err
+		}
+	}
+	return tag, nil
+}
+
+// lookup returns the tag of the field name, loaded or not.
+func (x *Exif) lookup(name FieldName) (*tiff.Tag, bool) {
+	if f, ok := x.main.get(name); ok {
+		return f.tag, true
+	}
+	if f, ok := x.main.qualified(name); ok {
+		return f.tag, true
+	}
+	if f, ok := x.shadowed.get(name); ok {
+		return f.tag, true
+	}
+	return nil, false
+}
+
+// Walker is the interface used to traverse all fields of an Exif object.
+type Walker interface {
+	// Walk is called for each non-nil EXIF field. Returning a non-nil
+	// error aborts the walk/traversal.
+	Walk(name FieldName, tag *tiff.Tag) error
+}
+
+// Walk calls the Walk method of w with the name and tag for every non-nil
+// EXIF field, in name order.  If w aborts the walk with an error, that
+// error is returned.
+func (x *Exif) Walk(w Walker) error {
+	if err := x.Load(); err != nil {
+		return err
+	}
+	for _, f := range x.main {
+		if err := w.Walk(f.name, f.tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DateTime returns the EXIF's "DateTimeOriginal" field, which
+// is the creation time of the photo. If not found, it tries
+// the "DateTime" (which is meant as the modtime) instead.
+// The error will be TagNotPresentErr if none of those tags
+// were found, or a generic error if the tag value was
+// not a string, or the error returned by time.Parse.
+//
+// If the EXIF lacks timezone information or GPS time, the returned
+// time's Location will be time.Local.
+func (x *Exif) DateTime() (time.Time, error) {
+	var dt time.Time
+	tag, err := x.Get(DateTimeOriginal)
+	if err != nil {
+		tag, err = x.Get(DateTime)
+		if err != nil {
+			return dt, err
+		}
+	}
+	if tag.Format() != tiff.StringVal {
+		return dt, errors.New("DateTime[Original] not in string format")
+	}
+	exifTimeLayout := "2006:01:02 15:04:05"
+	dateStr := strings.TrimRight(string(tag.Val), "\x00")
+	// TODO(bradfitz,mpl): look for timezone offset, GPS time, etc.
+	timeZone := time.Local
+	if tz, _ := x.TimeZone(); tz != nil {
+		timeZone = tz
+	}
+	return time.ParseInLocation(exifTimeLayout, dateStr, timeZone)
+}
+
+func (x *Exif) TimeZone() (*time.Location, error) {
+	// TODO: parse more timezone fields (e.g. Nikon WorldTime).
+	timeInfo, err := x.Get("Canon.TimeInfo")
+	if err != nil {
+		return nil, err
+	}
+	if timeInfo.Count < 2 {
+		return nil, errors.New("Canon.TimeInfo does not contain timezone")
+	}
+	offsetMinutes, err := timeInfo.Int(1)
+	if err != nil {
+		return nil, err
+	}
+	return time.FixedZone("", offsetMinutes*60), nil
+}
+
+func ratFloat(num, dem int64) float64 {
+	return float64(num) / float64(dem)
+}
+
+// Tries to parse a Geo degrees value from a string as it was found in some
+// EXIF data.
+// Supported formats so far:
+// - "52,00000,50,00000,34,01180" ==> 52 deg 50'34.0118"
+//   Probably due to locale the comma is used as decimal mark as well as the
+//   separator of three floats (degrees, minutes, seconds)
+//   http://en.wikipedia.org/wiki/Decimal_mark#Hindu.E2.80.93Arabic_numeral_system
+// - "52.0,50.0,34.01180" ==> 52deg50'34.0118"
+// - "52,50,34.01180"     ==> 52deg50'34.0118"
+func parseTagDegreesString(s string) (float64, error) {
+	const unparsableErrorFmt = "Unknown coordinate format: %s"
+	isSplitRune := func(c rune) bool {
+		return c == ',' || c == ';'
+	}
+	parts := strings.FieldsFunc(s, isSplitRune)
+	var degrees, minutes, seconds float64
+	var err error
+	switch len(parts) {
+	case 6:
+		degrees, err = strconv.ParseFloat(parts[0]+"."+parts[1], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		minutes, err = strconv.ParseFloat(parts[2]+"."+parts[3], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		minutes = math.Copysign(minutes, degrees)
+		seconds, err = strconv.ParseFloat(parts[4]+"."+parts[5], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		seconds = math.Copysign(seconds, degrees)
+	case 3:
+		degrees, err = strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		minutes, err = strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		minutes = math.Copysign(minutes, degrees)
+		seconds, err = strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+		}
+		seconds = math.Copysign(seconds, degrees)
+	default:
+		return 0.0, fmt.Errorf(unparsableErrorFmt, s)
+	}
+	return degrees + minutes/60.0 + seconds/3600.0, nil
+}
+
+func parse3Rat2(tag *tiff.Tag) ([3]float64, error) {
+	v := [3]float64{}
+	for i := range v {
+		num, den, err := tag.Rat2(i)
+		if err != nil {
+			return v, err
+		}
+		v[i] = ratFloat(num, den)
+		if tag.Count < uint32(i+2) {
+			break
+		}
+	}
+	return v, nil
+}
+
+func tagDegrees(tag *tiff.Tag) (float64, error) {
+	switch tag.Format() {
+	case tiff.RatVal:
+		// The usual case, according to the Exif spec
+		// (http://www.kodak.com/global/plugins/acrobat/en/service/digCam/exifStandard2.pdf,
+		// sec 4.6.6, p. 52 et seq.)
+		v, err := parse3Rat2(tag)
+		if err != nil {
+			return 0.0, err
+		}
+		return v[0] + v[1]/60 + v[2]/3600.0, nil
+	case tiff.StringVal:
+		// Encountered this weird case with a panorama picture taken with a HTC phone
+		s, err := tag.StringVal()
+		if err != nil {
+			return 0.0, err
+		}
+		return parseTagDegreesString(s)
+	default:
+		// don't know how to parse value, give up
+		return 0.0, fmt.Errorf("Malformed EXIF Tag Degrees")
+	}
+}
+
+// LatLong returns the latitude and longitude of the photo and
+// whether it was present.
+func (x *Exif) LatLong() (lat, long float64, err error) {
+	// All calls of x.Get might return an TagNotPresentError
+	longTag, err := x.Get(FieldName("GPSLongitude"))
+	if err != nil {
+		return
+	}
+	ewTag, err := x.Get(FieldName("GPSLongitudeRef"))
+	if err != nil {
+		return
+	}
+	latTag, err := x.Get(FieldName("GPSLatitude"))
+	if err != nil {
+		return
+	}
+	nsTag, err := x.Get(FieldName("GPSLatitudeRef"))
+	if err != nil {
+		return
+	}
+	if long, err = tagDegrees(longTag); err != nil {
+		return 0, 0, fmt.Errorf("Cannot parse longitude: %v", err)
+	}
+	if lat, err = tagDegrees(latTag); err != nil {
+		return 0, 0, fmt.Errorf("Cannot parse latitude: %v", err)
+	}
+	ew, err := ewTag.StringVal()
+	if err == nil && ew == "W" {
+		long *= -1.0
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("Cannot parse longitude: %v", err)
+	}
+	ns, err := nsTag.StringVal()
+	if err == nil && ns == "S" {
+		lat *= -1.0
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("Cannot parse longitude: %v", err)
+	}
+	return lat, long, nil
+}
+
+// String returns a pretty text representation of the decoded exif data.
+func (x *Exif) String() string {
+	x.Load()
+	var buf bytes.Buffer
+	for _, f := range x.main {
+		fmt.Fprintf(&buf, "%s: %s\n", f.name, f.tag)
+	}
+	return buf.String()
+}
+
+// JpegThumbnail returns the jpeg thumbnail if it exists. If it doesn't exist,
+// TagNotPresentError will be returned
+func (x *Exif) JpegThumbnail() ([]byte, error) {
+	offset, err := x.Get(ThumbJPEGInterchangeFormat)
+	if err != nil {
+		return nil, err
+	}
+	start, err := offset.Int(0)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := x.Get(ThumbJPEGInterchangeFormatLength)
+	if err != nil {
+		return nil, err
+	}
+	l, err := length.Int(0)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || l < 0 || start+l > len(x.Raw) {
+		return nil, errors.New("exif: thumbnail lies outside the EXIF data")
+	}
+
+	return x.Raw[start : start+l], nil
+}
+
+// MarshalJson implements the encoding/json.Marshaler interface providing output of
+// all EXIF fields present (names and values).
+func (x Exif) MarshalJSON() ([]byte, error) {
+	if err := x.Load(); err != nil {
+		return nil, err
+	}
+	m := make(map[FieldName]json.RawMessage, len(x.main))
+	for _, f := range x.main {
+		b, err := x.marshalField(f.name, f.tag)
+		if err != nil {
+			return nil, err
+		}
+		m[f.name] = b
+	}
+	return json.Marshal(m)
+}
+
+// SetJSONStrings selects how MarshalJSON renders ASCII and undefined
+// values.  The default, tiff.StringsASCII, mangles non-ASCII text;
+// tiff.StringsUTF8 keeps UTF-8 text such as non-Latin place names, and
+// tiff.StringsBase64 additionally preserves binary values as base64.
+func (x *Exif) SetJSONStrings(mode tiff.StringMode) {
+	x.jsonStrings = mode
+}
+
+type appSec struct {
+	marker byte
+	data   []byte
+
+	// jpeg is true if the section was found by walking the segments of a
+	// JPEG image.  meta holds the other metadata segments walked.
+	jpeg bool
+	meta jpegMeta
+}
+
+// jpegMeta holds the metadata read from JPEG segments other than the EXIF
+// APP1 segment.
+type jpegMeta struct {
+	comments []string // text of the COM segments
+	xmp      []byte   // XMP packet of the first APP1 XMP segment
+	mpf      []byte   // payload of the APP2 MPF segment, after "MPF\0"
+	jumbf    []byte   // payloads of the APP11 JUMBF segments, after "JP"
+
+	// audio is the WAV data embedded in the APPn segments with marker
+	// audioMarker, following audioIntro in each.
+	audio       []byte
+	audioMarker byte
+	audioIntro  []byte
+}
+
+// JPEG segment intros of XMP, MPF (multi-picture format) and JUMBF (e.g.
+// C2PA manifests) data.
+var (
+	xmpIntro   = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	mpfIntro   = []byte("MPF\x00")
+	jumbfIntro = []byte("JP")
+)
+
+// newAppSec finds marker in r and returns the corresponding application data
+// section.  In JPEG images, the marker segments are walked up to the image
+// data, so that images without the segment are rejected with ErrNoExif
+// without reading on to EOF.  Other data, and JPEG images whose segments are
+// malformed, are scanned for the marker byte by byte.
+func newAppSec(marker byte, r io.Reader) (*appSec, error) {
+	br := bufio.NewReader(r)
+	app := &appSec{marker: marker}
+
+	dataLen, err := seekSegment(marker, br, &app.meta)
+	if err != nil {
+		return nil, err
+	}
+	app.jpeg = dataLen >= 0
+
+	// seek to marker
+	for dataLen < 0 {
+		if _, err := br.ReadBytes(0xFF); err != nil {
+			return nil, err
+		}
+		c, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		} else if c != marker {
+			continue
+		}
+
+		dataLenBytes := make([]byte, 2)
+		for k, _ := range dataLenBytes {
+			c, err := br.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			dataLenBytes[k] = c
+		}
+		if dataLen = int(binary.BigEndian.Uint16(dataLenBytes)) - 2; dataLen == 0 {
+			dataLen = -1
+		}
+	}
+
+	// read section data
+	nread := 0
+	for nread < dataLen {
+		s := make([]byte, dataLen-nread)
+		n, err := br.Read(s)
+		nread += n
+		if err != nil && nread < dataLen {
+			return nil, err
+		}
+		app.data = append(app.data, s[:n]...)
+	}
+	return app, nil
+}
+
+// seekSegment walks the marker segments of the JPEG image in br up to the
+// non-empty segment with the given marker and returns its data length,
+// leaving br at the start of its data.  It returns ErrNoExif on reaching the
+// image data or the end of the image first, and -1 if br does not hold a
+// JPEG image or its segments are malformed.  The metadata segments
+// walked are recorded in meta; XMP segments are skipped even if marker is
+// APP1.
+func seekSegment(marker byte, br *bufio.Reader, meta *jpegMeta) (dataLen int, err error) {
+	if soi, err := br.Peek(2); err != nil || soi[0] != 0xFF || soi[1] != 0xD8 {
+		return -1, nil
+	}
+	br.Discard(2)
+	return nextSegment(marker, br, meta)
+}
+
+// nextSegment is seekSegment for br positioned at a marker after the SOI
+// marker.
+func nextSegment(marker byte, br *bufio.Reader, meta *jpegMeta) (dataLen int, err error) {
+	for {
+		hdr, err := br.Peek(2)
+		if err == io.EOF {
+			return 0, ErrNoExif
+		} else if err != nil {
+			return 0, err
+		}
+		if hdr[0] != 0xFF {
+			return -1, nil
+		}
+		switch m := hdr[1]; {
+		case m == 0xFF:
+			// fill byte
+			br.Discard(1)
+			continue
+		case m == jpegSOS || m == jpegEOI:
+			return 0, ErrNoExif
+		case m == 0x01 || (m >= 0xD0 && m <= 0xD7):
+			// standalone markers without a length
+			br.Discard(2)
+			continue
+		}
+
+		hdr, err = br.Peek(4)
+		if err != nil {
+			return 0, ErrNoExif
+		}
+		m, size := hdr[1], int(binary.BigEndian.Uint16(hdr[2:]))
+		if size < 2 {
+			return -1, nil
+		}
+		var intro []byte
+		switch m {
+		case jpeg_APP1:
+			if p, _ := br.Peek(4 + len(xmpIntro)); bytes.HasPrefix(p[4:], xmpIntro) {
+				intro = xmpIntro
+			}
+		case jpegAPP2:
+			if p, _ := br.Peek(4 + len(mpfIntro)); bytes.HasPrefix(p[4:], mpfIntro) {
+				intro = mpfIntro
+			}
+		case jpegAPP11:
+			if p, _ := br.Peek(4 + len(jumbfIntro)); bytes.HasPrefix(p[4:], jumbfIntro) {
+				intro = jumbfIntro
+			}
+		}
+		audio := false
+		if intro == nil && m != marker && m >= jpegAPP2 && m <= jpegAPP15 {
+			intro, audio = meta.audioSegment(br, m, size)
+		}
+		if m == marker && size > 2 && intro == nil {
+			br.Discard(4)
+			return size - 2, nil
+		}
+		if m == jpegCOM || intro != nil {
+			seg := make([]byte, 2+size)
+			if _, err := io.ReadFull(br, seg); err != nil {
+				return 0, ErrNoExif
+			}
+			data := seg[4+len(intro):]
+			switch {
+			case m == jpegCOM:
+				meta.comments = append(meta.comments, string(bytes.TrimRight(data, "\x00")))
+			case bytes.Equal(intro, xmpIntro) && meta.xmp == nil:
+				meta.xmp = data
+			case bytes.Equal(intro, mpfIntro) && meta.mpf == nil:
+				meta.mpf = data
+			case audio:
+				meta.audio = append(meta.audio, data...)
+			case bytes.Equal(intro, jumbfIntro):
+				meta.jumbf = append(meta.jumbf, data...)
+			}
+			continue
+		}
+		if _, err := br.Discard(2 + size); err == io.EOF {
+			return 0, ErrNoExif
+		} else if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// audioSegment reports whether the APPn segment at br, with marker m and
+// length size, holds WAV audio: the start of a RIFF WAVE file, if none was
+// found before, or the continuation of the one found.  It returns the bytes
+// preceding the audio data in the segment.
+func (meta *jpegMeta) audioSegment(br *bufio.Reader, m byte, size int) (intro []byte, ok bool) {
+	if meta.audio != nil {
+		if m != meta.audioMarker || len(meta.audio) >= wavSize(meta.audio) {
+			return nil, false
+		}
+		p, _ := br.Peek(4 + len(meta.audioIntro))
+		if len(p) < 4+len(meta.audioIntro) || !bytes.HasPrefix(p[4:], meta.audioIntro) {
+			return nil, false
+		}
+		return meta.audioIntro, true
+	}
+	n := size - 2
+	if n > 64 {
+		n = 64
+	}
+	p, _ := br.Peek(4 + n)
+	if len(p) < 4 {
+		return nil, false
+	}
+	for i := 4; i+12 <= len(p); i++ {
+		if string(p[i:i+4]) == "RIFF" && string(p[i+8:i+12]) == "WAVE" {
+			meta.audioMarker = m
+			meta.audioIntro = append([]byte{}, p[4:i]...)
+			return meta.audioIntro, true
+		}
+	}
+	return nil, false
+}
+
+// wavSize returns the size of the RIFF file starting wav, or 0 if wav is
+// too short to tell.
+func wavSize(wav []byte) int {
+	if len(wav) < 8 {
+		return 0
+	}
+	return 8 + int(binary.LittleEndian.Uint32(wav[4:]))
+}
+
+// readMeta walks the JPEG segments following app in br up to the image
+// data, recording the COM, XMP, MPF and JUMBF segments.
+func (app *appSec) readMeta(br *bufio.Reader) {
+	if app.jpeg {
+		nextSegment(0, br, &app.meta)
+	}
+}
+
+// appendContinuations appends to app the APP1 segments immediately
+// following it in br while its TIFF structure references data past its end.
+// Some writers split EXIF data exceeding the 64KB segment limit this way,
+// repeating the "Exif\0\0" intro or not.  XMP segments are left alone.  It
+// returns the number of segments appended.
+func (app *appSec) appendContinuations(br *bufio.Reader) int {
+	intro := []byte("Exif\x00\x00")
+	n := 0
+	for bytes.HasPrefix(app.data, intro) && tiffTruncated(app.data[len(intro):]) {
+		hdr, err := br.Peek(4 + len(xmpNamespace))
+		if err != nil || hdr[0] != 0xFF || hdr[1] != app.marker || bytes.HasPrefix(hdr[4:], []byte(xmpNamespace)) {
+			break
+		}
+		seg := make([]byte, 2+int(binary.BigEndian.Uint16(hdr[2:])))
+		if len(seg) < 4 {
+			break
+		}
+		if _, err := io.ReadFull(br, seg); err != nil {
+			break
+		}
+		app.data = append(app.data, bytes.TrimPrefix(seg[4:], intro)...)
+		n++
+	}
+	return n
+}
+
+// reader returns a reader on this appSec.
+func (app *appSec) reader() *bytes.Reader {
+	return bytes.NewReader(app.data)
+}
+
+// exifReader returns a reader on this appSec with the read cursor advanced to
+// the start of the exif's tiff encoded portion.  If permissive is true, an
+// intro with a wrong padding byte (e.g. "Exif\0\xFF") or lacking a NUL is
+// accepted if a TIFF header follows it; the malformed intro is returned.
+func (app *appSec) exifReader(permissive bool) (r *bytes.Reader, malformed string, err error) {
+	if len(app.data) >= 6 && bytes.Equal(app.data[:6], []byte("Exif\x00\x00")) {
+		return bytes.NewReader(app.data[6:]), "", nil
+	}
+	if permissive && bytes.HasPrefix(app.data, []byte("Exif")) {
+		for n := 4; n <= 7 && n+4 <= len(app.data); n++ {
+			if hdr := string(app.data[n : n+4]); hdr == "II*\x00" || hdr == "MM\x00*" {
+				return bytes.NewReader(app.data[n:]), string(app.data[:n]), nil
+			}
+		}
+	}
+	return nil, "", errors.New("exif: failed to find exif intro marker")
+}
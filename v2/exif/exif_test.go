@@ -14,7 +14,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/rwcarlsen/goexif/tiff"
+	"github.com/rwcarlsen/goexif/v2/tiff"
 )
 
 var dataDir = flag.String("test_data_dir", ".", "Directory where the data files for testing are located")
@@ -237,3 +237,63 @@ func TestZeroLengthTagError(t *testing.T) {
 		t.Fatal("wrong error:", err.Error())
 	}
 }
+
+func TestDecoderInternStrings(t *testing.T) {
+	d := &Decoder{InternStrings: true}
+	var models []*tiff.Tag
+	for i := 0; i < 2; i++ {
+		f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := d.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tag, err := x.Get(Model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		models = append(models, tag)
+	}
+
+	if &models[0].Val[0] != &models[1].Val[0] {
+		t.Errorf("Model values not shared between decodes")
+	}
+	if distinct, interned := d.InternStats(); distinct == 0 || interned < distinct {
+		t.Errorf("got %v distinct, %v interned strings", distinct, interned)
+	}
+}
+
+func TestMultiSegmentAPP1(t *testing.T) {
+	tif, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := func(data []byte) []byte {
+		return append([]byte{0xFF, 0xE1, byte((len(data) + 2) >> 8), byte(len(data) + 2)}, data...)
+	}
+
+	split := len(tif) / 2
+	for _, intro := range []string{"", "Exif\x00\x00"} {
+		jpg := []byte{0xFF, 0xD8}
+		jpg = append(jpg, seg(append([]byte("Exif\x00\x00"), tif[:split]...))...)
+		jpg = append(jpg, seg(append([]byte(intro), tif[split:]...))...)
+		jpg = append(jpg, seg([]byte(xmpNamespace+"x"))...)
+
+		x, err := Decode(bytes.NewReader(jpg))
+		if err != nil {
+			t.Fatalf("intro %q: %v", intro, err)
+		}
+		if !bytes.Equal(x.Raw, tif) {
+			t.Errorf("intro %q: reassembled %d bytes, want %d", intro, len(x.Raw), len(tif))
+		}
+		if tag, err := x.Get(ExposureTime); err != nil || tag.String() != `"1/250"` {
+			t.Errorf("intro %q: ExposureTime is %v, %v", intro, tag, err)
+		}
+		if ws := x.Warnings(); len(ws) != 1 {
+			t.Errorf("intro %q: warnings %v", intro, ws)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package exif
+
+import (
+	"errors"
+	"math"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Exposure holds the camera settings that determine how much light reached
+// the sensor for a single capture.
+type Exposure struct {
+	// FNumber is the aperture f-number (e.g. 2.8).
+	FNumber float64
+	// ExposureTime is the shutter duration in seconds.
+	ExposureTime float64
+	// ISO is the sensitivity. It is zero if the EXIF did not record it.
+	ISO float64
+}
+
+// EV returns the exposure value of the aperture/shutter combination,
+// log2(N²/t), independent of ISO.
+func (e Exposure) EV() float64 {
+	return math.Log2(e.FNumber * e.FNumber / e.ExposureTime)
+}
+
+// LightValue returns the exposure value normalized to ISO 100 (often called
+// EV100 or LV).  It describes the scene brightness the camera metered for
+// and is what deflicker tools compare across frames.  If ISO is unknown, the
+// result equals EV.
+func (e Exposure) LightValue() float64 {
+	if e.ISO <= 0 {
+		return e.EV()
+	}
+	return e.EV() - math.Log2(e.ISO/100)
+}
+
+// Diff returns the difference in stops between e and o.  A positive result
+// means e captured more light than o (i.e. e is the brighter image).
+func (e Exposure) Diff(o Exposure) float64 {
+	return o.LightValue() - e.LightValue()
+}
+
+// Exposure returns the aperture, shutter time and ISO recorded in x.  The
+// FNumber and ExposureTime fields are preferred; the APEX ApertureValue and
+// ShutterSpeedValue fields are used as a fallback.  ISO is left at zero if
+// no ISOSpeedRatings field is present.
+func (x *Exif) Exposure() (Exposure, error) {
+	var e Exposure
+	var err error
+
+	if e.FNumber, err = x.ratFloat(FNumber); err != nil {
+		av, err2 := x.ratFloat(ApertureValue)
+		if err2 != nil {
+			return e, err
+		}
+		e.FNumber = math.Pow(2, av/2)
+	}
+	if e.ExposureTime, err = x.ratFloat(ExposureTime); err != nil {
+		tv, err2 := x.ratFloat(ShutterSpeedValue)
+		if err2 != nil {
+			return e, err
+		}
+		e.ExposureTime = math.Pow(2, -tv)
+	}
+	if e.FNumber <= 0 || e.ExposureTime <= 0 {
+		return e, errors.New("exif: invalid aperture or exposure time")
+	}
+
+	if tag, err := x.Get(ISOSpeedRatings); err == nil {
+		if iso, err := tag.Int(0); err == nil {
+			e.ISO = float64(iso)
+		}
+	}
+	return e, nil
+}
+
+// ratFloat returns the first value of the named rational field as a float.
+func (x *Exif) ratFloat(name FieldName) (float64, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return 0, err
+	}
+	if tag.Format() != tiff.RatVal {
+		return 0, errors.New("exif: " + string(name) + " is not a rational")
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil {
+		return 0, err
+	}
+	if den == 0 {
+		return 0, errors.New("exif: " + string(name) + " has a zero denominator")
+	}
+	return ratFloat(num, den), nil
+}
@@ -0,0 +1,52 @@
+package exif
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExposure(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := x.Exposure()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.FNumber != 4.5 || e.ExposureTime != 1.0/125 {
+		t.Errorf("got %+v, want f/4.5 at 1/125s", e)
+	}
+	if ev, want := e.EV(), math.Log2(4.5*4.5*125); math.Abs(ev-want) > 1e-9 {
+		t.Errorf("EV = %v, want %v", ev, want)
+	}
+}
+
+func TestExposureDiff(t *testing.T) {
+	base := Exposure{FNumber: 8, ExposureTime: 1.0 / 125, ISO: 100}
+	tests := []struct {
+		e    Exposure
+		want float64
+	}{
+		{Exposure{FNumber: 8, ExposureTime: 1.0 / 125, ISO: 100}, 0},
+		{Exposure{FNumber: 8, ExposureTime: 1.0 / 250, ISO: 100}, -1},
+		{Exposure{FNumber: 5.6568542, ExposureTime: 1.0 / 125, ISO: 100}, 1},
+		{Exposure{FNumber: 8, ExposureTime: 1.0 / 125, ISO: 400}, 2},
+	}
+	for i, tt := range tests {
+		if got := tt.e.Diff(base); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%d: Diff = %v, want %v", i, got, tt.want)
+		}
+	}
+	if lv := base.LightValue(); math.Abs(lv-math.Log2(64*125)) > 1e-9 {
+		t.Errorf("LightValue = %v", lv)
+	}
+}
@@ -0,0 +1,146 @@
+package exif
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldNames returns the names in a tag ID to field name mapping.
+func fieldNames(fieldMap map[uint16]FieldName) []FieldName {
+	names := make([]FieldName, 0, len(fieldMap))
+	for _, name := range fieldMap {
+		names = append(names, name)
+	}
+	return names
+}
+
+// DecodeGPS is a fast path of Decode for geotagging: only the fields of the
+// GPS sub-IFD are kept, the values of the other sub-IFDs are not read and
+// the makernote is not parsed.  If the file has no GPS data, the returned
+// Exif has no fields and LatLong returns a TagNotPresentError.
+func DecodeGPS(r io.Reader) (*Exif, error) {
+	d := &Decoder{
+		KeepOnly:         fieldNames(gpsFields),
+		MakerNoteParsers: []string{},
+	}
+	return d.Decode(r)
+}
+
+// A TimeStamp is a time recorded in EXIF data, including its sub-second
+// part.  Zoned reports whether the time zone of Time comes from an
+// OffsetTime field; otherwise it is time.Local.
+type TimeStamp struct {
+	Time  time.Time
+	Zoned bool
+}
+
+// IsZero reports whether the time stamp is missing.
+func (ts TimeStamp) IsZero() bool {
+	return ts.Time.IsZero()
+}
+
+// Times holds the time stamps of an image.  Missing or malformed time
+// stamps are zero.
+type Times struct {
+	Original  TimeStamp // DateTimeOriginal, when the picture was taken
+	Digitized TimeStamp // DateTimeDigitized, a.k.a. CreateDate
+	Modified  TimeStamp // DateTime, a.k.a. ModifyDate
+	GPS       time.Time // GPSDateStamp and GPSTimeStamp, in UTC
+}
+
+// timeFields are the fields holding each time stamp, its sub-second part
+// and its offset from UTC.
+var timeFields = [...][3]FieldName{
+	{DateTimeOriginal, SubSecTimeOriginal, OffsetTimeOriginal},
+	{DateTimeDigitized, SubSecTimeDigitized, OffsetTimeDigitized},
+	{DateTime, SubSecTime, OffsetTime},
+}
+
+// DecodeTimes is a fast path of Decode for the time stamps of an image:
+// only the date and time fields are kept and the makernote is not parsed.
+func DecodeTimes(r io.Reader) (Times, error) {
+	keep := []FieldName{GPSDateStamp, GPSTimeStamp}
+	for _, fs := range timeFields {
+		keep = append(keep, fs[:]...)
+	}
+	d := &Decoder{KeepOnly: keep, MakerNoteParsers: []string{}}
+	x, err := d.Decode(r)
+	if x == nil {
+		return Times{}, err
+	}
+
+	var ts Times
+	for i, dst := range []*TimeStamp{&ts.Original, &ts.Digitized, &ts.Modified} {
+		*dst = x.timeStamp(timeFields[i][0], timeFields[i][1], timeFields[i][2])
+	}
+	ts.GPS = x.gpsTime()
+	return ts, err
+}
+
+// timeStamp parses the time in the field dt with its sub-second part and
+// UTC offset.
+func (x *Exif) timeStamp(dt, subsec, offset FieldName) TimeStamp {
+	var ts TimeStamp
+	s, err := x.stringVal(dt)
+	if err != nil {
+		return ts
+	}
+	loc := time.Local
+	if off, err := x.stringVal(offset); err == nil {
+		if t, err := time.Parse("-07:00", strings.TrimSpace(off)); err == nil {
+			_, secs := t.Zone()
+			loc, ts.Zoned = time.FixedZone(off, secs), true
+		}
+	}
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", strings.TrimSpace(s), loc)
+	if err != nil {
+		return ts
+	}
+	if sub, err := x.stringVal(subsec); err == nil {
+		sub = strings.TrimSpace(sub)
+		if len(sub) > 9 {
+			sub = sub[:9]
+		}
+		if ns, err := strconv.Atoi(sub + strings.Repeat("0", 9-len(sub))); err == nil {
+			t = t.Add(time.Duration(ns))
+		}
+	}
+	ts.Time = t
+	return ts
+}
+
+// gpsTime returns the UTC time of the GPS fix, or the zero time.
+func (x *Exif) gpsTime() time.Time {
+	date, err := x.stringVal(GPSDateStamp)
+	if err != nil {
+		return time.Time{}
+	}
+	day, err := time.Parse("2006:01:02", strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}
+	}
+	tag, err := x.Get(GPSTimeStamp)
+	if err != nil || tag.Count != 3 {
+		return time.Time{}
+	}
+	var secs float64
+	for i, unit := range []float64{3600, 60, 1} {
+		num, den, err := tag.Rat2(i)
+		if err != nil || den == 0 {
+			return time.Time{}
+		}
+		secs += float64(num) / float64(den) * unit
+	}
+	return day.Add(time.Duration(secs * float64(time.Second)))
+}
+
+// stringVal returns the string value of the field name.
+func (x *Exif) stringVal(name FieldName) (string, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return "", err
+	}
+	return tag.StringVal()
+}
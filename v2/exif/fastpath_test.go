@@ -0,0 +1,68 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestDecodeGPS(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", "be_gps.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := DecodeGPS(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lat, long, err := x.LatLong()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lat != 45.5 || long < -122.27 || long > -122.26 {
+		t.Errorf("LatLong = %v, %v", lat, long)
+	}
+	x.Walk(walkFunc(func(name FieldName, tag *tiff.Tag) error {
+		if name[:3] != "GPS" {
+			t.Errorf("non-GPS field %v kept", name)
+		}
+		return nil
+	}))
+}
+
+func TestDecodeTimes(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	ts, err := DecodeTimes(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2001, 2, 3, 4, 5, 6, 0, time.Local)
+	if !ts.Original.Time.Equal(want) || ts.Original.Zoned {
+		t.Errorf("Original = %+v, want %v", ts.Original, want)
+	}
+	if !ts.GPS.IsZero() {
+		t.Errorf("GPS = %v, want zero", ts.GPS)
+	}
+
+	x := &Exif{}
+	x.setTag(DateTimeOriginal, testString(t, "2001:02:03 04:05:06"))
+	x.setTag(SubSecTimeOriginal, testString(t, "12"))
+	x.setTag(OffsetTimeOriginal, testString(t, "+09:00"))
+	got := x.timeStamp(DateTimeOriginal, SubSecTimeOriginal, OffsetTimeOriginal)
+	want = time.Date(2001, 2, 2, 19, 5, 6, 120e6, time.UTC)
+	if !got.Time.Equal(want) || !got.Zoned {
+		t.Errorf("timeStamp = %+v, want %v", got, want)
+	}
+	if ts := x.timeStamp(DateTime, SubSecTime, OffsetTime); !ts.IsZero() {
+		t.Errorf("missing DateTime: got %+v", ts)
+	}
+}
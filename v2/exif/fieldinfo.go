@@ -0,0 +1,253 @@
+package exif
+
+import (
+	"sort"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// FieldInfo describes a field known to this package.
+type FieldInfo struct {
+	Name FieldName
+	ID   uint16
+	// Group is the IFD the EXIF specification places the field in
+	// (GroupIFD0, GroupIFD1, GroupExif, GroupGPS or GroupInterop).
+	Group string
+	// Types are the data types the field may be stored as, the preferred
+	// one first.
+	Types []tiff.DataType
+	// Count is the number of values of the field (the length including the
+	// trailing NUL for ASCII fields), or 0 if it varies.
+	Count uint32
+}
+
+// fieldSpec is the expected placement and form of a field.
+type fieldSpec struct {
+	group string
+	types []tiff.DataType
+	count uint32
+}
+
+var (
+	tAscii     = []tiff.DataType{tiff.DTAscii}
+	tText      = []tiff.DataType{tiff.DTAscii, tiff.DTUTF8}
+	tByte      = []tiff.DataType{tiff.DTByte}
+	tShort     = []tiff.DataType{tiff.DTShort}
+	tLong      = []tiff.DataType{tiff.DTLong}
+	tShortLong = []tiff.DataType{tiff.DTShort, tiff.DTLong}
+	tIFD       = []tiff.DataType{tiff.DTLong, tiff.DTIFD}
+	tRational  = []tiff.DataType{tiff.DTRational}
+	tSRational = []tiff.DataType{tiff.DTSRational}
+	tUndef     = []tiff.DataType{tiff.DTUndefined}
+)
+
+// fieldSpecs gives the placement and form of each known field as set out
+// by the EXIF 2.32 and TIFF 6.0 specifications (and Microsoft for the XP
+// fields), allowing the UTF-8 type of EXIF 3.0 for free text fields.
+var fieldSpecs = map[FieldName]fieldSpec{
+	NewSubfileType:            {GroupIFD0, tLong, 1},
+	ImageWidth:                {GroupIFD0, tShortLong, 1},
+	ImageLength:               {GroupIFD0, tShortLong, 1},
+	BitsPerSample:             {GroupIFD0, tShort, 3},
+	Compression:               {GroupIFD0, tShort, 1},
+	PhotometricInterpretation: {GroupIFD0, tShort, 1},
+	Orientation:               {GroupIFD0, tShort, 1},
+	SamplesPerPixel:           {GroupIFD0, tShort, 1},
+	PlanarConfiguration:       {GroupIFD0, tShort, 1},
+	YCbCrSubSampling:          {GroupIFD0, tShort, 2},
+	YCbCrPositioning:          {GroupIFD0, tShort, 1},
+	XResolution:               {GroupIFD0, tRational, 1},
+	YResolution:               {GroupIFD0, tRational, 1},
+	ResolutionUnit:            {GroupIFD0, tShort, 1},
+	WhitePoint:                {GroupIFD0, tRational, 2},
+	PrimaryChromaticities:     {GroupIFD0, tRational, 6},
+	StripOffsets:              {GroupIFD0, tShortLong, 0},
+	RowsPerStrip:              {GroupIFD0, tShortLong, 1},
+	StripByteCounts:           {GroupIFD0, tShortLong, 0},
+	SubIFDs:                   {GroupIFD0, tIFD, 0},
+	DateTime:                  {GroupIFD0, tAscii, 20},
+	ImageDescription:          {GroupIFD0, tText, 0},
+	Make:                      {GroupIFD0, tText, 0},
+	Model:                     {GroupIFD0, tText, 0},
+	Software:                  {GroupIFD0, tText, 0},
+	Artist:                    {GroupIFD0, tText, 0},
+	Copyright:                 {GroupIFD0, tText, 0},
+	CFARepeatPatternDim:       {GroupIFD0, tShort, 2},
+	CFAPattern2:               {GroupIFD0, tByte, 0},
+	XPTitle:                   {GroupIFD0, tByte, 0},
+	XPComment:                 {GroupIFD0, tByte, 0},
+	XPAuthor:                  {GroupIFD0, tByte, 0},
+	XPKeywords:                {GroupIFD0, tByte, 0},
+	XPSubject:                 {GroupIFD0, tByte, 0},
+	ExifIFDPointer:            {GroupIFD0, tIFD, 1},
+	GPSInfoIFDPointer:         {GroupIFD0, tIFD, 1},
+
+	ThumbJPEGInterchangeFormat:       {GroupIFD1, tLong, 1},
+	ThumbJPEGInterchangeFormatLength: {GroupIFD1, tLong, 1},
+
+	InteroperabilityIFDPointer: {GroupExif, tIFD, 1},
+	ExifVersion:                {GroupExif, tUndef, 4},
+	FlashpixVersion:            {GroupExif, tUndef, 4},
+	ColorSpace:                 {GroupExif, tShort, 1},
+	Gamma:                      {GroupExif, tRational, 1},
+	ComponentsConfiguration:    {GroupExif, tUndef, 4},
+	CompressedBitsPerPixel:     {GroupExif, tRational, 1},
+	PixelXDimension:            {GroupExif, tShortLong, 1},
+	PixelYDimension:            {GroupExif, tShortLong, 1},
+	MakerNote:                  {GroupExif, tUndef, 0},
+	UserComment:                {GroupExif, tUndef, 0},
+	RelatedSoundFile:           {GroupExif, tAscii, 13},
+	DateTimeOriginal:           {GroupExif, tAscii, 20},
+	DateTimeDigitized:          {GroupExif, tAscii, 20},
+	SubSecTime:                 {GroupExif, tAscii, 0},
+	SubSecTimeOriginal:         {GroupExif, tAscii, 0},
+	SubSecTimeDigitized:        {GroupExif, tAscii, 0},
+	OffsetTime:                 {GroupExif, tAscii, 7},
+	OffsetTimeOriginal:         {GroupExif, tAscii, 7},
+	OffsetTimeDigitized:        {GroupExif, tAscii, 7},
+	ImageUniqueID:              {GroupExif, tAscii, 33},
+	Temperature:                {GroupExif, tSRational, 1},
+	Humidity:                   {GroupExif, tRational, 1},
+	Pressure:                   {GroupExif, tRational, 1},
+	WaterDepth:                 {GroupExif, tSRational, 1},
+	Acceleration:               {GroupExif, tRational, 1},
+	CameraElevationAngle:       {GroupExif, tSRational, 1},
+	ExposureTime:               {GroupExif, tRational, 1},
+	FNumber:                    {GroupExif, tRational, 1},
+	ExposureProgram:            {GroupExif, tShort, 1},
+	SpectralSensitivity:        {GroupExif, tAscii, 0},
+	ISOSpeedRatings:            {GroupExif, tShort, 0},
+	OECF:                       {GroupExif, tUndef, 0},
+	ShutterSpeedValue:          {GroupExif, tSRational, 1},
+	ApertureValue:              {GroupExif, tRational, 1},
+	BrightnessValue:            {GroupExif, tSRational, 1},
+	ExposureBiasValue:          {GroupExif, tSRational, 1},
+	MaxApertureValue:           {GroupExif, tRational, 1},
+	SubjectDistance:            {GroupExif, tRational, 1},
+	MeteringMode:               {GroupExif, tShort, 1},
+	LightSource:                {GroupExif, tShort, 1},
+	Flash:                      {GroupExif, tShort, 1},
+	FocalLength:                {GroupExif, tRational, 1},
+	SubjectArea:                {GroupExif, tShort, 0},
+	FlashEnergy:                {GroupExif, tRational, 1},
+	SpatialFrequencyResponse:   {GroupExif, tUndef, 0},
+	FocalPlaneXResolution:      {GroupExif, tRational, 1},
+	FocalPlaneYResolution:      {GroupExif, tRational, 1},
+	FocalPlaneResolutionUnit:   {GroupExif, tShort, 1},
+	SubjectLocation:            {GroupExif, tShort, 2},
+	ExposureIndex:              {GroupExif, tRational, 1},
+	SensingMethod:              {GroupExif, tShort, 1},
+	FileSource:                 {GroupExif, tUndef, 1},
+	SceneType:                  {GroupExif, tUndef, 1},
+	CFAPattern:                 {GroupExif, tUndef, 0},
+	CustomRendered:             {GroupExif, tShort, 1},
+	ExposureMode:               {GroupExif, tShort, 1},
+	WhiteBalance:               {GroupExif, tShort, 1},
+	DigitalZoomRatio:           {GroupExif, tRational, 1},
+	FocalLengthIn35mmFilm:      {GroupExif, tShort, 1},
+	SceneCaptureType:           {GroupExif, tShort, 1},
+	GainControl:                {GroupExif, tShort, 1},
+	Contrast:                   {GroupExif, tShort, 1},
+	Saturation:                 {GroupExif, tShort, 1},
+	Sharpness:                  {GroupExif, tShort, 1},
+	DeviceSettingDescription:   {GroupExif, tUndef, 0},
+	SubjectDistanceRange:       {GroupExif, tShort, 1},
+	LensMake:                   {GroupExif, tText, 0},
+	LensModel:                  {GroupExif, tText, 0},
+
+	GPSVersionID:        {GroupGPS, tByte, 4},
+	GPSLatitudeRef:      {GroupGPS, tAscii, 2},
+	GPSLatitude:         {GroupGPS, tRational, 3},
+	GPSLongitudeRef:     {GroupGPS, tAscii, 2},
+	GPSLongitude:        {GroupGPS, tRational, 3},
+	GPSAltitudeRef:      {GroupGPS, tByte, 1},
+	GPSAltitude:         {GroupGPS, tRational, 1},
+	GPSTimeStamp:        {GroupGPS, tRational, 3},
+	GPSSatelites:        {GroupGPS, tAscii, 0},
+	GPSStatus:           {GroupGPS, tAscii, 2},
+	GPSMeasureMode:      {GroupGPS, tAscii, 2},
+	GPSDOP:              {GroupGPS, tRational, 1},
+	GPSSpeedRef:         {GroupGPS, tAscii, 2},
+	GPSSpeed:            {GroupGPS, tRational, 1},
+	GPSTrackRef:         {GroupGPS, tAscii, 2},
+	GPSTrack:            {GroupGPS, tRational, 1},
+	GPSImgDirectionRef:  {GroupGPS, tAscii, 2},
+	GPSImgDirection:     {GroupGPS, tRational, 1},
+	GPSMapDatum:         {GroupGPS, tAscii, 0},
+	GPSDestLatitudeRef:  {GroupGPS, tAscii, 2},
+	GPSDestLatitude:     {GroupGPS, tRational, 3},
+	GPSDestLongitudeRef: {GroupGPS, tAscii, 2},
+	GPSDestLongitude:    {GroupGPS, tRational, 3},
+	GPSDestBearingRef:   {GroupGPS, tAscii, 2},
+	GPSDestBearing:      {GroupGPS, tRational, 1},
+	GPSDestDistanceRef:  {GroupGPS, tAscii, 2},
+	GPSDestDistance:     {GroupGPS, tRational, 1},
+	GPSProcessingMethod: {GroupGPS, tUndef, 0},
+	GPSAreaInformation:  {GroupGPS, tUndef, 0},
+	GPSDateStamp:        {GroupGPS, tAscii, 11},
+	GPSDifferential:     {GroupGPS, tShort, 1},
+
+	InteroperabilityIndex: {GroupInterop, tAscii, 0},
+}
+
+// groupOrder orders the groups of FieldInfos as they appear in a file.
+var groupOrder = map[string]int{
+	GroupIFD0:    0,
+	GroupExif:    1,
+	GroupGPS:     2,
+	GroupInterop: 3,
+	GroupIFD1:    4,
+}
+
+// Fields returns the fields known to this package, ordered by group
+// (IFD0, Exif, GPS, Interop then IFD1) and then tag ID.  It does not
+// include makernote fields.  The returned slice may be modified freely.
+func Fields() []FieldInfo {
+	var infos []FieldInfo
+	for _, fieldMap := range []map[uint16]FieldName{exifFields, gpsFields, interopFields, thumbnailFields} {
+		for id, name := range fieldMap {
+			spec := fieldSpecs[name]
+			infos = append(infos, FieldInfo{
+				Name:  name,
+				ID:    id,
+				Group: spec.group,
+				Types: append([]tiff.DataType(nil), spec.types...),
+				Count: spec.count,
+			})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		if a.Group != b.Group {
+			return groupOrder[a.Group] < groupOrder[b.Group]
+		}
+		return a.ID < b.ID
+	})
+	return infos
+}
+
+// FieldsIn returns the known fields of group, as ordered by Fields.
+func FieldsIn(group string) []FieldInfo {
+	var infos []FieldInfo
+	for _, info := range Fields() {
+		if info.Group == group {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// GPSFields returns the known fields of the GPS IFD.
+func GPSFields() []FieldInfo {
+	return FieldsIn(GroupGPS)
+}
+
+// LookupField returns the description of the known field name.
+func LookupField(name FieldName) (FieldInfo, bool) {
+	for _, info := range Fields() {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return FieldInfo{}, false
+}
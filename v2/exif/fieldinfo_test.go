@@ -0,0 +1,36 @@
+package exif
+
+import (
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestFields(t *testing.T) {
+	seen := map[FieldName]bool{}
+	for _, info := range Fields() {
+		if seen[info.Name] {
+			t.Errorf("%v listed twice", info.Name)
+		}
+		seen[info.Name] = true
+		if _, ok := groupOrder[info.Group]; !ok || len(info.Types) == 0 {
+			t.Errorf("%v has no spec: %+v", info.Name, info)
+		}
+	}
+
+	gps := GPSFields()
+	if len(gps) != len(gpsFields) {
+		t.Errorf("got %d GPS fields, want %d", len(gps), len(gpsFields))
+	}
+	if gps[0].Name != GPSVersionID || gps[0].ID != 0 {
+		t.Errorf("first GPS field is %+v", gps[0])
+	}
+
+	info, ok := LookupField(ExposureBiasValue)
+	if !ok || info.Group != GroupExif || info.ID != 0x9204 || info.Types[0] != tiff.DTSRational || info.Count != 1 {
+		t.Errorf("ExposureBiasValue info is %+v", info)
+	}
+	if _, ok := LookupField("Nonexistent"); ok {
+		t.Error("found unknown field")
+	}
+}
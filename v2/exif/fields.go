@@ -8,6 +8,7 @@ const UnknownPrefix = "UnknownTag_"
 
 // Primary EXIF fields
 const (
+	NewSubfileType             FieldName = "NewSubfileType"
 	ImageWidth                 FieldName = "ImageWidth"
 	ImageLength                FieldName = "ImageLength" // Image height called Length by EXIF spec
 	BitsPerSample              FieldName = "BitsPerSample"
@@ -21,6 +22,12 @@ const (
 	XResolution                FieldName = "XResolution"
 	YResolution                FieldName = "YResolution"
 	ResolutionUnit             FieldName = "ResolutionUnit"
+	WhitePoint                 FieldName = "WhitePoint"
+	PrimaryChromaticities      FieldName = "PrimaryChromaticities"
+	StripOffsets               FieldName = "StripOffsets"
+	RowsPerStrip               FieldName = "RowsPerStrip"
+	StripByteCounts            FieldName = "StripByteCounts"
+	SubIFDs                    FieldName = "SubIFDs"
 	DateTime                   FieldName = "DateTime"
 	ImageDescription           FieldName = "ImageDescription"
 	Make                       FieldName = "Make"
@@ -46,6 +53,9 @@ const (
 	SubSecTime                 FieldName = "SubSecTime"
 	SubSecTimeOriginal         FieldName = "SubSecTimeOriginal"
 	SubSecTimeDigitized        FieldName = "SubSecTimeDigitized"
+	OffsetTime                 FieldName = "OffsetTime"
+	OffsetTimeOriginal         FieldName = "OffsetTimeOriginal"
+	OffsetTimeDigitized        FieldName = "OffsetTimeDigitized"
 	ImageUniqueID              FieldName = "ImageUniqueID"
 	ExposureTime               FieldName = "ExposureTime"
 	FNumber                    FieldName = "FNumber"
@@ -89,6 +99,23 @@ const (
 	SubjectDistanceRange       FieldName = "SubjectDistanceRange"
 	LensMake                   FieldName = "LensMake"
 	LensModel                  FieldName = "LensModel"
+	Gamma                      FieldName = "Gamma"
+)
+
+// EXIF 2.31 tags recording the environment of the shot
+const (
+	Temperature          FieldName = "Temperature"
+	Humidity             FieldName = "Humidity"
+	Pressure             FieldName = "Pressure"
+	WaterDepth           FieldName = "WaterDepth"
+	Acceleration         FieldName = "Acceleration"
+	CameraElevationAngle FieldName = "CameraElevationAngle"
+)
+
+// TIFF/EP tags describing the color filter array of raw images
+const (
+	CFARepeatPatternDim FieldName = "CFARepeatPatternDim"
+	CFAPattern2         FieldName = "CFAPattern2" // named CFAPattern by TIFF/EP
 )
 
 // Windows-specific tags
@@ -152,6 +179,7 @@ var exifFields = map[uint16]FieldName{
 	/////////////////////////////////////
 
 	// image data structure for the thumbnail
+	0x00FE: NewSubfileType,
 	0x0100: ImageWidth,
 	0x0101: ImageLength,
 	0x0102: BitsPerSample,
@@ -166,6 +194,16 @@ var exifFields = map[uint16]FieldName{
 	0x011B: YResolution,
 	0x0128: ResolutionUnit,
 
+	// image data characteristics
+	0x013E: WhitePoint,
+	0x013F: PrimaryChromaticities,
+
+	// recording layout of the main image in raw/tiff files
+	0x0111: StripOffsets,
+	0x0116: RowsPerStrip,
+	0x0117: StripByteCounts,
+	0x014A: SubIFDs,
+
 	// Other tags
 	0x0132: DateTime,
 	0x010E: ImageDescription,
@@ -175,6 +213,10 @@ var exifFields = map[uint16]FieldName{
 	0x013B: Artist,
 	0x8298: Copyright,
 
+	// TIFF/EP color filter array
+	0x828D: CFARepeatPatternDim,
+	0x828E: CFAPattern2,
+
 	// Windows-specific tags
 	0x9c9b: XPTitle,
 	0x9c9c: XPComment,
@@ -183,19 +225,20 @@ var exifFields = map[uint16]FieldName{
 	0x9c9f: XPSubject,
 
 	// private tags
-	exifPointer: ExifIFDPointer,
+	ExifIFDPointerID: ExifIFDPointer,
 
 	/////////////////////////////////////
 	////////// Exif sub IFD /////////////
 	/////////////////////////////////////
 
-	gpsPointer:     GPSInfoIFDPointer,
-	interopPointer: InteroperabilityIFDPointer,
+	GPSIFDPointerID:     GPSInfoIFDPointer,
+	InteropIFDPointerID: InteroperabilityIFDPointer,
 
 	0x9000: ExifVersion,
 	0xA000: FlashpixVersion,
 
 	0xA001: ColorSpace,
+	0xA500: Gamma,
 
 	0x9101: ComponentsConfiguration,
 	0x9102: CompressedBitsPerPixel,
@@ -211,9 +254,20 @@ var exifFields = map[uint16]FieldName{
 	0x9290: SubSecTime,
 	0x9291: SubSecTimeOriginal,
 	0x9292: SubSecTimeDigitized,
+	0x9010: OffsetTime,
+	0x9011: OffsetTimeOriginal,
+	0x9012: OffsetTimeDigitized,
 
 	0xA420: ImageUniqueID,
 
+	// environmental conditions
+	0x9400: Temperature,
+	0x9401: Humidity,
+	0x9402: Pressure,
+	0x9403: WaterDepth,
+	0x9404: Acceleration,
+	0x9405: CameraElevationAngle,
+
 	// picture conditions
 	0x829A: ExposureTime,
 	0x829D: FNumber,
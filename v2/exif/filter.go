@@ -0,0 +1,398 @@
+package exif
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// A Filter selects images by the values of their fields.  It is compiled
+// from an expression such as
+//
+//	ISO > 3200 && Model contains "Canon" && has(GPSLatitude)
+//
+// made of comparisons combined with &&, || and ! and grouped with
+// parentheses.  A comparison relates a field to a literal, or two fields,
+// with ==, !=, <, <=, >, >= or contains.  Literals are numbers, including
+// fractions such as 1/250, and double quoted strings.  Fields are named
+// as by FieldName (including qualified and makernote names); ISO is short
+// for ISOSpeedRatings.  has(Field) tests for the presence of a field.
+//
+// Fields are compared as numbers if either side is a number literal or
+// both are numeric fields, and as strings otherwise, using the first
+// value of the field.  A comparison involving a missing field, or a field
+// that is not a number when compared to one, is false.
+type Filter struct {
+	expr string
+	root filterNode
+}
+
+// fieldAliases are the short names accepted in filter expressions.
+var fieldAliases = map[string]FieldName{
+	"ISO": ISOSpeedRatings,
+}
+
+// ParseFilter compiles a filter expression.
+func ParseFilter(expr string) (*Filter, error) {
+	toks, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks}
+	root, err := p.or()
+	if err == nil && p.peek().kind != tokEOF {
+		err = fmt.Errorf("unexpected %v", p.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("exif: filter %q: %v", expr, err)
+	}
+	return &Filter{expr, root}, nil
+}
+
+// Match reports whether x satisfies f.
+func (f *Filter) Match(x *Exif) bool {
+	return f.root.eval(x)
+}
+
+func (f *Filter) String() string {
+	return f.expr
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp // operators and parentheses
+)
+
+type filterTok struct {
+	kind tokKind
+	text string
+}
+
+func (t filterTok) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// filterOps are the operators of filter expressions, longest first.
+var filterOps = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"}
+
+func lexFilter(s string) ([]filterTok, error) {
+	var toks []filterTok
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			str, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %v", i, err)
+			}
+			toks = append(toks, filterTok{tokString, str})
+			i = j + 1
+			continue
+		case c >= '0' && c <= '9' || c == '-' || c == '.':
+			j := i + 1
+			for j < len(s) && strings.IndexByte("0123456789.eE/", s[j]) >= 0 {
+				j++
+			}
+			toks = append(toks, filterTok{tokNumber, s[i:j]})
+			i = j
+			continue
+		case isIdentByte(c):
+			j := i
+			for j < len(s) && (isIdentByte(s[j]) || s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			toks = append(toks, filterTok{tokIdent, s[i:j]})
+			i = j
+			continue
+		}
+		op := ""
+		for _, o := range filterOps {
+			if strings.HasPrefix(s[i:], o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("unexpected %q at %d", c, i)
+		}
+		toks = append(toks, filterTok{tokOp, op})
+		i += len(op)
+	}
+	return append(toks, filterTok{kind: tokEOF}), nil
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c < 0x80 && unicode.IsLetter(rune(c))
+}
+
+type filterParser struct {
+	toks []filterTok
+	pos  int
+}
+
+func (p *filterParser) peek() filterTok {
+	return p.toks[p.pos]
+}
+
+func (p *filterParser) next() filterTok {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *filterParser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) or() (filterNode, error) {
+	n, err := p.and()
+	for err == nil && p.accept("||") {
+		var r filterNode
+		if r, err = p.and(); err == nil {
+			n = orNode{n, r}
+		}
+	}
+	return n, err
+}
+
+func (p *filterParser) and() (filterNode, error) {
+	n, err := p.unary()
+	for err == nil && p.accept("&&") {
+		var r filterNode
+		if r, err = p.unary(); err == nil {
+			n = andNode{n, r}
+		}
+	}
+	return n, err
+}
+
+func (p *filterParser) unary() (filterNode, error) {
+	if p.accept("!") {
+		n, err := p.unary()
+		return notNode{n}, err
+	}
+	if p.accept("(") {
+		n, err := p.or()
+		if err == nil && !p.accept(")") {
+			err = fmt.Errorf("expected \")\", got %v", p.peek())
+		}
+		return n, err
+	}
+	if t := p.peek(); t.kind == tokIdent && t.text == "has" && p.toks[p.pos+1].text == "(" {
+		p.pos += 2
+		name := p.next()
+		if name.kind != tokIdent {
+			return nil, fmt.Errorf("expected field name, got %v", name)
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("expected \")\", got %v", p.peek())
+		}
+		return hasNode{fieldName(name.text)}, nil
+	}
+
+	l, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if op.kind == tokString || op.kind == tokNumber || !cmpOps[op.text] {
+		return nil, fmt.Errorf("expected comparison operator, got %v", op)
+	}
+	r, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return cmpNode{op.text, l, r}, nil
+}
+
+func (p *filterParser) operand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokIdent:
+		return operand{field: fieldName(t.text)}, nil
+	case tokString:
+		return operand{str: t.text, lit: true}, nil
+	case tokNumber:
+		r, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return operand{}, fmt.Errorf("invalid number %q", t.text)
+		}
+		f, _ := r.Float64()
+		return operand{str: t.text, num: f, isNum: true, lit: true}, nil
+	}
+	return operand{}, fmt.Errorf("expected field or value, got %v", t)
+}
+
+func fieldName(s string) FieldName {
+	if name, ok := fieldAliases[s]; ok {
+		return name
+	}
+	return FieldName(s)
+}
+
+type filterNode interface {
+	eval(x *Exif) bool
+}
+
+type andNode struct{ l, r filterNode }
+
+func (n andNode) eval(x *Exif) bool { return n.l.eval(x) && n.r.eval(x) }
+
+type orNode struct{ l, r filterNode }
+
+func (n orNode) eval(x *Exif) bool { return n.l.eval(x) || n.r.eval(x) }
+
+type notNode struct{ n filterNode }
+
+func (n notNode) eval(x *Exif) bool { return !n.n.eval(x) }
+
+type hasNode struct{ name FieldName }
+
+func (n hasNode) eval(x *Exif) bool {
+	_, err := x.Get(n.name)
+	return err == nil
+}
+
+// operand is a literal or a field of a comparison.
+type operand struct {
+	field FieldName
+	lit   bool
+	str   string
+	num   float64
+	isNum bool
+}
+
+// value resolves a field operand to the first value of the field of x.
+func (o operand) value(x *Exif) (operand, bool) {
+	if o.lit {
+		return o, true
+	}
+	tag, err := x.Get(o.field)
+	if err != nil {
+		return o, false
+	}
+	v := operand{lit: true}
+	if tag.Format() == tiff.StringVal {
+		s, _ := tag.StringVal()
+		v.str = strings.TrimSpace(s)
+		return v, true
+	}
+	v.str = strings.Trim(tag.String(), `"`)
+	v.num, v.isNum = tagFloat(tag)
+	return v, true
+}
+
+// tagFloat returns the first value of tag as a float.
+func tagFloat(tag *tiff.Tag) (float64, bool) {
+	switch tag.Format() {
+	case tiff.IntVal:
+		v, err := tag.Int64(0)
+		return float64(v), err == nil
+	case tiff.RatVal:
+		num, den, err := tag.Rat2(0)
+		if err != nil || den == 0 {
+			return 0, false
+		}
+		return ratFloat(num, den), true
+	case tiff.FloatVal:
+		v, err := tag.Float(0)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// cmpOps are the comparison operators of filter expressions.
+var cmpOps = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"contains": true,
+}
+
+type cmpNode struct {
+	op   string
+	l, r operand
+}
+
+func (n cmpNode) eval(x *Exif) bool {
+	l, ok := n.l.value(x)
+	if !ok {
+		return false
+	}
+	r, ok := n.r.value(x)
+	if !ok {
+		return false
+	}
+	if n.op == "contains" {
+		return strings.Contains(l.str, r.str)
+	}
+
+	var c int
+	numeric := l.isNum && r.isNum || (n.l.lit && n.l.isNum) || (n.r.lit && n.r.isNum)
+	if numeric {
+		lf, lok := l.float()
+		rf, rok := r.float()
+		if !lok || !rok {
+			return false
+		}
+		switch {
+		case lf < rf:
+			c = -1
+		case lf > rf:
+			c = 1
+		}
+	} else {
+		c = strings.Compare(l.str, r.str)
+	}
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// float returns the numeric value of a resolved operand, parsing strings.
+func (o operand) float() (float64, bool) {
+	if o.isNum {
+		return o.num, true
+	}
+	f, err := strconv.ParseFloat(o.str, 64)
+	return f, err == nil
+}
@@ -0,0 +1,50 @@
+package exif
+
+import (
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestFilter(t *testing.T) {
+	x := &Exif{}
+	x.setTag(Make, testString(t, "Canon"))
+	x.setTag(Model, testString(t, "Canon EOS 5D Mark II"))
+	x.setTag(ISOSpeedRatings, testTag(t, tiff.DTShort, 1, []byte{0x0C, 0x80})) // 3200
+	x.setTag(ExposureTime, testRational(t, 1, 200))
+	x.setTag(DateTimeOriginal, testString(t, "2011:10:28 17:50:18"))
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`ISO >= 3200 && Model contains "Canon"`, true},
+		{`ISO > 3200 && Model contains "Canon" && has(GPSLatitude)`, false},
+		{`ISOSpeedRatings == 3200`, true},
+		{`ExposureTime > 1/250`, true},
+		{`ExposureTime < 0.004`, false},
+		{`Make == "Nikon" || !has(GPSLatitude)`, true},
+		{`!(Make == "Canon")`, false},
+		{`DateTimeOriginal >= "2011:01:01" && DateTimeOriginal < "2012"`, true},
+		{`Make != "Nikon"`, true},
+		{`Make > 3`, false},
+		{`GPSLatitude != 0`, false},
+		{`Make == Make`, true},
+	}
+	for _, test := range tests {
+		f, err := ParseFilter(test.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", test.expr, err)
+			continue
+		}
+		if got := f.Match(x); got != test.want {
+			t.Errorf("%s = %v, want %v", test.expr, got, test.want)
+		}
+	}
+
+	for _, bad := range []string{``, `ISO >`, `ISO 3`, `(ISO > 3`, `has(3)`, `Make == "x`, `ISO > 1/0x`, `ISO ~ 3`} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+//go:build ignore
+// +build ignore
+
+// gen_samples writes small synthesized TIFF and JPEG files with controlled
+// EXIF structures (byte orders, IFD layouts, corruptions) to the directory
+// given as its argument.  Output is deterministic and holds no data from
+// real photos, so regression tests can be added without shipping images.
+//
+// To add a sample, add an entry to the samples table and a matching entry to
+// synthTests in synth_test.go, then run go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type entry struct {
+	id    uint16
+	typ   tiff.DataType
+	count uint32
+	val   []byte
+}
+
+// ifd is an image file directory to encode.  sub maps pointer tag IDs to
+// the sub-IFDs they point to.
+type ifd struct {
+	entries []entry
+	sub     map[uint16]*ifd
+	next    *ifd
+	thumb   []byte // JPEG thumbnail referenced by tags 0x0201/0x0202
+}
+
+// layout controls how the TIFF structure is laid out.
+type layout struct {
+	order binary.ByteOrder
+	// valuesFirst places each IFD's value area before the IFD instead of
+	// after it.
+	valuesFirst bool
+}
+
+type sample struct {
+	name   string
+	layout layout
+	ifd0   *ifd
+	jpeg   bool
+	// corrupt, if set, modifies the encoded TIFF structure.
+	corrupt func(order binary.ByteOrder, b []byte) []byte
+}
+
+func ascii(id uint16, s string) entry {
+	return entry{id, tiff.DTAscii, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+func short(order binary.ByteOrder, id uint16, v uint16) entry {
+	b := make([]byte, 2)
+	order.PutUint16(b, v)
+	return entry{id, tiff.DTShort, 1, b}
+}
+
+func rationals(order binary.ByteOrder, id uint16, vals ...uint32) entry {
+	b := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		order.PutUint32(b[4*i:], v)
+	}
+	return entry{id, tiff.DTRational, uint32(len(vals) / 2), b}
+}
+
+// camera returns an anonymized IFD0 with Exif (and optionally GPS) sub-IFDs.
+func camera(order binary.ByteOrder, gps bool) *ifd {
+	exif := &ifd{entries: []entry{
+		rationals(order, 0x829A, 1, 250),
+		rationals(order, 0x829D, 28, 10),
+		ascii(0x9003, "2001:02:03 04:05:06"),
+		short(order, 0x8827, 200),
+	}}
+	d := &ifd{
+		entries: []entry{
+			ascii(0x010F, "Example"),
+			ascii(0x0110, "Synth 1"),
+			short(order, 0x0112, 1),
+		},
+		sub: map[uint16]*ifd{0x8769: exif},
+	}
+	if gps {
+		d.sub[0x8825] = &ifd{entries: []entry{
+			{0x0000, tiff.DTByte, 4, []byte{2, 2, 0, 0}},
+			ascii(0x0001, "N"),
+			rationals(order, 0x0002, 45, 1, 30, 1, 0, 1),
+			ascii(0x0003, "W"),
+			rationals(order, 0x0004, 122, 1, 15, 1, 36, 1),
+		}}
+	}
+	return d
+}
+
+// tinyJPEG encodes a deterministic 16x12 gradient.
+func tinyJPEG() []byte {
+	img := image.NewGray(image.Rect(0, 0, 16, 12))
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 16; x++ {
+			img.SetGray(x, y, color.Gray{uint8(x*16 + y)})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 75}); err != nil {
+		log.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var samples = []sample{
+	{name: "le_basic.tif", layout: layout{order: binary.LittleEndian}},
+	{name: "be_basic.tif", layout: layout{order: binary.BigEndian}},
+	{name: "le_values_first.tif", layout: layout{order: binary.LittleEndian, valuesFirst: true}},
+	{name: "be_gps.jpg", layout: layout{order: binary.BigEndian}, jpeg: true},
+	{name: "le_thumbnail.jpg", layout: layout{order: binary.LittleEndian}, jpeg: true},
+	{
+		name:   "corrupt_entry_count.jpg",
+		layout: layout{order: binary.LittleEndian},
+		jpeg:   true,
+		corrupt: func(order binary.ByteOrder, b []byte) []byte {
+			// IFD0 claims far more entries than the data holds.
+			order.PutUint16(b[8:], 0x4000)
+			return b
+		},
+	},
+	{
+		name:   "corrupt_exif_pointer.tif",
+		layout: layout{order: binary.BigEndian},
+		corrupt: func(order binary.ByteOrder, b []byte) []byte {
+			// Point the Exif sub-IFD past the end of the data.
+			return patchEntry(order, b, 0x8769, 0xFFFFFF00)
+		},
+	},
+	{
+		name:   "corrupt_truncated.tif",
+		layout: layout{order: binary.LittleEndian},
+		corrupt: func(order binary.ByteOrder, b []byte) []byte {
+			return b[:20]
+		},
+	},
+}
+
+func init() {
+	for i := range samples {
+		s := &samples[i]
+		s.ifd0 = camera(s.layout.order, s.name == "be_gps.jpg")
+		if s.name == "le_thumbnail.jpg" {
+			s.ifd0.next = &ifd{
+				entries: []entry{short(s.layout.order, 0x0103, 6)},
+				thumb:   tinyJPEG(),
+			}
+		}
+	}
+}
+
+// patchEntry sets the value of the IFD0 entry with the given tag ID.
+func patchEntry(order binary.ByteOrder, b []byte, id uint16, val uint32) []byte {
+	off := order.Uint32(b[4:])
+	n := order.Uint16(b[off:])
+	for i := uint32(0); i < uint32(n); i++ {
+		e := off + 2 + 12*i
+		if order.Uint16(b[e:]) == id {
+			order.PutUint32(b[e+8:], val)
+		}
+	}
+	return b
+}
+
+// encoder lays out IFDs and their values in a TIFF structure.
+type encoder struct {
+	layout
+	buf []byte
+}
+
+// size returns the size of the IFD d (not including its sub-IFDs) and of
+// its value area.
+func (d *ifd) size() (dir, vals int) {
+	n := len(d.entries) + len(d.sub)
+	if d.thumb != nil {
+		n += 2
+		vals += len(d.thumb)
+	}
+	for _, e := range d.entries {
+		if len(e.val) > 4 {
+			vals += len(e.val) + len(e.val)%2
+		}
+	}
+	return 2 + 12*n + 4, vals
+}
+
+// encode appends d, its value area and its sub-IFDs to the buffer and
+// returns the offset of d.
+func (enc *encoder) encode(d *ifd) uint32 {
+	dirSize, valSize := d.size()
+	start := len(enc.buf)
+	dirOff, valOff := start, start+dirSize
+	if enc.valuesFirst {
+		dirOff, valOff = start+valSize, start
+	}
+	enc.buf = append(enc.buf, make([]byte, dirSize+valSize)...)
+
+	entries := append([]entry{}, d.entries...)
+	var thumbOff int
+	if d.thumb != nil {
+		thumbOff = valOff
+		copy(enc.buf[valOff:], d.thumb)
+		valOff += len(d.thumb)
+		entries = append(entries,
+			entry{0x0201, tiff.DTLong, 1, enc.u32(uint32(thumbOff))},
+			entry{0x0202, tiff.DTLong, 1, enc.u32(uint32(len(d.thumb)))})
+	}
+	for _, id := range []uint16{0x8769, 0x8825} {
+		if _, ok := d.sub[id]; ok {
+			// filled in once the sub-IFD has been placed
+			entries = append(entries, entry{id, tiff.DTLong, 1, make([]byte, 4)})
+		}
+	}
+
+	order := enc.order
+	order.PutUint16(enc.buf[dirOff:], uint16(len(entries)))
+	ptrs := map[uint16]int{}
+	for i, e := range entries {
+		p := dirOff + 2 + 12*i
+		order.PutUint16(enc.buf[p:], e.id)
+		order.PutUint16(enc.buf[p+2:], uint16(e.typ))
+		order.PutUint32(enc.buf[p+4:], e.count)
+		if len(e.val) > 4 {
+			order.PutUint32(enc.buf[p+8:], uint32(valOff))
+			copy(enc.buf[valOff:], e.val)
+			valOff += len(e.val) + len(e.val)%2
+		} else {
+			copy(enc.buf[p+8:], e.val)
+		}
+		if _, ok := d.sub[e.id]; ok {
+			ptrs[e.id] = p + 8
+		}
+	}
+
+	for _, id := range []uint16{0x8769, 0x8825} {
+		if sub, ok := d.sub[id]; ok {
+			off := enc.encode(sub)
+			order.PutUint32(enc.buf[ptrs[id]:], off)
+		}
+	}
+	if d.next != nil {
+		off := enc.encode(d.next)
+		order.PutUint32(enc.buf[dirOff+dirSize-4:], off)
+	}
+	return uint32(dirOff)
+}
+
+func (enc *encoder) u32(v uint32) []byte {
+	b := make([]byte, 4)
+	enc.order.PutUint32(b, v)
+	return b
+}
+
+// encodeTIFF returns the TIFF structure for s.
+func encodeTIFF(s sample) []byte {
+	enc := &encoder{layout: s.layout}
+	if s.layout.order == binary.LittleEndian {
+		enc.buf = []byte("II*\x00\x00\x00\x00\x00")
+	} else {
+		enc.buf = []byte("MM\x00*\x00\x00\x00\x00")
+	}
+	off := enc.encode(s.ifd0)
+	s.layout.order.PutUint32(enc.buf[4:], off)
+	if s.corrupt != nil {
+		enc.buf = s.corrupt(s.layout.order, enc.buf)
+	}
+	return enc.buf
+}
+
+// encodeJPEG wraps t in an APP1 segment inserted after the SOI marker of a
+// tiny JPEG image.
+func encodeJPEG(t []byte) []byte {
+	img := tinyJPEG()
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(2+6+len(t)))
+	app1 = append(append(app1, "Exif\x00\x00"...), t...)
+	return append(append(append([]byte{}, img[:2]...), app1...), img[2:]...)
+}
+
+func main() {
+	flag.Parse()
+	dir := flag.Arg(0)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for _, s := range samples {
+		data := encodeTIFF(s)
+		if s.jpeg {
+			data = encodeJPEG(data)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, s.name), data, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
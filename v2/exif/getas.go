@@ -0,0 +1,156 @@
+//go:build go1.18
+// +build go1.18
+
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Value is the set of types GetAs converts field values to.
+type Value interface {
+	string | int64 | float64 | time.Time | *big.Rat |
+		[]string | []int64 | []float64 | []time.Time | []*big.Rat
+}
+
+// GetAs returns the value of the field name in x converted to T.  Scalar
+// types take the first value of the field and slice types all of them.
+//
+//   - string and []string accept ASCII fields; an ASCII field holding
+//     several NUL separated strings yields one element per string.
+//   - int64 accepts integer fields.
+//   - float64 accepts integer, rational and floating point fields.
+//   - *big.Rat accepts integer and rational fields, rejecting zero
+//     denominators.
+//   - time.Time accepts ASCII fields in the EXIF "2006:01:02 15:04:05"
+//     layout, in the time zone of x as by DateTime.
+//
+// The error is a TagNotPresentError if the field is missing.
+func GetAs[T Value](x *Exif, name FieldName) (T, error) {
+	var v T
+	tag, err := x.Get(name)
+	if err != nil {
+		return v, err
+	}
+
+	n := int(tag.Count)
+	if tag.Format() == tiff.StringVal {
+		n = len(splitStrings(tag))
+	}
+	if n == 0 {
+		return v, fmt.Errorf("exif: %v has no values", name)
+	}
+
+	var conv interface{}
+	switch p := interface{}(&v).(type) {
+	case *string:
+		*p, err = asString(tag, 0)
+	case *int64:
+		*p, err = tag.Int64(0)
+	case *float64:
+		*p, err = asFloat(tag, 0)
+	case *time.Time:
+		*p, err = asTime(x, tag, 0)
+	case **big.Rat:
+		*p, err = asRat(tag, 0)
+	case *[]string:
+		conv, err = asSlice(n, func(i int) (string, error) { return asString(tag, i) })
+	case *[]int64:
+		conv, err = asSlice(n, tag.Int64)
+	case *[]float64:
+		conv, err = asSlice(n, func(i int) (float64, error) { return asFloat(tag, i) })
+	case *[]time.Time:
+		conv, err = asSlice(n, func(i int) (time.Time, error) { return asTime(x, tag, i) })
+	case *[]*big.Rat:
+		conv, err = asSlice(n, func(i int) (*big.Rat, error) { return asRat(tag, i) })
+	}
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("exif: %v: %v", name, err)
+	}
+	if conv != nil {
+		v = conv.(T)
+	}
+	return v, nil
+}
+
+func asSlice[E any](n int, get func(int) (E, error)) ([]E, error) {
+	vals := make([]E, n)
+	for i := range vals {
+		v, err := get(i)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+// splitStrings returns the NUL separated strings of an ASCII tag.
+func splitStrings(tag *tiff.Tag) []string {
+	if tag.Format() != tiff.StringVal {
+		return nil
+	}
+	s := strings.TrimRight(string(tag.Val), "\x00")
+	if s == "" {
+		return []string{""}
+	}
+	return strings.Split(s, "\x00")
+}
+
+func asString(tag *tiff.Tag, i int) (string, error) {
+	if tag.Format() != tiff.StringVal {
+		_, err := tag.StringVal()
+		return "", err
+	}
+	return splitStrings(tag)[i], nil
+}
+
+func asFloat(tag *tiff.Tag, i int) (float64, error) {
+	switch tag.Format() {
+	case tiff.IntVal:
+		v, err := tag.Int64(i)
+		return float64(v), err
+	case tiff.RatVal:
+		r, err := asRat(tag, i)
+		if err != nil {
+			return 0, err
+		}
+		f, _ := r.Float64()
+		return f, nil
+	}
+	return tag.Float(i)
+}
+
+func asRat(tag *tiff.Tag, i int) (*big.Rat, error) {
+	if tag.Format() == tiff.IntVal {
+		v, err := tag.Int64(i)
+		return big.NewRat(v, 1), err
+	}
+	num, den, err := tag.Rat2(i)
+	if err != nil {
+		return nil, err
+	}
+	if den == 0 {
+		return nil, errors.New("zero denominator")
+	}
+	return big.NewRat(num, den), nil
+}
+
+func asTime(x *Exif, tag *tiff.Tag, i int) (time.Time, error) {
+	s, err := asString(tag, i)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := time.Local
+	if tz, _ := x.TimeZone(); tz != nil {
+		loc = tz
+	}
+	return time.ParseInLocation("2006:01:02 15:04:05", strings.TrimSpace(s), loc)
+}
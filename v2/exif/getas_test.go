@@ -0,0 +1,57 @@
+//go:build go1.18
+// +build go1.18
+
+package exif
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestGetAs(t *testing.T) {
+	x := &Exif{}
+	x.setTag(Model, testString(t, "Camera"))
+	x.setTag(Copyright, testTag(t, tiff.DTAscii, 11, []byte("Ann\x00Photog\x00")))
+	x.setTag(DateTimeOriginal, testString(t, "2020:05:01 12:30:00"))
+	x.setTag(ISOSpeedRatings, testTag(t, tiff.DTShort, 2, []byte{0, 100, 0, 200}))
+	x.setTag(FNumber, testTag(t, tiff.DTRational, 1, []byte{0, 0, 0, 28, 0, 0, 0, 10}))
+	x.setTag(ExposureTime, testTag(t, tiff.DTRational, 1, []byte{0, 0, 0, 1, 0, 0, 0, 0}))
+
+	if s, err := GetAs[string](x, Model); err != nil || s != "Camera" {
+		t.Errorf("string: %q, %v", s, err)
+	}
+	if ss, err := GetAs[[]string](x, Copyright); err != nil || len(ss) != 2 || ss[1] != "Photog" {
+		t.Errorf("[]string: %q, %v", ss, err)
+	}
+	if tm, err := GetAs[time.Time](x, DateTimeOriginal); err != nil || tm.Hour() != 12 || tm.Minute() != 30 {
+		t.Errorf("time.Time: %v, %v", tm, err)
+	}
+	if v, err := GetAs[int64](x, ISOSpeedRatings); err != nil || v != 100 {
+		t.Errorf("int64: %v, %v", v, err)
+	}
+	if vs, err := GetAs[[]int64](x, ISOSpeedRatings); err != nil || len(vs) != 2 || vs[1] != 200 {
+		t.Errorf("[]int64: %v, %v", vs, err)
+	}
+	if f, err := GetAs[float64](x, FNumber); err != nil || f != 2.8 {
+		t.Errorf("float64: %v, %v", f, err)
+	}
+	if r, err := GetAs[*big.Rat](x, FNumber); err != nil || r.Cmp(big.NewRat(14, 5)) != 0 {
+		t.Errorf("*big.Rat: %v, %v", r, err)
+	}
+	if fs, err := GetAs[[]float64](x, ISOSpeedRatings); err != nil || len(fs) != 2 || fs[0] != 100 {
+		t.Errorf("[]float64: %v, %v", fs, err)
+	}
+
+	if _, err := GetAs[*big.Rat](x, ExposureTime); err == nil {
+		t.Errorf("zero denominator accepted")
+	}
+	if _, err := GetAs[int64](x, Model); err == nil {
+		t.Errorf("string converted to int64")
+	}
+	if _, err := GetAs[string](x, Make); !IsTagNotPresentError(err) {
+		t.Errorf("missing field: got error %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package exif
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// GPSFix is a position and the time it was taken, as recorded by
+// AppendGPSAPP1.
+type GPSFix struct {
+	// Lat and Long are in degrees, negative south of the equator and west
+	// of the prime meridian.
+	Lat, Long float64
+	// Alt is the altitude above sea level in meters, negative below it.
+	Alt float64
+	// Time is the time of the fix.  DateTime and DateTimeOriginal hold it
+	// in its location, the GPS time and date stamps in UTC.
+	Time time.Time
+}
+
+// GPSAPP1Size is the size of the segments written by AppendGPSAPP1.
+const GPSAPP1Size = 4 + 6 + gpsTIFFSize
+
+// Layout of the TIFF structure written by AppendGPSAPP1, as offsets from
+// its start: IFD0, the Exif IFD and the GPS IFD, each followed by its
+// values.
+const (
+	gpsIFD0       = 8
+	gpsDateTime   = gpsIFD0 + 2 + 3*12 + 4
+	gpsExifIFD    = gpsDateTime + 20
+	gpsDateTimeO  = gpsExifIFD + 2 + 12 + 4
+	gpsGPSIFD     = gpsDateTimeO + 20
+	gpsLat        = gpsGPSIFD + 2 + 9*12 + 4
+	gpsLong       = gpsLat + 24
+	gpsAlt        = gpsLong + 24
+	gpsTimeStamp  = gpsAlt + 8
+	gpsDateStamp  = gpsTimeStamp + 24
+	gpsTIFFSize   = gpsDateStamp + 12 // 11 byte stamp and padding
+	gpsTIFFOffset = 4 + 6             // in the segment
+)
+
+var gpsZeros [GPSAPP1Size]byte
+
+// AppendGPSAPP1 appends to dst a JPEG APP1 segment of EXIF data holding only
+// the position and time of fix: the DateTime field, DateTimeOriginal and
+// the GPS version, position, altitude and time stamp.  It is meant for
+// devices stamping the images they capture, such as dashcams and drones,
+// with the segment inserted right after the SOI marker of each image.  It
+// does not allocate if dst has room for GPSAPP1Size more bytes.
+//
+// Like SetLatLong, SetAltitude and SetGPSTime, it returns an error, and dst
+// unchanged, for a position out of range, an altitude beyond 42,949 km or
+// a time, in its location or UTC, outside the years 0 to 9999.
+func AppendGPSAPP1(dst []byte, fix GPSFix) ([]byte, error) {
+	if err := checkLatLong(fix.Lat, fix.Long); err != nil {
+		return dst, err
+	}
+	if err := checkAltitude(fix.Alt); err != nil {
+		return dst, err
+	}
+	if err := checkTime(fix.Time); err != nil {
+		return dst, err
+	}
+	n := len(dst)
+	dst = append(dst, gpsZeros[:]...)
+	b := dst[n:]
+	o := binary.BigEndian
+
+	b[0], b[1] = 0xFF, 0xE1
+	o.PutUint16(b[2:], GPSAPP1Size-2)
+	copy(b[4:], "Exif")
+	t := b[gpsTIFFOffset:]
+	copy(t, "MM\x00*")
+	o.PutUint32(t[4:], gpsIFD0)
+
+	entry := func(p int, id uint16, typ tiff.DataType, count, val uint32) int {
+		o.PutUint16(t[p:], id)
+		o.PutUint16(t[p+2:], uint16(typ))
+		o.PutUint32(t[p+4:], count)
+		o.PutUint32(t[p+8:], val)
+		return p + 12
+	}
+	byteVal := func(vs ...byte) uint32 {
+		var v uint32
+		for i, x := range vs {
+			v |= uint32(x) << uint(24-8*i)
+		}
+		return v
+	}
+
+	// IFD0
+	o.PutUint16(t[gpsIFD0:], 3)
+	p := entry(gpsIFD0+2, 0x0132, tiff.DTAscii, 20, gpsDateTime)
+	p = entry(p, ExifIFDPointerID, tiff.DTLong, 1, gpsExifIFD)
+	entry(p, GPSIFDPointerID, tiff.DTLong, 1, gpsGPSIFD)
+	putDateTime(t[gpsDateTime:], fix.Time)
+
+	// Exif IFD
+	o.PutUint16(t[gpsExifIFD:], 1)
+	entry(gpsExifIFD+2, 0x9003, tiff.DTAscii, 20, gpsDateTimeO)
+	putDateTime(t[gpsDateTimeO:], fix.Time)
+
+	// GPS IFD
+	latRef, longRef, altRef := byte('N'), byte('E'), byte(0)
+	if fix.Lat < 0 {
+		latRef = 'S'
+	}
+	if fix.Long < 0 {
+		longRef = 'W'
+	}
+	if fix.Alt < 0 {
+		altRef = 1
+	}
+	utc := fix.Time.UTC()
+	o.PutUint16(t[gpsGPSIFD:], 9)
+	p = entry(gpsGPSIFD+2, 0x0000, tiff.DTByte, 4, byteVal(2, 3, 0, 0))
+	p = entry(p, 0x0001, tiff.DTAscii, 2, byteVal(latRef))
+	p = entry(p, 0x0002, tiff.DTRational, 3, gpsLat)
+	p = entry(p, 0x0003, tiff.DTAscii, 2, byteVal(longRef))
+	p = entry(p, 0x0004, tiff.DTRational, 3, gpsLong)
+	p = entry(p, 0x0005, tiff.DTByte, 1, byteVal(altRef))
+	p = entry(p, 0x0006, tiff.DTRational, 1, gpsAlt)
+	p = entry(p, 0x0007, tiff.DTRational, 3, gpsTimeStamp)
+	entry(p, 0x001D, tiff.DTAscii, 11, gpsDateStamp)
+	putDegrees(t[gpsLat:], fix.Lat)
+	putDegrees(t[gpsLong:], fix.Long)
+	o.PutUint32(t[gpsAlt:], uint32(centimeters(fix.Alt)))
+	o.PutUint32(t[gpsAlt+4:], 100)
+	h, m, ms := timeOfDay(utc)
+	ts := t[gpsTimeStamp:]
+	o.PutUint32(ts, h)
+	o.PutUint32(ts[4:], 1)
+	o.PutUint32(ts[8:], m)
+	o.PutUint32(ts[12:], 1)
+	o.PutUint32(ts[16:], ms)
+	o.PutUint32(ts[20:], 1000)
+	putDate(t[gpsDateStamp:], utc)
+	return dst, nil
+}
+
+// putDegrees writes the absolute value of the angle deg as three rationals:
+// degrees, minutes and seconds to the millisecond.
+func putDegrees(b []byte, deg float64) {
+	o := binary.BigEndian
+	d, m, ms := dms(deg)
+	o.PutUint32(b, d)
+	o.PutUint32(b[4:], 1)
+	o.PutUint32(b[8:], m)
+	o.PutUint32(b[12:], 1)
+	o.PutUint32(b[16:], ms)
+	o.PutUint32(b[20:], 1000)
+}
+
+// putDate writes the date of t as "YYYY:MM:DD".
+func putDate(b []byte, t time.Time) {
+	putDigits(b[0:4], t.Year())
+	b[4] = ':'
+	putDigits(b[5:7], int(t.Month()))
+	b[7] = ':'
+	putDigits(b[8:10], t.Day())
+}
+
+// putDateTime writes t as "YYYY:MM:DD HH:MM:SS".
+func putDateTime(b []byte, t time.Time) {
+	putDate(b, t)
+	b[10] = ' '
+	putDigits(b[11:13], t.Hour())
+	b[13] = ':'
+	putDigits(b[14:16], t.Minute())
+	b[16] = ':'
+	putDigits(b[17:19], t.Second())
+}
+
+// putDigits writes v as len(b) decimal digits.
+func putDigits(b []byte, v int) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte('0' + v%10)
+		v /= 10
+	}
+}
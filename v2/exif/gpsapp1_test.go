@@ -0,0 +1,91 @@
+package exif
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAppendGPSAPP1(t *testing.T) {
+	zone := time.FixedZone("", 2*3600)
+	fix := GPSFix{
+		Lat:  -33.856784,
+		Long: 151.215297,
+		Alt:  -12.345,
+		Time: time.Date(2024, 3, 1, 0, 30, 15, 250e6, zone),
+	}
+	seg, err := AppendGPSAPP1([]byte{0xFF, 0xD8}, fix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seg) != 2+GPSAPP1Size {
+		t.Fatalf("segment of %d bytes, want %d", len(seg)-2, GPSAPP1Size)
+	}
+	img := append(seg, jpegNoExif(10)[2:]...)
+
+	x, err := Decode(bytes.NewReader(img))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lat, long, err := x.LatLong()
+	if err != nil || math.Abs(lat-fix.Lat) > 1e-6 || math.Abs(long-fix.Long) > 1e-6 {
+		t.Errorf("LatLong = %v, %v, %v; want %v, %v", lat, long, err, fix.Lat, fix.Long)
+	}
+	for name, want := range map[FieldName]string{
+		DateTime:         `"2024:03:01 00:30:15"`,
+		DateTimeOriginal: `"2024:03:01 00:30:15"`,
+		GPSVersionID:     `[2,3,0,0]`,
+		GPSAltitudeRef:   `1`,
+		GPSAltitude:      `"1235/100"`,
+		GPSTimeStamp:     `["22/1","30/1","15250/1000"]`,
+		GPSDateStamp:     `"2024:02:29"`,
+	} {
+		if tag, err := x.Get(name); err != nil || tag.String() != want {
+			t.Errorf("%v = %v, %v; want %v", name, tag, err, want)
+		}
+	}
+	if ws := x.ValidateLayout(); len(ws) > 0 {
+		t.Errorf("layout warnings: %v", ws)
+	}
+
+	buf := make([]byte, 0, GPSAPP1Size)
+	if n := testing.AllocsPerRun(10, func() { AppendGPSAPP1(buf, fix) }); n != 0 {
+		t.Errorf("AppendGPSAPP1 made %v allocations", n)
+	}
+}
+
+func TestAppendGPSAPP1Invalid(t *testing.T) {
+	valid := GPSFix{Lat: 1, Long: 2, Alt: 3, Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	for name, change := range map[string]func(*GPSFix){
+		"NaN latitude":     func(f *GPSFix) { f.Lat = math.NaN() },
+		"latitude":         func(f *GPSFix) { f.Lat = 1200 },
+		"longitude":        func(f *GPSFix) { f.Long = -180.5 },
+		"altitude":         func(f *GPSFix) { f.Alt = 43e6 },
+		"infinite depth":   func(f *GPSFix) { f.Alt = math.Inf(-1) },
+		"negative year":    func(f *GPSFix) { f.Time = time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC) },
+		"5 digit year":     func(f *GPSFix) { f.Time = time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC) },
+		"5 digit UTC year": func(f *GPSFix) { f.Time = time.Date(9999, 12, 31, 23, 0, 0, 0, time.FixedZone("", -2*3600)) },
+	} {
+		fix := valid
+		change(&fix)
+		dst := []byte{0xFF, 0xD8}
+		got, err := AppendGPSAPP1(dst, fix)
+		if err == nil || len(got) != len(dst) {
+			t.Errorf("%s: appended %d bytes, error %v", name, len(got)-len(dst), err)
+		}
+
+		x := &Exif{}
+		errs := []error{x.SetLatLong(fix.Lat, fix.Long), x.SetAltitude(fix.Alt), x.SetGPSTime(fix.Time)}
+		if errs[0] == nil && errs[1] == nil && errs[2] == nil {
+			t.Errorf("%s: accepted by the Set methods", name)
+		}
+	}
+	for _, alt := range []float64{42949672.95, -42949672.95} {
+		fix := valid
+		fix.Alt = alt
+		if _, err := AppendGPSAPP1(nil, fix); err != nil {
+			t.Errorf("altitude %v: %v", alt, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package exif
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// SetLatLong sets the GPS position fields of x to the position lat, long in
+// degrees, negative south of the equator and west of the prime meridian:
+// GPSLatitude and GPSLongitude as degrees, minutes and seconds to the
+// millisecond, with their reference fields, and GPSVersionID if absent.
+// Like Set, it changes the decoded fields of x only; Encode and WriteJPEG
+// write them out.
+func (x *Exif) SetLatLong(lat, long float64) error {
+	if err := checkLatLong(lat, long); err != nil {
+		return err
+	}
+	latRef, longRef := "N", "E"
+	if lat < 0 {
+		latRef = "S"
+	}
+	if long < 0 {
+		longRef = "W"
+	}
+	return x.setGPS(
+		gpsTag{GPSLatitudeRef, tiff.DTAscii, []interface{}{latRef}},
+		gpsTag{GPSLatitude, tiff.DTRational, degrees(lat)},
+		gpsTag{GPSLongitudeRef, tiff.DTAscii, []interface{}{longRef}},
+		gpsTag{GPSLongitude, tiff.DTRational, degrees(long)},
+	)
+}
+
+// SetAltitude sets GPSAltitude and GPSAltitudeRef to the altitude alt in
+// meters above sea level, negative below it, to the centimeter.
+func (x *Exif) SetAltitude(alt float64) error {
+	if err := checkAltitude(alt); err != nil {
+		return err
+	}
+	cm := centimeters(alt)
+	ref := 0
+	if alt < 0 {
+		ref = 1
+	}
+	return x.setGPS(
+		gpsTag{GPSAltitudeRef, tiff.DTByte, []interface{}{ref}},
+		gpsTag{GPSAltitude, tiff.DTRational, []interface{}{[2]int64{int64(cm), 100}}},
+	)
+}
+
+// SetGPSTime sets GPSTimeStamp and GPSDateStamp to t in UTC, to the
+// millisecond.
+func (x *Exif) SetGPSTime(t time.Time) error {
+	if err := checkTime(t); err != nil {
+		return err
+	}
+	t = t.UTC()
+	h, m, ms := timeOfDay(t)
+	return x.setGPS(
+		gpsTag{GPSTimeStamp, tiff.DTRational, []interface{}{
+			[2]int64{int64(h), 1},
+			[2]int64{int64(m), 1},
+			[2]int64{int64(ms), 1000},
+		}},
+		gpsTag{GPSDateStamp, tiff.DTAscii, []interface{}{t.Format("2006:01:02")}},
+	)
+}
+
+// gpsTag is a GPS field to be set by setGPS.
+type gpsTag struct {
+	name FieldName
+	typ  tiff.DataType
+	vals []interface{}
+}
+
+// setGPS sets the fields tags on x, along with GPSVersionID if absent.
+// Either all fields are set or, on error, none.
+func (x *Exif) setGPS(tags ...gpsTag) error {
+	if _, err := x.Get(GPSVersionID); err != nil {
+		tags = append(tags, gpsTag{GPSVersionID, tiff.DTByte, []interface{}{2, 3, 0, 0}})
+	}
+	order := x.ByteOrder()
+	if order == nil {
+		order = binary.BigEndian
+	}
+	made := make([]*tiff.Tag, len(tags))
+	for i, t := range tags {
+		info, _ := LookupField(t.name)
+		tag, err := tiff.NewTag(info.ID, t.typ, order, t.vals...)
+		if err != nil {
+			return fmt.Errorf("exif: %v: %v", t.name, err)
+		}
+		made[i] = tag
+	}
+	for i, t := range tags {
+		x.Set(t.name, made[i])
+	}
+	return nil
+}
+
+// degrees returns the absolute value of the angle deg as the rational
+// values of degrees, minutes and seconds to the millisecond.
+func degrees(deg float64) []interface{} {
+	d, m, ms := dms(deg)
+	return []interface{}{
+		[2]int64{int64(d), 1},
+		[2]int64{int64(m), 1},
+		[2]int64{int64(ms), 1000},
+	}
+}
+
+// The GPS writers, SetLatLong and friends and AppendGPSAPP1, share the
+// following checks and conversions so that both accept and round values
+// alike.
+
+// checkLatLong returns an error unless lat and long are valid latitude
+// and longitude degrees.
+func checkLatLong(lat, long float64) error {
+	if !(math.Abs(lat) <= 90) || !(math.Abs(long) <= 180) {
+		return fmt.Errorf("exif: invalid position %v, %v", lat, long)
+	}
+	return nil
+}
+
+// checkAltitude returns an error unless the altitude alt in meters fits a
+// rational number of centimeters.
+func checkAltitude(alt float64) error {
+	if !(centimeters(alt) <= math.MaxUint32) {
+		return fmt.Errorf("exif: invalid altitude %v", alt)
+	}
+	return nil
+}
+
+// checkTime returns an error unless t, in its location and in UTC, has a
+// year of four digits.
+func checkTime(t time.Time) error {
+	if y, u := t.Year(), t.UTC().Year(); y < 0 || y > 9999 || u < 0 || u > 9999 {
+		return fmt.Errorf("exif: invalid GPS time %v", t)
+	}
+	return nil
+}
+
+// dms splits the absolute value of the angle deg into degrees, minutes and
+// seconds in milliseconds.
+func dms(deg float64) (d, m, ms uint32) {
+	total := uint32(math.Round(math.Abs(deg) * 3600 * 1000))
+	return total / 3600000, total / 60000 % 60, total % 60000
+}
+
+// timeOfDay returns the hour, minute and second in milliseconds of t in
+// UTC.
+func timeOfDay(t time.Time) (h, m, ms uint32) {
+	t = t.UTC()
+	return uint32(t.Hour()), uint32(t.Minute()), uint32(t.Second()*1000 + t.Nanosecond()/1e6)
+}
+
+// centimeters returns the absolute value of the altitude alt in meters as
+// a whole number of centimeters.
+func centimeters(alt float64) float64 {
+	return math.Round(math.Abs(alt) * 100)
+}
@@ -0,0 +1,96 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSetLatLong(t *testing.T) {
+	x := &Exif{}
+	if err := x.SetLatLong(-33.856784, 151.215297); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.SetAltitude(-12.345); err != nil {
+		t.Fatal(err)
+	}
+	zone := time.FixedZone("", 2*3600)
+	if err := x.SetGPSTime(time.Date(2024, 3, 1, 0, 30, 15, 250e6, zone)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, x, binary.LittleEndian); err != nil {
+		t.Fatal(err)
+	}
+	y, err := Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lat, long, err := y.LatLong()
+	if err != nil || math.Abs(lat+33.856784) > 1e-6 || math.Abs(long-151.215297) > 1e-6 {
+		t.Errorf("LatLong = %v, %v, %v", lat, long, err)
+	}
+	for name, want := range map[FieldName]string{
+		GPSVersionID:    `[2,3,0,0]`,
+		GPSLatitudeRef:  `"S"`,
+		GPSLongitudeRef: `"E"`,
+		GPSLatitude:     `["33/1","51/1","24422/1000"]`,
+		GPSAltitudeRef:  `1`,
+		GPSAltitude:     `"1235/100"`,
+		GPSTimeStamp:    `["22/1","30/1","15250/1000"]`,
+		GPSDateStamp:    `"2024:02:29"`,
+	} {
+		if tag, err := y.Get(name); err != nil || tag.String() != want {
+			t.Errorf("%v = %v, %v; want %v", name, tag, err, want)
+		}
+	}
+
+	for _, pos := range [][2]float64{{91, 0}, {0, -180.5}, {math.NaN(), 0}} {
+		if err := x.SetLatLong(pos[0], pos[1]); err == nil {
+			t.Errorf("SetLatLong(%v, %v) accepted", pos[0], pos[1])
+		}
+	}
+	if err := x.SetAltitude(math.Inf(1)); err == nil {
+		t.Error("infinite altitude accepted")
+	}
+	if lat, _, _ := x.LatLong(); math.Abs(lat+33.856784) > 1e-6 {
+		t.Errorf("invalid position changed the latitude to %v", lat)
+	}
+}
+
+func TestGPSWritersAgree(t *testing.T) {
+	fix := GPSFix{
+		Lat:  51.4999999,
+		Long: -0.0000004,
+		Alt:  0.005,
+		Time: time.Date(2021, 12, 31, 23, 59, 59, 999500000, time.UTC),
+	}
+	seg, err := AppendGPSAPP1([]byte{0xFF, 0xD8}, fix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := Decode(bytes.NewReader(append(seg, jpegNoExif(10)[2:]...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := &Exif{}
+	if err := x.SetLatLong(fix.Lat, fix.Long); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.SetAltitude(fix.Alt); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.SetGPSTime(fix.Time); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []FieldName{GPSLatitude, GPSLongitude, GPSLongitudeRef, GPSAltitude, GPSTimeStamp, GPSDateStamp} {
+		a, err1 := x.Get(name)
+		b, err2 := y.Get(name)
+		if err1 != nil || err2 != nil || a.String() != b.String() {
+			t.Errorf("%v: Set* wrote %v (%v), AppendGPSAPP1 %v (%v)", name, a, err1, b, err2)
+		}
+	}
+}
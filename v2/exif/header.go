@@ -0,0 +1,77 @@
+package exif
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Container identifies the kind of file the EXIF data of an Exif was
+// decoded from.
+type Container int
+
+const (
+	ContainerUnknown Container = iota
+	ContainerTIFF              // TIFF or TIFF based raw file
+	ContainerJPEG              // JPEG APP1 segment
+	ContainerRawExif           // bare "Exif\0\0" block
+	ContainerPSD               // Photoshop image resource
+	ContainerCR3               // Canon CR3 CMT boxes
+	ContainerVideo             // MP4/MOV metadata atoms
+	ContainerAVI               // AVI exif chunks
+)
+
+var containerNames = map[Container]string{
+	ContainerUnknown: "unknown",
+	ContainerTIFF:    "TIFF",
+	ContainerJPEG:    "JPEG",
+	ContainerRawExif: "raw EXIF",
+	ContainerPSD:     "Photoshop",
+	ContainerCR3:     "CR3",
+	ContainerVideo:   "MP4/MOV",
+	ContainerAVI:     "AVI",
+}
+
+func (c Container) String() string {
+	if s, ok := containerNames[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("Container(%d)", int(c))
+}
+
+// Container returns the kind of file x was decoded from.  It is
+// ContainerUnknown for an Exif not returned by Decode.
+func (x *Exif) Container() Container {
+	return x.container
+}
+
+// Comments returns the text of the COM segments of the JPEG image x was
+// decoded from, in file order.  Only the segments before the image data
+// are read.
+func (x *Exif) Comments() []string {
+	return x.comments
+}
+
+// ByteOrder returns the byte order of the TIFF structure holding the EXIF
+// data, or nil if there is none.  Fields synthesized from other metadata
+// (e.g. of videos) are big endian.
+func (x *Exif) ByteOrder() binary.ByteOrder {
+	if x.Tiff == nil {
+		return nil
+	}
+	return x.Tiff.Order
+}
+
+// IFD0Offset returns the offset of IFD0 from the start of the TIFF header
+// in x.Raw.  ok is false if x has no TIFF structure.
+func (x *Exif) IFD0Offset() (offset uint32, ok bool) {
+	if len(x.Raw) < 8 {
+		return 0, false
+	}
+	switch string(x.Raw[:4]) {
+	case "II*\x00":
+		return binary.LittleEndian.Uint32(x.Raw[4:]), true
+	case "MM\x00*":
+		return binary.BigEndian.Uint32(x.Raw[4:]), true
+	}
+	return 0, false
+}
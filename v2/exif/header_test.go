@@ -0,0 +1,66 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHeaderInfo(t *testing.T) {
+	tests := []struct {
+		file      string
+		container Container
+		order     binary.ByteOrder
+		ifd0      uint32
+	}{
+		{"sample1.jpg", ContainerJPEG, binary.LittleEndian, 8},
+		{"testdata/synth/be_basic.tif", ContainerTIFF, binary.BigEndian, 8},
+		{"testdata/synth/le_values_first.tif", ContainerTIFF, binary.LittleEndian, 24},
+	}
+	for _, test := range tests {
+		f, err := os.Open(filepath.Join(*dataDir, test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := x.Container(); got != test.container {
+			t.Errorf("%v: container %v, want %v", test.file, got, test.container)
+		}
+		if got := x.ByteOrder(); got != test.order {
+			t.Errorf("%v: byte order %v, want %v", test.file, got, test.order)
+		}
+		if got, ok := x.IFD0Offset(); !ok || got != test.ifd0 {
+			t.Errorf("%v: IFD0 offset %v, %v; want %v", test.file, got, ok, test.ifd0)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	exifData, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	com := func(s string) []byte {
+		return append([]byte{0xFF, 0xFE, 0, byte(len(s) + 2)}, s...)
+	}
+	// COM segments before and after APP1.
+	jpg := append([]byte{0xFF, 0xD8}, com("before")...)
+	jpg = append(jpg, exifData[2:4+int(exifData[4])<<8+int(exifData[5])]...)
+	jpg = append(jpg, com("after\x00")...)
+	jpg = append(jpg, jpegNoExif(16)[20:]...)
+
+	x, err := Decode(bytes.NewReader(jpg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := x.Comments(); len(got) != 2 || got[0] != "before" || got[1] != "after" {
+		t.Errorf("Comments = %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+// +build go1.23
+
+package exif
+
+import (
+	"iter"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// All returns an iterator over the fields of x and their tags, in name
+// order.  It visits the same fields as Walk.  Like Walk, it loads the
+// fields of an Exif returned by DecodeIndex first and visits none if that
+// fails; call Load beforehand to learn the error.
+func (x *Exif) All() iter.Seq2[FieldName, *tiff.Tag] {
+	return func(yield func(FieldName, *tiff.Tag) bool) {
+		if err := x.Load(); err != nil {
+			return
+		}
+		for _, f := range x.main {
+			if !yield(f.name, f.tag) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+// +build go1.23
+
+package exif
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestAll(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []FieldName
+	x.Walk(walkFunc(func(name FieldName, tag *tiff.Tag) error {
+		walked = append(walked, name)
+		return nil
+	}))
+	i := 0
+	for name, tag := range x.All() {
+		if name != walked[i] {
+			t.Fatalf("field %d is %v, want %v", i, name, walked[i])
+		}
+		if want, _ := x.Get(name); tag != want {
+			t.Errorf("%v: got tag %v, want %v", name, tag, want)
+		}
+		i++
+	}
+	if i != len(walked) {
+		t.Errorf("visited %d fields, want %d", i, len(walked))
+	}
+
+	n := 0
+	for range x.All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("break did not stop the iteration")
+	}
+}
+
+func TestAllLoadError(t *testing.T) {
+	data := buildTIFF(
+		asciiEntry(0x0110, "Synth 1"),
+		testEntry{0x010F, tiff.DTAscii, 0x1000, make([]byte, 8)},
+	)
+	x, err := new(Decoder).DecodeIndex(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range x.All() {
+		t.Errorf("visited %v of fields failing to load", name)
+	}
+	if err := x.Load(); err == nil {
+		t.Error("Load succeeded")
+	}
+}
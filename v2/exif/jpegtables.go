@@ -0,0 +1,117 @@
+package exif
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+)
+
+// JPEG markers of the segments read besides APP1.
+const (
+	jpegSOS   = 0xDA
+	jpegEOI   = 0xD9
+	jpegDQT   = 0xDB
+	jpegDHT   = 0xC4
+	jpegCOM   = 0xFE
+	jpegAPP2  = 0xE2
+	jpegAPP11 = 0xEB
+	jpegAPP15 = 0xEF
+)
+
+// JPEGFingerprint identifies the quantization and Huffman tables of a JPEG
+// image.  Cameras and editing software use characteristic tables, so
+// fingerprints that are unusual for the recorded Make and Model are a sign
+// of an image having been re-encoded.
+type JPEGFingerprint struct {
+	// DQT and DHT are the hex encoded SHA-256 sums of the concatenated
+	// DQT and DHT segment payloads, in file order.  They are empty if the
+	// image has no such segments.
+	DQT, DHT string
+}
+
+// JPEGFingerprint returns the table fingerprint of the JPEG image x was
+// decoded from.  It is only available if the image was decoded by a
+// Decoder with Fingerprint set.
+func (x *Exif) JPEGFingerprint() (JPEGFingerprint, bool) {
+	if x.jpegFP == nil {
+		return JPEGFingerprint{}, false
+	}
+	return *x.jpegFP, true
+}
+
+// readJPEGTables fingerprints the tables of the JPEG image whose first
+// bytes are in head.  Reading from r appends to head; it is read until head
+// holds the whole header up to the start of the image data.
+func readJPEGTables(head *bytes.Buffer, r io.Reader) *JPEGFingerprint {
+	buf := make([]byte, 4096)
+	for {
+		fp, done := jpegTables(head.Bytes())
+		if done {
+			return fp
+		}
+		if _, err := r.Read(buf); err != nil {
+			fp, _ := jpegTables(head.Bytes())
+			return fp
+		}
+	}
+}
+
+// jpegTables walks the segments of the JPEG image in data, fingerprinting
+// its tables.  done is false if data ends before the start of scan segment.
+func jpegTables(data []byte) (fp *JPEGFingerprint, done bool) {
+	dqt, dht := sha256.New(), sha256.New()
+	var nqt, nht int
+	finish := func() *JPEGFingerprint {
+		fp := &JPEGFingerprint{}
+		if nqt > 0 {
+			fp.DQT = hex.EncodeToString(dqt.Sum(nil))
+		}
+		if nht > 0 {
+			fp.DHT = hex.EncodeToString(dht.Sum(nil))
+		}
+		return fp
+	}
+
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return finish(), true
+	}
+	for i := 2; ; {
+		if i+2 > len(data) {
+			return finish(), false
+		}
+		if data[i] != 0xFF {
+			// not a marker: give up on the header
+			return finish(), true
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			i++
+			continue
+		case marker == jpegSOS || marker == jpegEOI:
+			return finish(), true
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD7:
+			// markers without a payload
+			i += 2
+			continue
+		}
+		if i+4 > len(data) {
+			return finish(), false
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			return finish(), false
+		}
+		switch marker {
+		case jpegDQT:
+			dqt.Write(data[i+4 : end])
+			nqt++
+		case jpegDHT:
+			dht.Write(data[i+4 : end])
+			nht++
+		}
+		i = end
+	}
+}
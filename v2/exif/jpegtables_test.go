@@ -0,0 +1,53 @@
+package exif
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestJPEGFingerprint(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := x.JPEGFingerprint(); ok {
+		t.Errorf("fingerprint available without Decoder.Fingerprint")
+	}
+
+	d := &Decoder{Fingerprint: true}
+	x, err = d.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp, ok := x.JPEGFingerprint()
+	if !ok || len(fp.DQT) != 64 || len(fp.DHT) != 64 {
+		t.Fatalf("fingerprint = %+v, %v", fp, ok)
+	}
+	if fp2, _ := jpegTables(data); *fp2 != fp {
+		t.Errorf("streamed fingerprint %+v differs from %+v", fp, *fp2)
+	}
+
+	// Changing a quantization table changes the DQT fingerprint only.  The
+	// last DQT marker belongs to the main image (and not the thumbnail),
+	// as 0xFF bytes are escaped in the entropy coded data.
+	i := bytes.LastIndex(data, []byte{0xFF, jpegDQT})
+	if i < 0 {
+		t.Fatal("no DQT segment in sample")
+	}
+	edited := append([]byte{}, data...)
+	edited[i+6]++
+	x, err = d.Decode(bytes.NewReader(edited))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp2, _ := x.JPEGFingerprint(); fp2.DQT == fp.DQT || fp2.DHT != fp.DHT {
+		t.Errorf("edited DQT: fingerprint %+v, original %+v", fp2, fp)
+	}
+}
@@ -0,0 +1,93 @@
+package exif
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// A JSONEncoder writes the metadata of a stream of files as a JSON array,
+// one element per file, writing each element as soon as it is encoded so
+// that batches of any size are written in constant memory:
+//
+//	[
+//	{"file":"a.jpg","exif":{"Make":"Canon",...}},
+//	{"file":"b.jpg","error":"exif: no EXIF data found"}
+//	]
+//
+// Used with a BatchDecoder, whose results may be encoded as they arrive:
+//
+//	enc := exif.NewJSONEncoder(w)
+//	for res := range b.Run(names) {
+//	    if err := enc.Encode(res); err != nil {
+//	        return err
+//	    }
+//	}
+//	return enc.Close()
+type JSONEncoder struct {
+	w   io.Writer
+	n   int
+	err error
+}
+
+// NewJSONEncoder returns a JSONEncoder writing to w.
+func NewJSONEncoder(w io.Writer) *JSONEncoder {
+	return &JSONEncoder{w: w}
+}
+
+var errEncoderClosed = errors.New("exif: JSONEncoder closed")
+
+// jsonElement is the element of the array written by a JSONEncoder.
+type jsonElement struct {
+	File  string `json:"file"`
+	Exif  *Exif  `json:"exif,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Encode writes the element of res: its name and either its fields, as
+// written by Exif.MarshalJSON, or its error.  The fields of files whose
+// decoding failed with a non-critical error are written along with the
+// error.  The first write error is returned by all later calls.
+func (e *JSONEncoder) Encode(res BatchResult) error {
+	if e.err != nil {
+		return e.err
+	}
+	el := jsonElement{File: res.Name, Exif: res.Exif}
+	if res.Err != nil {
+		el.Error = res.Err.Error()
+		if IsCriticalError(res.Err) {
+			el.Exif = nil
+		}
+	}
+	b, err := json.Marshal(el)
+	if err != nil {
+		// e.g. fields that failed to load; record the file rather than
+		// abandoning the array.
+		b, _ = json.Marshal(jsonElement{File: res.Name, Error: err.Error()})
+	}
+
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "[\n"
+	}
+	e.n++
+	_, e.err = e.w.Write(append([]byte(sep), b...))
+	return e.err
+}
+
+// Close ends the array.  It does not close the underlying writer.
+func (e *JSONEncoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	end := "\n]\n"
+	if e.n == 0 {
+		end = "[]\n"
+	}
+	if _, err := io.WriteString(e.w, end); err != nil {
+		e.err = err
+		return err
+	}
+	e.err = errEncoderClosed
+	return nil
+}
@@ -0,0 +1,98 @@
+package exif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/exiftest"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := exif.NewJSONEncoder(&buf)
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("empty array = %q", buf.String())
+	}
+	if err := enc.Encode(exif.BatchResult{Name: "a"}); err == nil {
+		t.Error("Encode after Close succeeded")
+	}
+
+	data, err := exiftest.NewJPEG().WithTag(exif.Model, "Synth 1").Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	enc = exif.NewJSONEncoder(&buf)
+	b := &exif.BatchDecoder{
+		Workers: 2,
+		Open: func(name string) (io.ReadCloser, error) {
+			if name != "synth.jpg" {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+	names := make(chan string)
+	go func() {
+		names <- "synth.jpg"
+		names <- "no-such-file.jpg"
+		close(names)
+	}()
+	for res := range b.Run(names) {
+		if err := enc.Encode(res); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var els []struct {
+		File  string
+		Exif  map[string]json.RawMessage
+		Error string
+	}
+	if err := json.Unmarshal(buf.Bytes(), &els); err != nil {
+		t.Fatalf("%v: %s", err, buf.Bytes())
+	}
+	if len(els) != 2 {
+		t.Fatalf("%d elements, want 2", len(els))
+	}
+	for _, el := range els {
+		switch el.File {
+		case "synth.jpg":
+			if el.Error != "" || string(el.Exif["Model"]) != `"Synth 1"` {
+				t.Errorf("%v: Model %s, error %q", el.File, el.Exif["Model"], el.Error)
+			}
+		case "no-such-file.jpg":
+			if el.Error == "" || el.Exif != nil {
+				t.Errorf("%v: missing error", el.File)
+			}
+		default:
+			t.Errorf("unexpected element %q", el.File)
+		}
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestJSONEncoderWriteError(t *testing.T) {
+	enc := exif.NewJSONEncoder(failWriter{})
+	if err := enc.Encode(exif.BatchResult{Name: "a"}); err == nil {
+		t.Fatal("write error not returned")
+	}
+	if err := enc.Close(); err == nil {
+		t.Error("write error not returned by Close")
+	}
+}
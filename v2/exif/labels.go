@@ -0,0 +1,236 @@
+package exif
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A Label is the text displayed for a field in a user interface.
+type Label struct {
+	Name string // e.g. "Exposure time"
+	Unit string // unit of the field's values, if fixed, e.g. "s"
+}
+
+// labels holds the registered labels by language.
+var labels = map[string]map[FieldName]Label{
+	"en": englishLabels,
+}
+
+// RegisterLabels registers labels for the language lang, a BCP 47 tag such
+// as "de" or "pt-BR", adding to or replacing those registered before.  It
+// is not safe to call concurrently with LabelFor and is meant to be called
+// from init functions.
+func RegisterLabels(lang string, ls map[FieldName]Label) {
+	lang = strings.ToLower(lang)
+	if labels[lang] == nil {
+		labels[lang] = map[FieldName]Label{}
+	}
+	for name, l := range ls {
+		labels[lang][name] = l
+	}
+}
+
+// LabelFor returns the label of the field name in the language lang.  A
+// label missing for a regional variant (e.g. "en-GB") is looked up in the
+// base language ("en"), then in English.  Qualified names are labeled like
+// their unqualified name, and makernote fields without a label of their own
+// like the standard field of the same bare name.  For fields with no label
+// at all, such as unknown tags, a label is derived from the field name.
+func LabelFor(name FieldName, lang string) Label {
+	_, name = name.Group()
+	_, bare := name.Namespace()
+	for _, n := range []FieldName{name, bare} {
+		if l, ok := lookupLabel(n, strings.ToLower(lang)); ok {
+			return l
+		}
+	}
+	return Label{Name: splitWords(string(bare))}
+}
+
+// lookupLabel looks up the label of name in lang, its base languages and
+// English.
+func lookupLabel(name FieldName, lang string) (Label, bool) {
+	for lang != "" {
+		if l, ok := labels[lang][name]; ok {
+			return l, true
+		}
+		i := strings.LastIndexAny(lang, "-_")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	l, ok := labels["en"][name]
+	return l, ok
+}
+
+// splitWords turns a CamelCase field name into space separated words,
+// keeping runs of capitals (acronyms) together, e.g. "FocalPlaneXResolution"
+// becomes "Focal plane X resolution".
+func splitWords(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1])
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if prevLower || (unicode.IsUpper(rs[i-1]) && nextLower) {
+				b.WriteByte(' ')
+			}
+			if nextLower {
+				r = unicode.ToLower(r)
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var englishLabels = map[FieldName]Label{
+	NewSubfileType:            {"Subfile type", ""},
+	ImageWidth:                {"Image width", "px"},
+	ImageLength:               {"Image height", "px"},
+	BitsPerSample:             {"Bits per sample", ""},
+	Compression:               {"Compression", ""},
+	PhotometricInterpretation: {"Photometric interpretation", ""},
+	Orientation:               {"Orientation", ""},
+	SamplesPerPixel:           {"Samples per pixel", ""},
+	PlanarConfiguration:       {"Planar configuration", ""},
+	YCbCrSubSampling:          {"YCbCr subsampling", ""},
+	YCbCrPositioning:          {"YCbCr positioning", ""},
+	XResolution:               {"Horizontal resolution", ""},
+	YResolution:               {"Vertical resolution", ""},
+	ResolutionUnit:            {"Resolution unit", ""},
+	WhitePoint:                {"White point", ""},
+	PrimaryChromaticities:     {"Primary chromaticities", ""},
+	StripOffsets:              {"Strip offsets", ""},
+	RowsPerStrip:              {"Rows per strip", ""},
+	StripByteCounts:           {"Strip byte counts", ""},
+	SubIFDs:                   {"Sub-IFDs", ""},
+	DateTime:                  {"Date modified", ""},
+	ImageDescription:          {"Description", ""},
+	Make:                      {"Camera make", ""},
+	Model:                     {"Camera model", ""},
+	Software:                  {"Software", ""},
+	Artist:                    {"Artist", ""},
+	Copyright:                 {"Copyright", ""},
+
+	ExifIFDPointer:             {"Exif IFD offset", ""},
+	GPSInfoIFDPointer:          {"GPS IFD offset", ""},
+	InteroperabilityIFDPointer: {"Interoperability IFD offset", ""},
+
+	ExifVersion:              {"Exif version", ""},
+	FlashpixVersion:          {"FlashPix version", ""},
+	ColorSpace:               {"Color space", ""},
+	Gamma:                    {"Gamma", ""},
+	ComponentsConfiguration:  {"Components configuration", ""},
+	CompressedBitsPerPixel:   {"Compressed bits per pixel", ""},
+	PixelXDimension:          {"Image width", "px"},
+	PixelYDimension:          {"Image height", "px"},
+	MakerNote:                {"Maker note", ""},
+	UserComment:              {"User comment", ""},
+	RelatedSoundFile:         {"Related sound file", ""},
+	DateTimeOriginal:         {"Date taken", ""},
+	DateTimeDigitized:        {"Date digitized", ""},
+	SubSecTime:               {"Date modified (subseconds)", ""},
+	SubSecTimeOriginal:       {"Date taken (subseconds)", ""},
+	SubSecTimeDigitized:      {"Date digitized (subseconds)", ""},
+	OffsetTime:               {"Time zone (modified)", ""},
+	OffsetTimeOriginal:       {"Time zone (taken)", ""},
+	OffsetTimeDigitized:      {"Time zone (digitized)", ""},
+	ImageUniqueID:            {"Unique image ID", ""},
+	Temperature:              {"Temperature", "°C"},
+	Humidity:                 {"Humidity", "%"},
+	Pressure:                 {"Pressure", "hPa"},
+	WaterDepth:               {"Water depth", "m"},
+	Acceleration:             {"Acceleration", "mGal"},
+	CameraElevationAngle:     {"Camera elevation angle", "°"},
+	ExposureTime:             {"Exposure time", "s"},
+	FNumber:                  {"F-number", ""},
+	ExposureProgram:          {"Exposure program", ""},
+	SpectralSensitivity:      {"Spectral sensitivity", ""},
+	ISOSpeedRatings:          {"ISO", ""},
+	OECF:                     {"Opto-electronic conversion function", ""},
+	ShutterSpeedValue:        {"Shutter speed (APEX)", ""},
+	ApertureValue:            {"Aperture (APEX)", ""},
+	BrightnessValue:          {"Brightness", "EV"},
+	ExposureBiasValue:        {"Exposure compensation", "EV"},
+	MaxApertureValue:         {"Maximum aperture (APEX)", ""},
+	SubjectDistance:          {"Subject distance", "m"},
+	MeteringMode:             {"Metering mode", ""},
+	LightSource:              {"Light source", ""},
+	Flash:                    {"Flash", ""},
+	FocalLength:              {"Focal length", "mm"},
+	SubjectArea:              {"Subject area", ""},
+	FlashEnergy:              {"Flash energy", "BCPS"},
+	SpatialFrequencyResponse: {"Spatial frequency response", ""},
+	FocalPlaneXResolution:    {"Focal plane horizontal resolution", ""},
+	FocalPlaneYResolution:    {"Focal plane vertical resolution", ""},
+	FocalPlaneResolutionUnit: {"Focal plane resolution unit", ""},
+	SubjectLocation:          {"Subject location", "px"},
+	ExposureIndex:            {"Exposure index", ""},
+	SensingMethod:            {"Sensing method", ""},
+	FileSource:               {"File source", ""},
+	SceneType:                {"Scene type", ""},
+	CFAPattern:               {"CFA pattern", ""},
+	CustomRendered:           {"Custom rendered", ""},
+	ExposureMode:             {"Exposure mode", ""},
+	WhiteBalance:             {"White balance", ""},
+	DigitalZoomRatio:         {"Digital zoom ratio", ""},
+	FocalLengthIn35mmFilm:    {"Focal length (35 mm equivalent)", "mm"},
+	SceneCaptureType:         {"Scene capture type", ""},
+	GainControl:              {"Gain control", ""},
+	Contrast:                 {"Contrast", ""},
+	Saturation:               {"Saturation", ""},
+	Sharpness:                {"Sharpness", ""},
+	DeviceSettingDescription: {"Device settings", ""},
+	SubjectDistanceRange:     {"Subject distance range", ""},
+	LensMake:                 {"Lens make", ""},
+	LensModel:                {"Lens model", ""},
+
+	CFARepeatPatternDim: {"CFA repeat pattern dimensions", ""},
+	CFAPattern2:         {"CFA pattern", ""},
+
+	XPTitle:    {"Title", ""},
+	XPComment:  {"Comments", ""},
+	XPAuthor:   {"Authors", ""},
+	XPKeywords: {"Tags", ""},
+	XPSubject:  {"Subject", ""},
+
+	ThumbJPEGInterchangeFormat:       {"Thumbnail offset", ""},
+	ThumbJPEGInterchangeFormatLength: {"Thumbnail length", "bytes"},
+
+	GPSVersionID:        {"GPS version", ""},
+	GPSLatitudeRef:      {"Latitude reference", ""},
+	GPSLatitude:         {"Latitude", "°"},
+	GPSLongitudeRef:     {"Longitude reference", ""},
+	GPSLongitude:        {"Longitude", "°"},
+	GPSAltitudeRef:      {"Altitude reference", ""},
+	GPSAltitude:         {"Altitude", "m"},
+	GPSTimeStamp:        {"GPS time (UTC)", ""},
+	GPSSatelites:        {"GPS satellites", ""},
+	GPSStatus:           {"GPS status", ""},
+	GPSMeasureMode:      {"GPS measure mode", ""},
+	GPSDOP:              {"GPS dilution of precision", ""},
+	GPSSpeedRef:         {"GPS speed unit", ""},
+	GPSSpeed:            {"GPS speed", ""},
+	GPSTrackRef:         {"GPS track reference", ""},
+	GPSTrack:            {"GPS track", "°"},
+	GPSImgDirectionRef:  {"Image direction reference", ""},
+	GPSImgDirection:     {"Image direction", "°"},
+	GPSMapDatum:         {"Map datum", ""},
+	GPSDestLatitudeRef:  {"Destination latitude reference", ""},
+	GPSDestLatitude:     {"Destination latitude", "°"},
+	GPSDestLongitudeRef: {"Destination longitude reference", ""},
+	GPSDestLongitude:    {"Destination longitude", "°"},
+	GPSDestBearingRef:   {"Destination bearing reference", ""},
+	GPSDestBearing:      {"Destination bearing", "°"},
+	GPSDestDistanceRef:  {"Destination distance unit", ""},
+	GPSDestDistance:     {"Destination distance", ""},
+	GPSProcessingMethod: {"GPS processing method", ""},
+	GPSAreaInformation:  {"GPS area information", ""},
+	GPSDateStamp:        {"GPS date (UTC)", ""},
+	GPSDifferential:     {"GPS differential correction", ""},
+
+	InteroperabilityIndex: {"Interoperability index", ""},
+}
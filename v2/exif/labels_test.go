@@ -0,0 +1,35 @@
+package exif
+
+import "testing"
+
+func TestLabelFor(t *testing.T) {
+	RegisterLabels("de", map[FieldName]Label{ExposureTime: {"Belichtungszeit", "s"}})
+	defer delete(labels, "de")
+
+	tests := []struct {
+		name FieldName
+		lang string
+		want Label
+	}{
+		{ExposureTime, "en", Label{"Exposure time", "s"}},
+		{ExposureTime, "de-AT", Label{"Belichtungszeit", "s"}},
+		{FocalLength, "de", Label{"Focal length", "mm"}},
+		{ExposureTime, "", Label{"Exposure time", "s"}},
+		{Qualified(GroupGPS, GPSAltitude), "en", Label{"Altitude", "m"}},
+		{"Canon.FocalLength", "en", Label{"Focal length", "mm"}},
+		{"Canon.LensType", "en", Label{"Lens type", ""}},
+		{"FocalPlaneXResolution2", "en", Label{"Focal plane X resolution2", ""}},
+		{"ISOSpeed", "en", Label{"ISO speed", ""}},
+	}
+	for _, tt := range tests {
+		if got := LabelFor(tt.name, tt.lang); got != tt.want {
+			t.Errorf("LabelFor(%v, %q) = %+v, want %+v", tt.name, tt.lang, got, tt.want)
+		}
+	}
+
+	for _, f := range Fields() {
+		if _, ok := englishLabels[f.Name]; !ok {
+			t.Errorf("no English label for %v", f.Name)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package exif
+
+import "fmt"
+
+// ValidateLayout checks the TIFF structure of the raw EXIF data (x.Raw),
+// returning a warning for each IFD or value that overlaps the header or
+// another IFD or value, IFDs and values starting at odd offsets (TIFF
+// requires word alignment), references past the end of the data and
+// unreferenced ("slack") areas other than single padding bytes, where data
+// may be hidden.  It does not rely on the decoded fields: the IFDs are
+// walked again directly from x.Raw.
+func (x *Exif) ValidateLayout() []Warning {
+	regions, err := tiffRegions(x.Raw)
+	if err != nil {
+		return []Warning{{Msg: err.Error()}}
+	}
+
+	var ws []Warning
+	warn := func(format string, args ...interface{}) {
+		ws = append(ws, Warning{Msg: fmt.Sprintf(format, args...)})
+	}
+	slack := func(from, to uint64) {
+		if n := to - from; n > 1 || (n == 1 && from%2 == 0) {
+			warn("%d unreferenced bytes at %#x", n, from)
+		}
+	}
+
+	var end uint64
+	var last dumpRegion
+	for _, r := range regions {
+		switch r.kind {
+		case regionInvalid:
+			warn("%s", r.label)
+			continue
+		case regionIFD, regionValue:
+			if r.start%2 != 0 {
+				warn("%s at odd offset %#x", r.label, r.start)
+			}
+		}
+		if r.start < end {
+			warn("%s at %#x overlaps %s at %#x", r.label, r.start, last.label, last.start)
+		} else {
+			slack(end, r.start)
+		}
+		if r.end > end {
+			end, last = r.end, r
+		}
+	}
+	if size := uint64(len(x.Raw)); end < size {
+		slack(end, size)
+	}
+	return ws
+}
@@ -0,0 +1,58 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateLayout(t *testing.T) {
+	for _, name := range []string{"le_basic.tif", "be_basic.tif", "le_values_first.tif", "be_gps.jpg", "le_thumbnail.jpg"} {
+		data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ws := x.ValidateLayout(); len(ws) != 0 {
+			t.Errorf("%s: got warnings %v", name, ws)
+		}
+	}
+}
+
+func TestValidateLayoutCrafted(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Point the Make value at IFD0 itself and hide data after the end.
+	le := binary.LittleEndian
+	ifd0 := le.Uint32(data[4:])
+	for i, e := uint16(0), ifd0+2; i < le.Uint16(data[ifd0:]); i, e = i+1, e+12 {
+		if le.Uint16(data[e:]) == 0x010F {
+			le.PutUint32(data[e+8:], ifd0)
+		}
+	}
+	data = append(data, "hidden message"...)
+
+	x, err := Decode(bytes.NewReader(data))
+	if x == nil {
+		t.Fatal(err)
+	}
+	ws := x.ValidateLayout()
+	var overlap bool
+	for _, w := range ws {
+		overlap = overlap || strings.HasPrefix(w.Msg, "Make value at 0x8 overlaps IFD0")
+	}
+	if !overlap {
+		t.Errorf("no Make value overlap in %v", ws)
+	}
+	if len(ws) == 0 || ws[len(ws)-1].Msg != "14 unreferenced bytes at 0xa8" {
+		t.Errorf("no hidden bytes in %v", ws)
+	}
+}
@@ -0,0 +1,368 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// loadGap is the largest gap between two values Load fetches with a single
+// read.
+const loadGap = 4 << 10
+
+// lazyValues holds the state of an Exif returned by DecodeIndex.
+type lazyValues struct {
+	r io.ReaderAt // the TIFF structure
+	// pending maps the tags whose values are not loaded yet to their IFD
+	// entries.
+	pending map[*tiff.Tag][]byte
+	// base is the position of the TIFF structure in the file, size its
+	// length and entries maps the tags to the positions of their IFD
+	// entries in it.
+	base, size int64
+	entries    map[*tiff.Tag]int64
+}
+
+// IndexEntry locates the value of a field of an Exif returned by
+// DecodeIndex.
+type IndexEntry struct {
+	Name  FieldName
+	Group string
+	Type  tiff.DataType
+	Count uint32
+	// Offset is the position of the value in the TIFF structure and Size
+	// its length.  Values of up to 4 bytes are stored in the IFD entry,
+	// their Offset is 0 and they are always loaded.
+	Offset, Size int64
+	Loaded       bool
+	// FileOffset is the position of the value in the file, including
+	// values stored in the IFD entry, or -1 if unknown: it is only known
+	// for fields of an Exif returned by DecodeIndex that were not Set
+	// since.  See Patch.
+	FileOffset int64
+}
+
+// Patch overwrites the value of the field of e in the file w, which must be
+// the one e was indexed from, with val, which must be of the same size as
+// the value, encoded in the byte order of the file.  It allows fixing
+// fixed-size fields such as Orientation or DateTime of many files without
+// rewriting them.
+func (e IndexEntry) Patch(w io.WriterAt, val []byte) error {
+	if e.FileOffset < 0 {
+		return fmt.Errorf("exif: position of %v in the file is unknown", e.Name)
+	}
+	if int64(len(val)) != e.Size {
+		return fmt.Errorf("exif: %d byte value for %d byte field %v", len(val), e.Size, e.Name)
+	}
+	_, err := w.WriteAt(val, e.FileOffset)
+	return err
+}
+
+// DecodeIndex is the first phase of a two-phase decode of the EXIF data of
+// the size bytes of the TIFF or JPEG file in r, meant for remote data (see
+// RangeReader): it reads the IFDs of IFD0, IFD1 and the Exif, GPS and
+// Interoperability sub-IFDs, building an index of the fields, but not the
+// values stored outside the IFDs.  These are loaded by Load, or by Get as
+// each field is requested.  Walk, String and the other functions visiting
+// all fields load them all first.
+//
+// The makernote is not parsed, the KeepOnly option is ignored and x.Raw is
+// nil.  Loading values modifies x, so it is not safe to use concurrently
+// until Load has loaded all the fields.
+func (d *Decoder) DecodeIndex(r io.ReaderAt, size int64) (*Exif, error) {
+	var head [4]byte
+	if _, err := r.ReadAt(head[:], 0); err != nil {
+		return nil, err
+	}
+	x := &Exif{
+		permissive:  d.Permissive,
+		jsonStrings: d.JSONStrings,
+		rationals:   d.Rationals,
+		subLimits:   d.SubIFDLimits,
+	}
+	var tr io.ReaderAt
+	var base, tsize int64
+	switch {
+	case string(head[:]) == "II*\x00" || string(head[:]) == "MM\x00*":
+		tr, tsize, x.container = r, size, ContainerTIFF
+	case head[0] == 0xFF && head[1] == 0xD8:
+		off, n, err := findExifSegment(r, size)
+		if err != nil {
+			return nil, err
+		}
+		tr, base, tsize, x.container = io.NewSectionReader(r, off, n), off, n, ContainerJPEG
+	default:
+		return nil, errors.New("exif: DecodeIndex needs a TIFF or JPEG file")
+	}
+
+	var hdr [8]byte
+	if _, err := tr.ReadAt(hdr[:], 0); err != nil {
+		return nil, decodeError{cause: err}
+	}
+	var order binary.ByteOrder
+	switch string(hdr[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return nil, decodeError{cause: errors.New("tiff: could not read tiff byte order")}
+	}
+	x.Tiff = &tiff.Tiff{Order: order}
+	x.lazy = &lazyValues{r: tr, pending: map[*tiff.Tag][]byte{}, base: base, size: tsize, entries: map[*tiff.Tag]int64{}}
+
+	seen := map[int64]bool{}
+	for off := int64(order.Uint32(hdr[4:])); off != 0 && len(x.Tiff.Dirs) < 2; {
+		if seen[off] {
+			return nil, decodeError{cause: errors.New("tiff: recursive IFD")}
+		}
+		seen[off] = true
+		dir, next, err := x.indexDir(off)
+		if err != nil {
+			return nil, decodeError{cause: err}
+		}
+		x.Tiff.Dirs = append(x.Tiff.Dirs, dir)
+		off = next
+	}
+	if len(x.Tiff.Dirs) == 0 {
+		return nil, decodeError{cause: errors.New("Invalid exif data")}
+	}
+
+	defer x.setGroup("")
+	x.setGroup(GroupIFD0)
+	x.LoadTags(x.Tiff.Dirs[0], exifFields, false)
+	if len(x.Tiff.Dirs) >= 2 {
+		x.setGroup(GroupIFD1)
+		x.LoadTags(x.Tiff.Dirs[1], thumbnailFields, false)
+	}
+	te := make(tiffErrors)
+	for _, sub := range []struct {
+		stage    tiffError
+		group    string
+		ptr      FieldName
+		fieldMap map[uint16]FieldName
+	}{
+		{loadExif, GroupExif, ExifIFDPointer, exifFields},
+		{loadGPS, GroupGPS, GPSInfoIFDPointer, gpsFields},
+		{loadInteroperability, GroupInterop, InteroperabilityIFDPointer, interopFields},
+	} {
+		tag, err := x.Get(sub.ptr)
+		if err != nil {
+			continue
+		}
+		off, err := tag.Int64(0)
+		if err != nil {
+			continue
+		}
+		dir, _, err := x.indexDir(off)
+		if err != nil {
+			te[sub.stage] = fmt.Sprintf("exif: sub-IFD %s decode failed: %v", sub.ptr, err)
+			continue
+		}
+		x.setGroup(sub.group)
+		x.LoadTags(dir, sub.fieldMap, false)
+	}
+	if len(te) > 0 {
+		return x, te
+	}
+	return x, nil
+}
+
+// indexDir reads the IFD at off of the TIFF structure of x, decoding the
+// tags whose values are stored in the IFD and recording the others as
+// pending.
+func (x *Exif) indexDir(off int64) (dir *tiff.Dir, next int64, err error) {
+	r, order := x.lazy.r, x.Tiff.Order
+	var cnt [2]byte
+	if _, err := r.ReadAt(cnt[:], off); err != nil {
+		return nil, 0, errors.New("tiff: failed to read IFD tag count: " + err.Error())
+	}
+	n := int(order.Uint16(cnt[:]))
+	entries := make([]byte, 12*n+4)
+	if _, err := r.ReadAt(entries, off+2); err != nil {
+		return nil, 0, errors.New("tiff: failed to read IFD: " + err.Error())
+	}
+	dir = &tiff.Dir{}
+	for i := 0; i < n; i++ {
+		entry := entries[12*i : 12*i+12 : 12*i+12]
+		typ := tiff.DataType(order.Uint16(entry[2:]))
+		count := order.Uint32(entry[4:])
+		var tag *tiff.Tag
+		if uint64(typ.Size())*uint64(count) > 4 {
+			tag = &tiff.Tag{Id: order.Uint16(entry), Type: typ, Count: count, ValOffset: order.Uint32(entry[8:])}
+			x.lazy.pending[tag] = entry
+		} else if tag, err = tiff.DecodeEntry(entry, r, order); err != nil {
+			return nil, 0, err
+		}
+		x.lazy.entries[tag] = off + 2 + 12*int64(i)
+		dir.Tags = append(dir.Tags, tag)
+	}
+	return dir, int64(order.Uint32(entries[12*n:])), nil
+}
+
+// Index returns the entries of the fields of x in name order, followed by
+// the fields shadowed by same-named fields of another group.  The entries
+// of an Exif not returned by DecodeIndex are all loaded, and their
+// FileOffset is unknown.
+func (x *Exif) Index() []IndexEntry {
+	var idx []IndexEntry
+	for _, fs := range []fields{x.main, x.shadowed} {
+		for _, f := range fs {
+			e := IndexEntry{
+				Name:       f.name,
+				Group:      f.group,
+				Type:       f.tag.Type,
+				Count:      f.tag.Count,
+				Size:       int64(f.tag.Type.Size()) * int64(f.tag.Count),
+				Loaded:     x.lazy == nil || x.lazy.pending[f.tag] == nil,
+				FileOffset: -1,
+			}
+			if e.Size > 4 {
+				e.Offset = int64(f.tag.ValOffset)
+			}
+			if x.lazy != nil {
+				if pos, ok := x.lazy.entries[f.tag]; ok {
+					e.FileOffset = x.lazy.base + pos + 8
+					if e.Size > 4 {
+						e.FileOffset = x.lazy.base + e.Offset
+					}
+				}
+			}
+			idx = append(idx, e)
+		}
+	}
+	return idx
+}
+
+// Load loads the values of the named fields of an Exif returned by
+// DecodeIndex, or of all its fields if no names are given, reading values
+// close to each other at once.  Unknown names and fields already loaded
+// are skipped.  It does nothing for other Exifs.
+func (x *Exif) Load(names ...FieldName) error {
+	if x.lazy == nil || len(x.lazy.pending) == 0 {
+		return nil
+	}
+	var tags []*tiff.Tag
+	if len(names) == 0 {
+		for tag := range x.lazy.pending {
+			tags = append(tags, tag)
+		}
+	}
+	for _, name := range names {
+		if tag, ok := x.lookup(name); ok && x.lazy.pending[tag] != nil {
+			tags = append(tags, tag)
+		}
+	}
+	return x.loadTags(tags)
+}
+
+// loadTags loads the values of the pending tags, with one read for each
+// run of values less than loadGap apart.  Tags whose values would extend
+// past the end of the TIFF structure are left pending without reading
+// them, as their counts cannot be trusted to size a buffer.
+func (x *Exif) loadTags(tags []*tiff.Tag) error {
+	var firstErr error
+	valid := tags[:0]
+	for _, tag := range tags {
+		if int64(tag.ValOffset)+int64(tag.Type.Size())*int64(tag.Count) > x.lazy.size {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("exif: value of tag %#x past the end of the data", tag.Id)
+			}
+			continue
+		}
+		valid = append(valid, tag)
+	}
+	tags = valid
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ValOffset < tags[j].ValOffset })
+	for len(tags) > 0 {
+		start := int64(tags[0].ValOffset)
+		end := start
+		n := 0
+		for ; n < len(tags); n++ {
+			off := int64(tags[n].ValOffset)
+			if off > end+loadGap {
+				break
+			}
+			if e := off + int64(tags[n].Type.Size())*int64(tags[n].Count); e > end {
+				end = e
+			}
+		}
+		buf := make([]byte, end-start)
+		m, err := x.lazy.r.ReadAt(buf, start)
+		span := spanReader{buf[:m], start}
+		for _, tag := range tags[:n] {
+			entry := x.lazy.pending[tag]
+			loaded, err2 := tiff.DecodeEntry(entry, span, x.Tiff.Order)
+			if err2 == nil {
+				*tag = *loaded
+				delete(x.lazy.pending, tag)
+			} else if firstErr == nil {
+				firstErr = err2
+				if m < len(buf) && err != nil {
+					firstErr = err
+				}
+			}
+		}
+		tags = tags[n:]
+	}
+	return firstErr
+}
+
+// spanReader reads the bytes of b, located at offset base.
+type spanReader struct {
+	b    []byte
+	base int64
+}
+
+func (s spanReader) ReadAt(p []byte, off int64) (int, error) {
+	off -= s.base
+	if off < 0 || off >= int64(len(s.b)) {
+		return 0, io.EOF
+	}
+	n := copy(p, s.b[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// findExifSegment returns the offset and length of the TIFF structure of
+// the EXIF APP1 segment of the JPEG image of size bytes in r.
+func findExifSegment(r io.ReaderAt, size int64) (off, n int64, err error) {
+	pos := int64(2)
+	for pos+4 <= size {
+		var hdr [10]byte
+		if _, err := r.ReadAt(hdr[:4], pos); err != nil {
+			return 0, 0, err
+		}
+		if hdr[0] != 0xFF {
+			return 0, 0, errors.New("exif: invalid JPEG segment marker")
+		}
+		m := hdr[1]
+		switch {
+		case m == 0xFF:
+			pos++
+			continue
+		case m == jpegSOS || m == jpegEOI:
+			return 0, 0, ErrNoExif
+		case m == 0x01 || (m >= 0xD0 && m <= 0xD7):
+			pos += 2
+			continue
+		}
+		l := int64(binary.BigEndian.Uint16(hdr[2:]))
+		if l < 2 {
+			return 0, 0, errors.New("exif: invalid JPEG segment length")
+		}
+		if m == jpeg_APP1 && l >= 8 {
+			if _, err := r.ReadAt(hdr[4:], pos+4); err == nil && string(hdr[4:]) == "Exif\x00\x00" {
+				return pos + 10, l - 8, nil
+			}
+		}
+		pos += 2 + l
+	}
+	return 0, 0, ErrNoExif
+}
@@ -0,0 +1,169 @@
+package exif
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestDecodeIndex(t *testing.T) {
+	for _, name := range []string{"le_basic.tif", "le_thumbnail.jpg"} {
+		data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		fetch := func(p []byte, off int64) (int, error) {
+			return copy(p, data[off:]), nil
+		}
+		r := NewRangeReader(fetch, int64(len(data)))
+		r.Window = 64
+		x, err := new(Decoder).DecodeIndex(r, r.Size())
+		if err != nil {
+			t.Fatalf("%s: DecodeIndex: %v", name, err)
+		}
+
+		var pending int
+		for _, e := range x.Index() {
+			if !e.Loaded {
+				pending++
+				if e.Size <= 4 || e.Offset == 0 {
+					t.Errorf("%s: pending entry %+v", name, e)
+				}
+			}
+		}
+		if pending == 0 {
+			t.Fatalf("%s: no pending entries", name)
+		}
+
+		before := r.Stats()
+		tag, err := x.Get(Model)
+		if err != nil {
+			t.Fatalf("%s: Get(Model): %v", name, err)
+		}
+		wantTag, _ := want.Get(Model)
+		if tag.String() != wantTag.String() {
+			t.Errorf("%s: Model = %v, want %v", name, tag, wantTag)
+		}
+		if st := r.Stats(); st.Fetches-before.Fetches > 1 {
+			t.Errorf("%s: Get(Model) fetched %d times", name, st.Fetches-before.Fetches)
+		}
+
+		if err := x.Load(); err != nil {
+			t.Fatalf("%s: Load: %v", name, err)
+		}
+		for _, e := range x.Index() {
+			if !e.Loaded {
+				t.Errorf("%s: %s not loaded", name, e.Name)
+			}
+		}
+		if x.String() != want.String() {
+			t.Errorf("%s: fields = \n%v\nwant\n%v", name, x, want)
+		}
+	}
+}
+
+func TestDecodeIndexNoExif(t *testing.T) {
+	data := jpegNoExif(100)
+	if _, err := new(Decoder).DecodeIndex(bytes.NewReader(data), int64(len(data))); err != ErrNoExif {
+		t.Errorf("DecodeIndex = %v, want ErrNoExif", err)
+	}
+}
+
+func TestDecodeIndexHugeCount(t *testing.T) {
+	for _, e := range []testEntry{
+		{0x010F, tiff.DTDouble, 0x7FFFFFF0, make([]byte, 8)},
+		{0x8769, tiff.DTLong, 0x7FFFFFF0, make([]byte, 8)},
+	} {
+		data := buildTIFF(e)
+		x, err := new(Decoder).DecodeIndex(bytes.NewReader(data), int64(len(data)))
+		if err != nil && !IsExifError(err) {
+			t.Fatalf("tag %#x: DecodeIndex: %v", e.id, err)
+		}
+		if err := x.Load(); err == nil {
+			t.Errorf("tag %#x: Load succeeded", e.id)
+		}
+		if _, err := x.MarshalJSON(); err == nil {
+			t.Errorf("tag %#x: MarshalJSON succeeded", e.id)
+		}
+	}
+}
+
+// byteFile is a file held in memory.
+type byteFile []byte
+
+func (f byteFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(f)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f byteFile) WriteAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > int64(len(f)) {
+		return 0, io.ErrShortWrite
+	}
+	return copy(f[off:], p), nil
+}
+
+func TestIndexEntryPatch(t *testing.T) {
+	for _, name := range []string{"be_basic.tif", "le_thumbnail.jpg"} {
+		data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := byteFile(data)
+		x, err := new(Decoder).DecodeIndex(f, int64(len(f)))
+		if err != nil {
+			t.Fatalf("%s: DecodeIndex: %v", name, err)
+		}
+		patched := map[FieldName]string{
+			DateTimeOriginal: `"2021:06:01 12:00:00"`,
+			Orientation:      `6`,
+		}
+		for _, e := range x.Index() {
+			var val []byte
+			switch e.Name {
+			case DateTimeOriginal:
+				val = []byte("2021:06:01 12:00:00\x00")
+			case Orientation:
+				val = make([]byte, 2)
+				x.ByteOrder().PutUint16(val, 6)
+			default:
+				continue
+			}
+			if err := e.Patch(f, val[:1]); err == nil {
+				t.Errorf("%s: %v: patched with a value of the wrong size", name, e.Name)
+			}
+			if err := e.Patch(f, val); err != nil {
+				t.Fatalf("%s: %v: %v", name, e.Name, err)
+			}
+		}
+
+		got, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for field, want := range patched {
+			if tag, err := got.Get(field); err != nil || tag.String() != want {
+				t.Errorf("%s: %v = %v, %v; want %v", name, field, tag, err, want)
+			}
+		}
+		for _, e := range got.Index() {
+			if e.FileOffset != -1 {
+				t.Errorf("%s: Decode: %v has FileOffset %d", name, e.Name, e.FileOffset)
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package exif
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// DNG opcode list tags, applied to the raw data as read (1), after mapping
+// to linear values (2) and after demosaicing (3).
+var opcodeListIDs = [...]uint16{0xC740, 0xC741, 0xC74E}
+
+// Opcode is an entry of a DNG opcode list, a processing step a raw
+// developer must apply to the image.
+type Opcode struct {
+	ID      uint32
+	Version uint32 // DNG version the opcode was introduced in
+	Flags   uint32 // bit 0: optional, bit 1: may be skipped for previews
+	Params  []byte
+}
+
+// Optional reports whether a reader not supporting the opcode may ignore
+// it.
+func (op Opcode) Optional() bool {
+	return op.Flags&1 != 0
+}
+
+// ParseOpcodeList parses the value of a DNG OpcodeList tag, which is big
+// endian whatever the byte order of the file.
+func ParseOpcodeList(b []byte) ([]Opcode, error) {
+	if len(b) < 4 {
+		return nil, fmt.Errorf("exif: opcode list of %d bytes", len(b))
+	}
+	n := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	var ops []Opcode
+	for i := uint32(0); i < n; i++ {
+		if len(b) < 16 {
+			return ops, fmt.Errorf("exif: opcode list truncated at opcode %d", i)
+		}
+		op := Opcode{
+			ID:      binary.BigEndian.Uint32(b),
+			Version: binary.BigEndian.Uint32(b[4:]),
+			Flags:   binary.BigEndian.Uint32(b[8:]),
+		}
+		size := binary.BigEndian.Uint32(b[12:])
+		b = b[16:]
+		if uint32(len(b)) < size {
+			return ops, fmt.Errorf("exif: opcode list truncated at opcode %d", i)
+		}
+		op.Params, b = b[:size], b[size:]
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// LensCorrectionKind is the kind of a lens correction.
+type LensCorrectionKind int
+
+// DNG opcode IDs of the lens corrections.
+const (
+	WarpRectilinear   LensCorrectionKind = 1
+	WarpFisheye       LensCorrectionKind = 2
+	FixVignetteRadial LensCorrectionKind = 3
+)
+
+var lensCorrectionNames = map[LensCorrectionKind]string{
+	WarpRectilinear:   "WarpRectilinear",
+	WarpFisheye:       "WarpFisheye",
+	FixVignetteRadial: "FixVignetteRadial",
+}
+
+func (k LensCorrectionKind) String() string {
+	if s, ok := lensCorrectionNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("LensCorrectionKind(%d)", int(k))
+}
+
+// LensCorrection is a distortion or vignetting correction to apply to the
+// image, with the parameters of the DNG specification.  Coordinates are
+// relative to the image: (0, 0) is its top left corner and (1, 1) its bottom
+// right one.
+type LensCorrection struct {
+	Kind LensCorrectionKind
+	// List is the opcode list (1, 2 or 3) holding the correction.
+	List     int
+	Optional bool
+	// Coeffs holds, for the warps, the coefficients of each color plane
+	// (or a single set for all planes): kr0-kr3, kt0 and kt1 for
+	// WarpRectilinear and kr0-kr3 for WarpFisheye.  For FixVignetteRadial,
+	// it holds a single set, k0-k4.
+	Coeffs [][]float64
+	// CenterX and CenterY give the optical center.
+	CenterX, CenterY float64
+}
+
+// LensCorrections returns the lens corrections of the DNG opcode lists in
+// IFD0 and its sub-IFDs, in the order they are to be applied.  It returns
+// nil if there are none.  Other opcodes are skipped.  The lens corrections
+// stored in makernotes are not decoded.
+func (x *Exif) LensCorrections() ([]LensCorrection, error) {
+	if x.Tiff == nil || len(x.Tiff.Dirs) == 0 {
+		return nil, nil
+	}
+	dirs := []*tiff.Dir{x.Tiff.Dirs[0]}
+	subs, err := x.SubIFDs()
+	if err != nil {
+		return nil, err
+	}
+	var walk func(subs []*tiff.SubDir)
+	walk = func(subs []*tiff.SubDir) {
+		for _, s := range subs {
+			dirs = append(dirs, s.Dir)
+			walk(s.Subs)
+		}
+	}
+	walk(subs)
+
+	var lcs []LensCorrection
+	for list, id := range opcodeListIDs {
+		for _, d := range dirs {
+			for _, tag := range d.Tags {
+				if tag.Id != id {
+					continue
+				}
+				ops, err := ParseOpcodeList(tag.Val)
+				if err != nil {
+					return lcs, err
+				}
+				for _, op := range ops {
+					lc, ok, err := lensCorrection(op)
+					if err != nil {
+						return lcs, err
+					}
+					if ok {
+						lc.List = list + 1
+						lcs = append(lcs, lc)
+					}
+				}
+			}
+		}
+	}
+	return lcs, nil
+}
+
+// lensCorrection decodes the parameters of op if it is a lens correction.
+func lensCorrection(op Opcode) (lc LensCorrection, ok bool, err error) {
+	kind := LensCorrectionKind(op.ID)
+	var n int // coefficients per plane
+	switch kind {
+	case WarpRectilinear:
+		n = 6
+	case WarpFisheye:
+		n = 4
+	case FixVignetteRadial:
+		n = 5
+	default:
+		return lc, false, nil
+	}
+	p := op.Params
+	planes := uint32(1)
+	if kind != FixVignetteRadial {
+		if len(p) < 4 {
+			return lc, false, fmt.Errorf("exif: %v opcode too short", kind)
+		}
+		planes, p = binary.BigEndian.Uint32(p), p[4:]
+	}
+	if planes == 0 || uint64(len(p)) != (uint64(planes)*uint64(n)+2)*8 {
+		return lc, false, fmt.Errorf("exif: %v opcode has %d bytes of parameters for %d planes", kind, len(op.Params), planes)
+	}
+	double := func() float64 {
+		f := math.Float64frombits(binary.BigEndian.Uint64(p))
+		p = p[8:]
+		return f
+	}
+	lc = LensCorrection{Kind: kind, Optional: op.Optional()}
+	for i := uint32(0); i < planes; i++ {
+		c := make([]float64, n)
+		for j := range c {
+			c[j] = double()
+		}
+		lc.Coeffs = append(lc.Coeffs, c)
+	}
+	lc.CenterX, lc.CenterY = double(), double()
+	return lc, true, nil
+}
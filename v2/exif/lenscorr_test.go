@@ -0,0 +1,69 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// testOpcode is an opcode with float64 parameters, preceded by a plane
+// count if planes is positive.
+type testOpcode struct {
+	id, flags uint32
+	planes    int
+	params    []float64
+}
+
+// testOpcodeList returns a tag holding the DNG opcode list of ops.
+func testOpcodeList(t *testing.T, id uint16, ops ...testOpcode) *tiff.Tag {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint32(len(ops)))
+	for _, op := range ops {
+		size := 8 * len(op.params)
+		if op.planes > 0 {
+			size += 4
+		}
+		binary.Write(&buf, binary.BigEndian, []uint32{op.id, 0x01030000, op.flags, uint32(size)})
+		if op.planes > 0 {
+			binary.Write(&buf, binary.BigEndian, uint32(op.planes))
+		}
+		binary.Write(&buf, binary.BigEndian, op.params)
+	}
+	tag, err := tiff.NewTag(id, tiff.DTUndefined, binary.LittleEndian, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tag
+}
+
+func TestLensCorrections(t *testing.T) {
+	x := &Exif{Tiff: &tiff.Tiff{Order: binary.LittleEndian, Dirs: []*tiff.Dir{{Tags: []*tiff.Tag{
+		testOpcodeList(t, 0xC74E, testOpcode{3, 1, 0, []float64{1, 0.5, 0, 0, 0, 0.5, 0.5}}),
+		testOpcodeList(t, 0xC740,
+			testOpcode{9, 0, 0, []float64{0}},
+			testOpcode{1, 0, 1, []float64{1, -0.1, 0.01, 0, 0, 0, 0.5, 0.4}},
+		),
+	}}}}}
+	lcs, err := x.LensCorrections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LensCorrection{
+		{Kind: WarpRectilinear, List: 1, Coeffs: [][]float64{{1, -0.1, 0.01, 0, 0, 0}}, CenterX: 0.5, CenterY: 0.4},
+		{Kind: FixVignetteRadial, List: 3, Optional: true, Coeffs: [][]float64{{1, 0.5, 0, 0, 0}}, CenterX: 0.5, CenterY: 0.5},
+	}
+	if !reflect.DeepEqual(lcs, want) {
+		t.Errorf("got %+v, want %+v", lcs, want)
+	}
+
+	x.Tiff.Dirs[0].Tags = []*tiff.Tag{testOpcodeList(t, 0xC741, testOpcode{2, 0, 3, []float64{1, 0, 0, 0, 0.5, 0.5}})}
+	if _, err := x.LensCorrections(); err == nil {
+		t.Errorf("decoded WarpFisheye with missing planes")
+	}
+	if _, err := ParseOpcodeList([]byte{0, 0, 0, 1, 0}); err == nil {
+		t.Errorf("parsed truncated opcode list")
+	}
+}
@@ -0,0 +1,136 @@
+package exif
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// NamespaceSep separates a namespace from the field name in namespaced
+// field names (e.g. "Canon.LensType").
+const NamespaceSep = "."
+
+var namespaces = map[string]map[uint16]FieldName{}
+
+// RegisterFields registers the tag id to field name mapping for the
+// makernote (or other vendor) namespace ns.  Tags loaded with
+// LoadNamespacedTags are stored as ns + NamespaceSep + name, so
+// Get("Canon.LensType") returns the LensType tag loaded under "Canon" and
+// vendor field names never collide with standard EXIF field names.  The
+// names in fields must not include the namespace.
+//
+// RegisterFields is intended to be called from the init function of
+// packages providing makernote parsers.  It fails if ns is empty, contains
+// NamespaceSep or is already registered.
+func RegisterFields(ns string, fields map[uint16]FieldName) error {
+	if ns == "" || strings.Contains(ns, NamespaceSep) {
+		return fmt.Errorf("exif: invalid field namespace %q", ns)
+	}
+	if _, dup := namespaces[ns]; dup {
+		return fmt.Errorf("exif: RegisterFields called twice for namespace %q", ns)
+	}
+	m := make(map[uint16]FieldName, len(fields))
+	for id, name := range fields {
+		m[id] = Namespaced(ns, name)
+	}
+	namespaces[ns] = m
+	return nil
+}
+
+// Namespaced returns the field name for name in namespace ns.
+func Namespaced(ns string, name FieldName) FieldName {
+	return FieldName(ns + NamespaceSep + string(name))
+}
+
+// Namespace splits a namespaced field name into its namespace and bare
+// name.  ns is empty if name has no namespace.
+func (name FieldName) Namespace() (ns string, bare FieldName) {
+	i := strings.Index(string(name), NamespaceSep)
+	if i < 0 {
+		return "", name
+	}
+	return string(name[:i]), name[i+len(NamespaceSep):]
+}
+
+// LoadNamespacedTags is like LoadTags, using the field table registered for
+// namespace ns.  Missing tags are loaded as ns + NamespaceSep + UnknownPrefix
+// followed by the tag ID (in hex format) if showMissing is true.
+func (x *Exif) LoadNamespacedTags(ns string, d *tiff.Dir, showMissing bool) error {
+	fields, ok := namespaces[ns]
+	if !ok {
+		return fmt.Errorf("exif: field namespace %q is not registered", ns)
+	}
+	defer x.setGroup(x.group)
+	x.setGroup(GroupMakerNote + NamespaceSep + ns)
+	for _, tag := range d.Tags {
+		name := fields[tag.Id]
+		if name == "" {
+			if !showMissing {
+				continue
+			}
+			name = Namespaced(ns, FieldName(fmt.Sprintf("%v%x", UnknownPrefix, tag.Id)))
+		}
+		x.setTag(name, tag)
+	}
+	return nil
+}
+
+// Field groups, naming the IFD (or makernote) a field was loaded from.
+// Makernote fields are grouped by parser or namespace name, e.g.
+// "MakerNote.Canon".
+const (
+	GroupIFD0      = "IFD0"
+	GroupIFD1      = "IFD1"
+	GroupExif      = "Exif"
+	GroupGPS       = "GPS"
+	GroupInterop   = "Interop"
+	GroupMakerNote = "MakerNote"
+)
+
+// GroupSep separates the group from the field name in qualified field names
+// (e.g. "Exif/DateTimeOriginal").
+const GroupSep = "/"
+
+// Qualified returns the qualified field name of name in group.  Qualified
+// names are unambiguous when the same name is used in several IFDs or
+// makernotes.  The namespace of a namespaced name is dropped, as the group
+// already includes it.
+func Qualified(group string, name FieldName) FieldName {
+	_, bare := name.Namespace()
+	return FieldName(group + GroupSep + string(bare))
+}
+
+// Group splits a qualified field name into its group and unqualified name.
+// group is empty if name is not qualified.
+func (name FieldName) Group() (group string, bare FieldName) {
+	i := strings.Index(string(name), GroupSep)
+	if i < 0 {
+		return "", name
+	}
+	return string(name[:i]), name[i+len(GroupSep):]
+}
+
+// QualifiedName returns the qualified name of the field name (as passed to
+// Walkers) in x, or name itself if the group it was loaded from is unknown.
+func (x *Exif) QualifiedName(name FieldName) FieldName {
+	if f, ok := x.main.get(name); ok && f.group != "" {
+		return Qualified(f.group, name)
+	}
+	return name
+}
+
+// setGroup sets the group subsequently loaded tags are recorded in.
+func (x *Exif) setGroup(group string) {
+	x.group = group
+}
+
+// setTag stores tag as the field name, recorded as loaded from the current
+// group.  A field of the same name from another group remains accessible by
+// qualified name.
+func (x *Exif) setTag(name FieldName, tag *tiff.Tag) {
+	old, ok := x.main.set(field{name, x.group, tag})
+	if ok && old.group != "" && old.group != x.group {
+		x.shadowed.set(field{Qualified(old.group, old.name), old.group, old.tag})
+	}
+}
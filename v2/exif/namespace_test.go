@@ -0,0 +1,100 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestLoadNamespacedTags(t *testing.T) {
+	if err := RegisterFields("TestVendor", map[uint16]FieldName{0x0001: "LensType", 0x0002: Model}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ns := range []string{"", "Test.Vendor", "TestVendor"} {
+		if err := RegisterFields(ns, nil); err == nil {
+			t.Errorf("RegisterFields(%q) succeeded", ns)
+		}
+	}
+
+	lens := testString(t, "EF 50mm f/1.8")
+	lens.Id = 0x0001
+	model := testString(t, "Vendor Model")
+	model.Id = 0x0002
+	unknown := testString(t, "?")
+	unknown.Id = 0x00ab
+
+	x := &Exif{}
+	x.setTag(Model, testString(t, "Camera"))
+	d := &tiff.Dir{Tags: []*tiff.Tag{lens, model, unknown}}
+	if err := x.LoadNamespacedTags("TestVendor", d, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if tag, err := x.Get("TestVendor.LensType"); err != nil || tag != lens {
+		t.Errorf("Get(TestVendor.LensType) = %v, %v", tag, err)
+	}
+	if tag, err := x.Get("TestVendor.Model"); err != nil || tag != model {
+		t.Errorf("Get(TestVendor.Model) = %v, %v", tag, err)
+	}
+	if tag, _ := x.Get(Model); tag == model {
+		t.Errorf("namespaced Model overwrote the standard Model field")
+	}
+	if tag, err := x.Get("MakerNote.TestVendor/LensType"); err != nil || tag != lens {
+		t.Errorf("Get(MakerNote.TestVendor/LensType) = %v, %v", tag, err)
+	}
+	if _, err := x.Get("TestVendor." + UnknownPrefix + "ab"); err != nil {
+		t.Errorf("missing tag not loaded: %v", err)
+	}
+
+	if err := x.LoadNamespacedTags("NoSuchVendor", d, false); err == nil {
+		t.Errorf("unregistered namespace: got no error")
+	}
+
+	if ns, name := FieldName("TestVendor.LensType").Namespace(); ns != "TestVendor" || name != "LensType" {
+		t.Errorf("Namespace() = %q, %q", ns, name)
+	}
+	if ns, name := Model.Namespace(); ns != "" || name != Model {
+		t.Errorf("Namespace() = %q, %q", ns, name)
+	}
+}
+
+func TestQualifiedNames(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name, qualified FieldName
+	}{
+		{Model, "IFD0/Model"},
+		{ExposureTime, "Exif/ExposureTime"},
+		{ThumbJPEGInterchangeFormat, "IFD1/ThumbJPEGInterchangeFormat"},
+	} {
+		if got := x.QualifiedName(test.name); got != test.qualified {
+			t.Errorf("QualifiedName(%v) = %v, want %v", test.name, got, test.qualified)
+		}
+		bare, err := x.Get(test.name)
+		if err != nil {
+			t.Errorf("Get(%v): %v", test.name, err)
+			continue
+		}
+		if tag, err := x.Get(test.qualified); err != nil || tag != bare {
+			t.Errorf("Get(%v) = %v, %v; want %v", test.qualified, tag, err, bare)
+		}
+	}
+	if _, err := x.Get("GPS/Model"); err == nil {
+		t.Errorf("Get(GPS/Model): got no error")
+	}
+
+	if group, name := FieldName("Exif/ExposureTime").Group(); group != GroupExif || name != ExposureTime {
+		t.Errorf("Group() = %q, %q", group, name)
+	}
+}
@@ -0,0 +1,91 @@
+package exif
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// jpegNoExif returns a JPEG image with JFIF and table segments but no EXIF
+// data, followed by size bytes of image data.
+func jpegNoExif(size int) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xD8})
+	b.Write([]byte{0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0, 1, 1, 0, 0, 1, 0, 1, 0, 0})
+	b.Write(append([]byte{0xFF, 0xDB, 0x00, 0x43, 0x00}, make([]byte, 64)...))
+	b.Write([]byte{0xFF, 0xDA, 0x00, 0x08, 0x01, 0x01, 0x00, 0x00, 0x3F, 0x00})
+	for i := 0; i < size; i++ {
+		// Stuffed 0xFF bytes of the image data look like APP1 markers to a
+		// byte by byte scan.
+		if i%2 == 0 {
+			b.WriteByte(0xFF)
+		} else {
+			b.WriteByte(0xE1)
+		}
+	}
+	b.Write([]byte{0xFF, 0xD9})
+	return b.Bytes()
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestDecodeNoExif(t *testing.T) {
+	data := jpegNoExif(1 << 20)
+	cr := &countingReader{r: bytes.NewReader(data)}
+	if _, err := Decode(cr); err != ErrNoExif {
+		t.Errorf("got error %v, want ErrNoExif", err)
+	}
+	if cr.n > 64<<10 {
+		t.Errorf("read %d of %d bytes", cr.n, len(data))
+	}
+
+	// Segments up to the EXIF data are skipped.
+	exifData, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jfif := jpegNoExif(0)[2:20]
+	jpg := append(append([]byte{0xFF, 0xD8}, jfif...), exifData[2:]...)
+	x, err := Decode(bytes.NewReader(jpg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag, err := x.Get(Model); err != nil || tag.String() != `"Synth 1"` {
+		t.Errorf("Model is %v, %v", tag, err)
+	}
+}
+
+func BenchmarkDecodeNoExif(b *testing.B) {
+	data := jpegNoExif(1 << 20)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(bytes.NewReader(data)); err != ErrNoExif {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeJPEG(b *testing.B) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package exif
+
+import (
+	"context"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// An Option configures a Decoder created by NewDecoder or used by Decode.
+// Each option sets the Decoder field of the same name.
+type Option func(*Decoder)
+
+// NewDecoder returns a Decoder configured by opts, applied in order.
+func NewDecoder(opts ...Option) *Decoder {
+	d := &Decoder{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// WithMakerNoteParsers selects the makernote parsers to run, in priority
+// order; with no names, makernotes are not parsed.
+func WithMakerNoteParsers(names ...string) Option {
+	return func(d *Decoder) {
+		d.MakerNoteParsers = append([]string{}, names...)
+	}
+}
+
+// WithPermissive sets whether malformed data is accepted with warnings.
+func WithPermissive(permissive bool) Option {
+	return func(d *Decoder) { d.Permissive = permissive }
+}
+
+// WithKeepOnly lists the only fields to keep.
+func WithKeepOnly(names ...FieldName) Option {
+	return func(d *Decoder) {
+		d.KeepOnly = append(d.KeepOnly, names...)
+	}
+}
+
+// WithSubIFDLimits bounds the trees of IFDs read by Exif.SubIFDs.
+func WithSubIFDLimits(limits tiff.SubIFDLimits) Option {
+	return func(d *Decoder) { d.SubIFDLimits = limits }
+}
+
+// WithFingerprint enables the fingerprinting of JPEG tables.
+func WithFingerprint() Option {
+	return func(d *Decoder) { d.Fingerprint = true }
+}
+
+// WithDropImplausible makes the Decoder delete fields holding obviously
+// invalid values.
+func WithDropImplausible() Option {
+	return func(d *Decoder) { d.DropImplausible = true }
+}
+
+// WithDuplicates selects which of the tags repeated within an IFD is
+// loaded.
+func WithDuplicates(policy DuplicatePolicy) Option {
+	return func(d *Decoder) { d.Duplicates = policy }
+}
+
+// WithJSONStrings selects how ASCII and undefined values are rendered as
+// JSON.
+func WithJSONStrings(mode tiff.StringMode) Option {
+	return func(d *Decoder) { d.JSONStrings = mode }
+}
+
+// WithRationals selects how rational values are rendered.
+func WithRationals(format RationalFormat) Option {
+	return func(d *Decoder) { d.Rationals = format }
+}
+
+// WithProgress sets the function reporting decoding progress.
+func WithProgress(fn func(stage Stage, n int64) error) Option {
+	return func(d *Decoder) { d.Progress = fn }
+}
+
+// WithContext stops decoding with the error of ctx once it is done.  It
+// is checked as input is read and before each parser is run, in addition
+// to any Progress function.
+func WithContext(ctx context.Context) Option {
+	return func(d *Decoder) { d.ctx = ctx }
+}
+
+// contextProgress returns a progress function failing with the error of
+// ctx once it is done, and otherwise calling fn, which may be nil.
+func contextProgress(ctx context.Context, fn func(Stage, int64) error) func(Stage, int64) error {
+	return func(stage Stage, n int64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if fn != nil {
+			return fn(stage, n)
+		}
+		return nil
+	}
+}
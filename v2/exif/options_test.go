@@ -0,0 +1,45 @@
+package exif_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/exiftest"
+)
+
+func TestDecodeOptions(t *testing.T) {
+	data, err := exiftest.NewJPEG().
+		WithTag(exif.Make, "Synth").
+		WithTag(exif.Model, "Synth 1").
+		WithTag(exif.FNumber, [2]int64{28, 10}).
+		Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(data)
+
+	x, err := exif.Decode(r, exif.WithKeepOnly(exif.Model), exif.WithRationals(exif.RationalFormat{Units: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Get(exif.Model); err != nil {
+		t.Errorf("Model: %v", err)
+	}
+	if _, err := x.Get(exif.Make); err == nil {
+		t.Error("Make kept")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.Seek(0, 0)
+	if _, err := exif.Decode(r, exif.WithContext(ctx)); err != context.Canceled {
+		t.Errorf("Decode canceled context = %v", err)
+	}
+
+	d := exif.NewDecoder(exif.WithMakerNoteParsers(), exif.WithPermissive(true))
+	if d.MakerNoteParsers == nil || len(d.MakerNoteParsers) != 0 || !d.Permissive {
+		t.Errorf("NewDecoder = %+v", d)
+	}
+}
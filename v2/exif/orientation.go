@@ -0,0 +1,55 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// ReadWriterAt is the interface of files that can be read and overwritten
+// at arbitrary offsets, such as *os.File.
+type ReadWriterAt interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// ErrNoOrientation is returned by SetOrientationInPlace for files without an
+// Orientation field: one cannot be added without rewriting the file.
+var ErrNoOrientation = errors.New("exif: no Orientation field to overwrite")
+
+// SetOrientationInPlace overwrites the value of the Orientation field of
+// IFD0 of the TIFF or JPEG file rw with orientation (1 to 8, see the EXIF
+// specification), leaving the rest of the file untouched.  Only the IFDs
+// and the value itself are read, so rotating an image is cheap whatever
+// its size.
+func SetOrientationInPlace(rw ReadWriterAt, orientation int) error {
+	if orientation < 1 || orientation > 8 {
+		return fmt.Errorf("exif: invalid orientation %d", orientation)
+	}
+	x, err := new(Decoder).DecodeIndex(rw, math.MaxInt64)
+	if x == nil || (err != nil && IsCriticalError(err)) {
+		if err == io.EOF {
+			err = ErrNoExif
+		}
+		return err
+	}
+	for _, e := range x.Index() {
+		if e.Name != Orientation || e.Group != GroupIFD0 {
+			continue
+		}
+		val := make([]byte, e.Size)
+		switch {
+		case e.Type == tiff.DTShort && e.Count == 1:
+			x.ByteOrder().PutUint16(val, uint16(orientation))
+		case e.Type == tiff.DTLong && e.Count == 1:
+			x.ByteOrder().PutUint32(val, uint32(orientation))
+		default:
+			return fmt.Errorf("exif: Orientation field of type %v and count %d", e.Type, e.Count)
+		}
+		return e.Patch(rw, val)
+	}
+	return ErrNoOrientation
+}
@@ -0,0 +1,49 @@
+package exif
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetOrientationInPlace(t *testing.T) {
+	for _, name := range []string{"be_basic.tif", "le_thumbnail.jpg", "be_gps.jpg"} {
+		data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := append([]byte(nil), data...)
+		if err := SetOrientationInPlace(byteFile(data), 8); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		x, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if tag, err := x.Get(Orientation); err != nil || tag.String() != "8" {
+			t.Errorf("%s: Orientation = %v, %v; want 8", name, tag, err)
+		}
+		var changed int
+		for i := range data {
+			if data[i] != orig[i] {
+				changed++
+			}
+		}
+		if changed != 1 {
+			t.Errorf("%s: %d bytes changed, want 1", name, changed)
+		}
+
+		if err := SetOrientationInPlace(byteFile(data), 9); err == nil {
+			t.Errorf("%s: invalid orientation accepted", name)
+		}
+	}
+
+	data := buildTIFF(asciiEntry(0x010F, "Example"))
+	if err := SetOrientationInPlace(byteFile(data), 6); err != ErrNoOrientation {
+		t.Errorf("no Orientation field: got %v", err)
+	}
+	if err := SetOrientationInPlace(byteFile(jpegNoExif(100)), 6); err != ErrNoExif {
+		t.Errorf("no EXIF data: got %v", err)
+	}
+}
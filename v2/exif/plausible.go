@@ -0,0 +1,118 @@
+package exif
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// minPlausibleYear is the earliest year accepted for time stamps: digital
+// cameras recording EXIF data did not exist before then, so earlier dates
+// are almost always unset clocks.
+const minPlausibleYear = 1990
+
+// futureSlack is how far in the future time stamps may lie, allowing for
+// time zones and clocks set slightly fast.
+const futureSlack = 24 * time.Hour
+
+// dateFields are the time stamp fields checked by Implausible, with their
+// layouts.
+var dateFields = []struct {
+	name   FieldName
+	layout string
+}{
+	{DateTime, "2006:01:02 15:04:05"},
+	{DateTimeOriginal, "2006:01:02 15:04:05"},
+	{DateTimeDigitized, "2006:01:02 15:04:05"},
+	{GPSDateStamp, "2006:01:02"},
+}
+
+// nonZeroFields are the fields for which a zero value means the camera did
+// not know the actual value.
+var nonZeroFields = []FieldName{ISOSpeedRatings, FocalLength, FNumber, ExposureTime}
+
+// Implausible returns a warning for each field of x holding an obviously
+// invalid value: GPS coordinates of exactly (0, 0), time stamps that are
+// malformed, before 1990 or in the future, and an ISO, focal length,
+// aperture or exposure time of zero.  Such values are typically written by
+// cameras that do not know the actual value.
+func (x *Exif) Implausible() []Warning {
+	return x.implausible(time.Now())
+}
+
+func (x *Exif) implausible(now time.Time) []Warning {
+	var ws []Warning
+	if lat, long, err := x.LatLong(); err == nil && lat == 0 && long == 0 {
+		ws = append(ws,
+			Warning{GPSLatitude, "GPS position (0, 0) is implausible"},
+			Warning{GPSLongitude, "GPS position (0, 0) is implausible"})
+	}
+
+	for _, f := range dateFields {
+		s, err := x.stringVal(f.name)
+		if err != nil {
+			continue
+		}
+		s = strings.TrimSpace(s)
+		t, err := time.Parse(f.layout, s)
+		switch {
+		case err != nil:
+			ws = append(ws, Warning{f.name, fmt.Sprintf("malformed time stamp %q", s)})
+		case t.Year() < minPlausibleYear:
+			ws = append(ws, Warning{f.name, fmt.Sprintf("time stamp %q is too early", s)})
+		case t.After(now.Add(futureSlack)):
+			ws = append(ws, Warning{f.name, fmt.Sprintf("time stamp %q is in the future", s)})
+		}
+	}
+
+	for _, name := range nonZeroFields {
+		if tag, err := x.Get(name); err == nil && isZeroTag(tag) {
+			ws = append(ws, Warning{name, "zero value is implausible"})
+		}
+	}
+	return ws
+}
+
+// DropImplausible deletes the fields reported by Implausible, so that they
+// read as missing rather than holding garbage, and records the reasons as
+// warnings of x.  With GPS coordinates of (0, 0), the latitude and
+// longitude reference fields are deleted as well.
+func (x *Exif) DropImplausible() {
+	x.dropImplausible(time.Now())
+}
+
+func (x *Exif) dropImplausible(now time.Time) {
+	ws := x.implausible(now)
+	for _, w := range ws {
+		x.main.delete(w.Field)
+		switch w.Field {
+		case GPSLatitude:
+			x.main.delete(GPSLatitudeRef)
+		case GPSLongitude:
+			x.main.delete(GPSLongitudeRef)
+		}
+	}
+	x.warnings = append(x.warnings, ws...)
+}
+
+// isZeroTag reports whether the first value of the numeric tag is zero.
+// Rationals with a zero denominator count as zero.
+func isZeroTag(tag *tiff.Tag) bool {
+	if tag.Count == 0 {
+		return false
+	}
+	switch tag.Format() {
+	case tiff.IntVal:
+		v, err := tag.Int64(0)
+		return err == nil && v == 0
+	case tiff.RatVal:
+		num, den, err := tag.Rat2(0)
+		return err == nil && (num == 0 || den == 0)
+	case tiff.FloatVal:
+		v, err := tag.Float(0)
+		return err == nil && v == 0
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestImplausible(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	x := &Exif{}
+	x.setTag(DateTime, testString(t, "2019:12:31 23:00:00"))
+	x.setTag(DateTimeOriginal, testString(t, "1970:01:01 00:00:00"))
+	x.setTag(DateTimeDigitized, testString(t, "    :  :     :  :  "))
+	x.setTag(GPSDateStamp, testString(t, "2030:01:01"))
+	x.setTag(FocalLength, testRational(t, 0, 0))
+	x.setTag(FNumber, testRational(t, 28, 10))
+	zero := []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1}
+	x.setTag(GPSLatitude, testTag(t, tiff.DTRational, 3, zero))
+	x.setTag(GPSLatitudeRef, testString(t, "N"))
+	x.setTag(GPSLongitude, testTag(t, tiff.DTRational, 3, zero))
+	x.setTag(GPSLongitudeRef, testString(t, "E"))
+
+	x.dropImplausible(now)
+	want := []FieldName{GPSLatitude, GPSLongitude, DateTimeOriginal, DateTimeDigitized, GPSDateStamp, FocalLength}
+	ws := x.Warnings()
+	if len(ws) != len(want) {
+		t.Fatalf("got warnings %v, want for %v", ws, want)
+	}
+	for i, w := range ws {
+		if w.Field != want[i] {
+			t.Errorf("warning %d is %v, want one for %v", i, w, want[i])
+		}
+		if _, err := x.Get(w.Field); err == nil {
+			t.Errorf("%v not dropped", w.Field)
+		}
+	}
+	for _, name := range []FieldName{DateTime, FNumber} {
+		if _, err := x.Get(name); err != nil {
+			t.Errorf("%v dropped", name)
+		}
+	}
+	if _, err := x.Get(GPSLatitudeRef); err == nil {
+		t.Error("GPSLatitudeRef not dropped")
+	}
+}
+
+func TestDecodeDropImplausible(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", "be_gps.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := (&Decoder{DropImplausible: true}).Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws := x.Warnings(); len(ws) != 0 {
+		t.Errorf("got warnings %v", ws)
+	}
+	if _, _, err := x.LatLong(); err != nil {
+		t.Error(err)
+	}
+}
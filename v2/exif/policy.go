@@ -0,0 +1,114 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A Policy is a set of rules the metadata of images must satisfy, e.g.
+// before they are published.  The zero Policy accepts every image.
+//
+// Policies can be built in Go or read from JSON with ParsePolicy, using
+// the field names given in the struct tags:
+//
+//	{"require": ["Artist"], "forbidGPS": true, "maxAgeDays": 30}
+type Policy struct {
+	// Require and Forbid list fields images must have and must not have.
+	Require []FieldName `json:"require,omitempty"`
+	Forbid  []FieldName `json:"forbid,omitempty"`
+	// RequireCopyright requires a copyright notice, in the Copyright field
+	// or the XMP data (see Rights).
+	RequireCopyright bool `json:"requireCopyright,omitempty"`
+	// ForbidGPS forbids all GPS fields.
+	ForbidGPS bool `json:"forbidGPS,omitempty"`
+	// MaxAgeDays, if positive, is how many days before the check the image
+	// may have been taken, according to DateTime.
+	MaxAgeDays int `json:"maxAgeDays,omitempty"`
+	// MaxThumbnailSize, if positive, is the size limit in bytes of the
+	// embedded JPEG thumbnail.
+	MaxThumbnailSize int `json:"maxThumbnailSize,omitempty"`
+}
+
+// Policy rule names, as reported in Violations.
+const (
+	RuleRequire      = "require"
+	RuleForbid       = "forbid"
+	RuleCopyright    = "requireCopyright"
+	RuleGPS          = "forbidGPS"
+	RuleMaxAge       = "maxAgeDays"
+	RuleMaxThumbnail = "maxThumbnailSize"
+)
+
+// A Violation is an image's breach of a Policy rule.
+type Violation struct {
+	// Rule is the rule broken, one of the Rule constants.
+	Rule string
+	// Field is the field concerned, if any.
+	Field FieldName
+	Msg   string
+}
+
+func (v Violation) String() string {
+	if v.Field != "" {
+		return fmt.Sprintf("%v: %v: %v", v.Rule, v.Field, v.Msg)
+	}
+	return fmt.Sprintf("%v: %v", v.Rule, v.Msg)
+}
+
+// ParsePolicy reads a Policy from its JSON form.  Unknown keys are an
+// error, so that misspelled rules are not silently ignored.
+func ParsePolicy(data []byte) (*Policy, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var p Policy
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("exif: invalid policy: %v", err)
+	}
+	return &p, nil
+}
+
+// Check returns the violations of p by x, in the order of the Policy
+// fields, or nil if x satisfies p.
+func (p *Policy) Check(x *Exif) []Violation {
+	return p.check(x, time.Now())
+}
+
+func (p *Policy) check(x *Exif, now time.Time) []Violation {
+	var vs []Violation
+	for _, name := range p.Require {
+		if _, err := x.Get(name); err != nil {
+			vs = append(vs, Violation{RuleRequire, name, "missing required field"})
+		}
+	}
+	for _, name := range p.Forbid {
+		if _, err := x.Get(name); err == nil {
+			vs = append(vs, Violation{RuleForbid, name, "has forbidden field"})
+		}
+	}
+	if p.RequireCopyright && x.Rights().Copyright == "" {
+		vs = append(vs, Violation{RuleCopyright, Copyright, "no copyright notice"})
+	}
+	if p.ForbidGPS {
+		for _, info := range GPSFields() {
+			if _, err := x.Get(info.Name); err == nil {
+				vs = append(vs, Violation{RuleGPS, info.Name, "has GPS data"})
+				break
+			}
+		}
+	}
+	if p.MaxAgeDays > 0 {
+		if t, err := x.DateTime(); err != nil {
+			vs = append(vs, Violation{RuleMaxAge, DateTime, "no capture time"})
+		} else if age := now.Sub(t); age > time.Duration(p.MaxAgeDays)*24*time.Hour {
+			vs = append(vs, Violation{RuleMaxAge, DateTime, fmt.Sprintf("taken %d days ago, more than %d", int(age.Hours()/24), p.MaxAgeDays)})
+		}
+	}
+	if p.MaxThumbnailSize > 0 {
+		if thumb, err := x.JpegThumbnail(); err == nil && len(thumb) > p.MaxThumbnailSize {
+			vs = append(vs, Violation{RuleMaxThumbnail, ThumbJPEGInterchangeFormatLength, fmt.Sprintf("thumbnail of %d bytes, more than %d", len(thumb), p.MaxThumbnailSize)})
+		}
+	}
+	return vs
+}
@@ -0,0 +1,58 @@
+package exif
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	f, err := os.Open("sample1.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ParsePolicy([]byte(`{
+		"require": ["Make", "Artist"],
+		"forbid": ["Model"],
+		"requireCopyright": true,
+		"forbidGPS": true,
+		"maxAgeDays": 30,
+		"maxThumbnailSize": 1000
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2003, 12, 31, 0, 0, 0, 0, time.UTC)
+	got := p.check(x, now)
+	want := []Violation{
+		{RuleRequire, Artist, "missing required field"},
+		{RuleForbid, Model, "has forbidden field"},
+		{RuleCopyright, Copyright, "no copyright notice"},
+		{RuleGPS, GPSVersionID, "has GPS data"},
+		{RuleMaxAge, DateTime, "taken 37 days ago, more than 30"},
+		{RuleMaxThumbnail, ThumbJPEGInterchangeFormatLength, "thumbnail of 4034 bytes, more than 1000"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got violations:\n%v\nwant:\n%v", got, want)
+	}
+
+	if vs := (&Policy{}).Check(x); vs != nil {
+		t.Errorf("zero Policy: got %v", vs)
+	}
+	if vs := (&Policy{MaxAgeDays: 1}).Check(&Exif{}); len(vs) != 1 || vs[0].Msg != "no capture time" {
+		t.Errorf("no DateTime: got %v", vs)
+	}
+}
+
+func TestParsePolicyUnknownKey(t *testing.T) {
+	if _, err := ParsePolicy([]byte(`{"forbidLocation": true}`)); err == nil {
+		t.Error("misspelled rule accepted")
+	}
+}
@@ -0,0 +1,47 @@
+package exif
+
+import "io"
+
+// Stage is a stage of decoding reported to Decoder.Progress.
+type Stage string
+
+const (
+	// StageRead is reported as input is read.
+	StageRead Stage = "read"
+	// StageParse is reported before each registered parser is run.
+	StageParse Stage = "parse"
+)
+
+// progressReader reports the bytes read from r to fn, failing once fn
+// returns an error.
+type progressReader struct {
+	r   io.Reader
+	fn  func(Stage, int64) error
+	n   int64
+	err error
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	if pr.err != nil {
+		return 0, pr.err
+	}
+	n, err := pr.r.Read(p)
+	pr.n += int64(n)
+	if n > 0 {
+		if perr := pr.report(StageRead); perr != nil {
+			return n, perr
+		}
+	}
+	return n, err
+}
+
+// report calls the progress function for stage.  pr may be nil.
+func (pr *progressReader) report(stage Stage) error {
+	if pr == nil {
+		return nil
+	}
+	if pr.err == nil {
+		pr.err = pr.fn(stage, pr.n)
+	}
+	return pr.err
+}
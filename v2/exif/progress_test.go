@@ -0,0 +1,47 @@
+package exif
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	name := filepath.Join(*dataDir, "sample1.jpg")
+	fi, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last int64
+	var parses int
+	d := &Decoder{Progress: func(stage Stage, n int64) error {
+		if n < last || n > fi.Size() {
+			t.Errorf("%v: %d bytes read after %d", stage, n, last)
+		}
+		last = n
+		if stage == StageParse {
+			parses++
+		}
+		return nil
+	}}
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := d.Decode(f); err != nil {
+		t.Fatal(err)
+	}
+	if last == 0 || parses != len(parsers) {
+		t.Errorf("read %d bytes, %d parse stages", last, parses)
+	}
+
+	cancel := errors.New("canceled")
+	d.Progress = func(stage Stage, n int64) error { return cancel }
+	f.Seek(0, 0)
+	if x, err := d.Decode(f); x != nil || err != cancel {
+		t.Errorf("canceled decode returned %v, %v", x, err)
+	}
+}
@@ -0,0 +1,141 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Photoshop (PSD/PSB) files store metadata in the image resources section,
+// which follows the 26 byte file header and the color mode data section.
+// It is a sequence of big endian resource blocks:
+//
+//    "8BIM", id(2), Pascal name (padded to even length), size(4), data
+//
+// with the data padded to even length.  EXIF data is a TIFF structure in
+// resource 1058 and XMP is a raw packet in resource 1060.
+
+// Photoshop image resource IDs.
+const (
+	psdResourceExif = 1058
+	psdResourceXMP  = 1060
+)
+
+// tiffXMPTag is the TIFF tag holding an XMP packet.
+const tiffXMPTag = 700
+
+// isPSD reports whether header (the first 4 bytes of a file) is the start
+// of a Photoshop file.
+func isPSD(header []byte) bool {
+	return len(header) >= 4 && string(header[:4]) == "8BPS"
+}
+
+// psd holds the metadata resources of a Photoshop file.
+type psd struct {
+	exif, xmp []byte
+}
+
+// decodePSD reads the image resources section of the Photoshop file r.
+// Image data following it is not read.
+func decodePSD(r io.Reader) (*psd, error) {
+	var hdr [26]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	if !isPSD(hdr[:]) {
+		return nil, errors.New("exif: not a Photoshop file")
+	}
+
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(ioutil.Discard, r, int64(size)); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	res, err := readN(r, int64(size))
+	if err != nil {
+		return nil, err
+	}
+	return parsePSDResources(res)
+}
+
+// parsePSDResources extracts the EXIF and XMP resources from an image
+// resources section.
+func parsePSDResources(data []byte) (*psd, error) {
+	p := &psd{}
+	for len(data) > 0 {
+		if len(data) < 7 || string(data[:4]) != "8BIM" {
+			return p, errors.New("exif: invalid Photoshop image resource block")
+		}
+		id := binary.BigEndian.Uint16(data[4:])
+		nameLen := int(data[6]) + 1
+		nameLen += nameLen % 2
+		if 6+nameLen+4 > len(data) {
+			return p, errors.New("exif: short Photoshop image resource block")
+		}
+		data = data[6+nameLen:]
+		size := uint64(binary.BigEndian.Uint32(data))
+		data = data[4:]
+		if size > uint64(len(data)) {
+			return p, fmt.Errorf("exif: Photoshop image resource %d overruns its section", id)
+		}
+		switch id {
+		case psdResourceExif:
+			p.exif = data[:size]
+		case psdResourceXMP:
+			p.xmp = data[:size]
+		}
+		size += size % 2
+		if size > uint64(len(data)) {
+			size = uint64(len(data))
+		}
+		data = data[size:]
+	}
+	return p, nil
+}
+
+// loadPSD builds an Exif from the metadata resources of a Photoshop file.
+func loadPSD(p *psd) (*Exif, error) {
+	if p.exif == nil && p.xmp == nil {
+		return nil, errors.New("exif: no EXIF or XMP data in Photoshop file")
+	}
+	x := &Exif{
+		Tiff: &tiff.Tiff{Order: binary.BigEndian, Dirs: []*tiff.Dir{{}}},
+		xmp:  p.xmp,
+	}
+	if p.exif != nil {
+		tif, err := tiff.Decode(bytes.NewReader(p.exif))
+		if err != nil {
+			return nil, err
+		}
+		x.Tiff, x.Raw = tif, p.exif
+	}
+	return x, nil
+}
+
+// XMP returns the raw XMP packet stored alongside the EXIF data, if any.
+// It is available for JPEG files (APP1 segment), Photoshop files (image
+// resource 1060) and TIFF files (tag 700).
+func (x *Exif) XMP() []byte {
+	if x.xmp != nil {
+		return x.xmp
+	}
+	if x.Tiff == nil || len(x.Tiff.Dirs) == 0 {
+		return nil
+	}
+	for _, tag := range x.Tiff.Dirs[0].Tags {
+		if tag.Id == tiffXMPTag {
+			return tag.Val
+		}
+	}
+	return nil
+}
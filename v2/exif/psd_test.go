@@ -0,0 +1,105 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"testing"
+)
+
+func psdResource(id uint16, name string, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("8BIM")
+	binary.Write(&b, binary.BigEndian, id)
+	b.WriteByte(byte(len(name)))
+	b.WriteString(name)
+	if len(name)%2 == 0 {
+		b.WriteByte(0)
+	}
+	binary.Write(&b, binary.BigEndian, uint32(len(data)))
+	b.Write(data)
+	if len(data)%2 == 1 {
+		b.WriteByte(0)
+	}
+	return b.Bytes()
+}
+
+func buildPSD(resources ...[]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("8BPS\x00\x01")
+	b.Write(make([]byte, 20))
+	binary.Write(&b, binary.BigEndian, uint32(3)) // color mode data
+	b.Write([]byte{1, 2, 3})
+	res := bytes.Join(resources, nil)
+	binary.Write(&b, binary.BigEndian, uint32(len(res)))
+	b.Write(res)
+	b.Write(make([]byte, 64)) // layers and image data
+	return b.Bytes()
+}
+
+func TestDecodePSD(t *testing.T) {
+	xmp := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"/>`)
+	file := buildPSD(
+		psdResource(1005, "", make([]byte, 16)),
+		psdResource(psdResourceExif, "odd", buildTIFF(asciiEntry(0x010F, "Canon"), asciiEntry(0x0131, "Adobe Photoshop"))),
+		psdResource(psdResourceXMP, "", xmp),
+	)
+	x, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[FieldName]string{Make: "Canon", Software: "Adobe Photoshop"} {
+		tag, err := x.Get(name)
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+		} else if got, _ := tag.StringVal(); got != want {
+			t.Errorf("%v = %q, want %q", name, got, want)
+		}
+	}
+	if got := x.XMP(); !bytes.Equal(got, xmp) {
+		t.Errorf("XMP = %q, want %q", got, xmp)
+	}
+
+	// XMP only
+	x, err = Decode(bytes.NewReader(buildPSD(psdResource(psdResourceXMP, "", xmp))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := x.XMP(); !bytes.Equal(got, xmp) {
+		t.Errorf("XMP = %q, want %q", got, xmp)
+	}
+
+	if _, err := Decode(bytes.NewReader(buildPSD(psdResource(1005, "", nil)))); err == nil {
+		t.Errorf("PSD without metadata: got no error")
+	}
+}
+
+func TestTIFFXMP(t *testing.T) {
+	xmp := "<x:xmpmeta/>"
+	data := buildTIFF(asciiEntry(0x010F, "Nikon"), testEntry{tiffXMPTag, 1, uint32(len(xmp)), []byte(xmp)})
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(x.XMP()); got != xmp {
+		t.Errorf("XMP = %q, want %q", got, xmp)
+	}
+}
+
+func TestDecodePSDHugeResources(t *testing.T) {
+	var b bytes.Buffer
+	b.WriteString("8BPS\x00\x01")
+	b.Write(make([]byte, 20))
+	binary.Write(&b, binary.BigEndian, uint32(0)) // color mode data
+	binary.Write(&b, binary.BigEndian, uint32(0xFFFFFFF0))
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := Decode(bytes.NewReader(b.Bytes())); err == nil {
+		t.Error("truncated file decoded")
+	}
+	runtime.ReadMemStats(&after)
+	if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+		t.Errorf("decoding allocated %d bytes", n)
+	}
+}
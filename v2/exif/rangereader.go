@@ -0,0 +1,327 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// DefaultWindow is the default size of the blocks fetched by a RangeReader.
+const DefaultWindow = 16 << 10
+
+// RangeFunc reads len(p) bytes at offset off of remote data, as
+// io.ReaderAt.ReadAt does, e.g. with an HTTP range request or an S3
+// GetObject call with a Range.
+type RangeFunc func(p []byte, off int64) (n int, err error)
+
+// RangeStats counts the fetches made by a RangeReader.
+type RangeStats struct {
+	Fetches int
+	Bytes   int64
+}
+
+// RangeReader is an io.ReaderAt over remote data that fetches it in
+// aligned blocks of Window bytes and caches them, so that the small
+// scattered reads of decoding metadata cost few round trips.  Reads
+// spanning several missing blocks are coalesced into a single fetch.  It is
+// safe for concurrent use.  Cached blocks are kept until the RangeReader is
+// discarded.
+type RangeReader struct {
+	// Window is the size of the blocks fetched; zero means DefaultWindow.
+	// It must not be changed after the first read.
+	Window int
+
+	fetch RangeFunc
+	size  int64
+
+	mu     sync.Mutex
+	blocks map[int64][]byte // by block index
+	stats  RangeStats
+}
+
+// NewRangeReader returns a RangeReader over the size bytes read by fetch.
+func NewRangeReader(fetch RangeFunc, size int64) *RangeReader {
+	return &RangeReader{fetch: fetch, size: size, blocks: map[int64][]byte{}}
+}
+
+// NewHTTPRangeReader returns a RangeReader fetching the resource at url with
+// HTTP range requests made with client, or http.DefaultClient if nil.  The
+// size of the resource is taken from the response to the request of the
+// first block, which is kept if complete.  It fails if the server does not honor range
+// requests.
+func NewHTTPRangeReader(client *http.Client, url string) (*RangeReader, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	fetch := func(p []byte, off int64) (int, error) {
+		resp, err := httpRange(client, url, off, len(p))
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return io.ReadFull(resp.Body, p)
+	}
+
+	rr := NewRangeReader(fetch, 0)
+	resp, err := httpRange(client, url, 0, rr.window())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Content-Range: bytes 0-16383/123456
+	cr := resp.Header.Get("Content-Range")
+	i := strings.LastIndexByte(cr, '/')
+	if i < 0 {
+		return nil, fmt.Errorf("exif: invalid Content-Range %q from %v", cr, url)
+	}
+	if rr.size, err = strconv.ParseInt(cr[i+1:], 10, 64); err != nil {
+		return nil, fmt.Errorf("exif: size of %v unknown (Content-Range %q)", url, cr)
+	}
+	block, err := ioutil.ReadAll(io.LimitReader(resp.Body, int64(rr.window())))
+	if err != nil {
+		return nil, err
+	}
+	rr.stats = RangeStats{1, int64(len(block))}
+	want := int64(rr.window())
+	if rr.size < want {
+		want = rr.size
+	}
+	// A short first response is dropped, to be fetched again when read,
+	// rather than kept as a block missing its end.
+	if int64(len(block)) == want {
+		rr.blocks[0] = block
+	}
+	return rr, nil
+}
+
+// httpRange requests n bytes at off of url.
+func httpRange(client *http.Client, url string, off int64, n int) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(n)-1))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return nil, fmt.Errorf("exif: %v does not support range requests", url)
+		}
+		return nil, fmt.Errorf("exif: fetching %v: %v", url, resp.Status)
+	}
+	return resp, nil
+}
+
+func (r *RangeReader) window() int {
+	if r.Window <= 0 {
+		return DefaultWindow
+	}
+	return r.Window
+}
+
+// Size returns the size of the remote data.
+func (r *RangeReader) Size() int64 {
+	return r.size
+}
+
+// Stats returns the number of fetches made and bytes fetched so far.
+func (r *RangeReader) Stats() RangeStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *RangeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("exif: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+	w := int64(r.window())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	first, last := off/w, (end-1)/w
+	for b := first; b <= last; b++ {
+		if r.blocks[b] != nil {
+			continue
+		}
+		// Coalesce the run of missing blocks starting at b.
+		run := b
+		for run < last && r.blocks[run+1] == nil {
+			run++
+		}
+		start, stop := b*w, (run+1)*w
+		if stop > r.size {
+			stop = r.size
+		}
+		buf := make([]byte, stop-start)
+		n, err := r.fetch(buf, start)
+		r.stats.Fetches++
+		r.stats.Bytes += int64(n)
+		if n < len(buf) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		for i := b; i <= run; i++ {
+			lo := (i - b) * w
+			hi := lo + w
+			if hi > int64(len(buf)) {
+				hi = int64(len(buf))
+			}
+			r.blocks[i] = buf[lo:hi:hi]
+		}
+		b = run
+	}
+
+	n := 0
+	for b := first; b <= last; b++ {
+		block := r.blocks[b]
+		lo := int64(0)
+		if b == first {
+			lo = off - b*w
+		}
+		n += copy(p[n:], block[lo:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// tiffSlack is read past the metadata of TIFF files by DecodeAt, covering
+// makernote values stored past the makernote itself.
+const tiffSlack = 16 << 10
+
+// DecodeAt decodes the EXIF data of the size bytes in r, which may be
+// remote (see RangeReader).  For TIFF based files (TIFF, DNG and most raw
+// formats) only the IFDs, the values they reference and the JPEG thumbnail
+// are read, rather than the whole file as by Decode, so that decoding a
+// large raw file reads tens of kilobytes; the resulting x.Raw holds this
+// prefix of the file.  Other files are decoded as by Decode, reading only
+// as far as their format requires.
+func (d *Decoder) DecodeAt(r io.ReaderAt, size int64) (*Exif, error) {
+	head := make([]byte, 4)
+	if _, err := r.ReadAt(head, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if string(head) != "II*\x00" && string(head) != "MM\x00*" {
+		return d.Decode(io.NewSectionReader(r, 0, size))
+	}
+
+	end, err := metadataExtent(io.NewSectionReader(r, 0, size), d.SubIFDLimits)
+	if err != nil {
+		return nil, decodeError{cause: err}
+	}
+	if end += tiffSlack; end > size {
+		end = size
+	}
+	raw := make([]byte, end)
+	if _, err := r.ReadAt(raw, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return d.Decode(bytes.NewReader(raw))
+}
+
+// metadataExtent returns the offset past the last byte of the IFDs of the TIFF
+// structure in r, including the Exif, GPS, Interoperability and SubIFDs
+// sub-IFDs, of the values of their tags and of the JPEG thumbnail.
+func metadataExtent(r *io.SectionReader, lim tiff.SubIFDLimits) (int64, error) {
+	var hdr [8]byte
+	if _, err := r.ReadAt(hdr[:], 0); err != nil {
+		return 0, err
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if hdr[0] == 'M' {
+		order = binary.BigEndian
+	}
+
+	var end int64 = 8
+	extend := func(e int64) {
+		if e > end {
+			end = e
+		}
+	}
+	seen := map[int64]bool{}
+	// walk walks the IFD at off and, if chain is true, the IFDs linked
+	// from it.
+	var walk func(off int64, chain bool) error
+	walk = func(off int64, chain bool) error {
+		for off != 0 && !seen[off] {
+			seen[off] = true
+			if _, err := r.Seek(off, io.SeekStart); err != nil {
+				return err
+			}
+			d, next, err := tiff.DecodeDir(r, order)
+			if err != nil {
+				return err
+			}
+			extend(off + 2 + 12*int64(len(d.Tags)) + 4)
+			var thumb, thumbLen int64
+			for _, t := range d.Tags {
+				if len(t.Val) > 4 {
+					extend(int64(t.ValOffset) + int64(len(t.Val)))
+				}
+				switch t.Id {
+				case ExifIFDPointerID, GPSIFDPointerID, InteropIFDPointerID:
+					if p, err := t.Int64(0); err == nil {
+						if err := walk(p, false); err != nil {
+							return err
+						}
+					}
+				case 0x0201:
+					thumb, _ = t.Int64(0)
+				case 0x0202:
+					thumbLen, _ = t.Int64(0)
+				}
+			}
+			if thumb > 0 && thumbLen > 0 {
+				extend(thumb + thumbLen)
+			}
+			subs, err := tiff.DecodeSubIFDs(r, order, d, lim)
+			if err != nil {
+				return err
+			}
+			var walkSubs func(subs []*tiff.SubDir)
+			walkSubs = func(subs []*tiff.SubDir) {
+				for _, s := range subs {
+					extend(int64(s.Offset) + 2 + 12*int64(len(s.Tags)) + 4)
+					for _, t := range s.Tags {
+						if len(t.Val) > 4 {
+							extend(int64(t.ValOffset) + int64(len(t.Val)))
+						}
+					}
+					walkSubs(s.Subs)
+				}
+			}
+			walkSubs(subs)
+			if !chain {
+				break
+			}
+			off = int64(next)
+		}
+		return nil
+	}
+	err := walk(int64(order.Uint32(hdr[4:])), true)
+	return end, err
+}
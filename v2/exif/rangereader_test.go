@@ -0,0 +1,117 @@
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRangeReader(t *testing.T) {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	var fetches [][2]int64
+	r := NewRangeReader(func(p []byte, off int64) (int, error) {
+		fetches = append(fetches, [2]int64{off, int64(len(p))})
+		return copy(p, data[off:]), nil
+	}, int64(len(data)))
+	r.Window = 16
+
+	p := make([]byte, 40)
+	if n, err := r.ReadAt(p, 10); n != 40 || err != nil || !bytes.Equal(p, data[10:50]) {
+		t.Fatalf("ReadAt = %d, %v: %v", n, err, p)
+	}
+	if n, err := r.ReadAt(p, 70); n != 30 || err != io.EOF || !bytes.Equal(p[:n], data[70:]) {
+		t.Fatalf("ReadAt at end = %d, %v: %v", n, err, p[:n])
+	}
+	r.ReadAt(p[:4], 20)
+	// One coalesced fetch of blocks 0-3, one of blocks 4-6 and none for the
+	// cached block.
+	want := [][2]int64{{0, 64}, {64, 36}}
+	if len(fetches) != len(want) || fetches[0] != want[0] || fetches[1] != want[1] {
+		t.Errorf("fetches = %v, want %v", fetches, want)
+	}
+	if st := r.Stats(); st.Fetches != 2 || st.Bytes != 100 {
+		t.Errorf("Stats() = %+v", st)
+	}
+}
+
+func TestDecodeAtHTTP(t *testing.T) {
+	for _, name := range []string{"le_basic.tif", "le_thumbnail.jpg"} {
+		img, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The image followed by 8 MB of image data.
+		file := append(img, make([]byte, 8<<20)...)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.ServeContent(w, req, name, time.Time{}, bytes.NewReader(file))
+		}))
+		defer srv.Close()
+
+		r, err := NewHTTPRangeReader(nil, srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Size() != int64(len(file)) {
+			t.Errorf("%v: Size() = %d, want %d", name, r.Size(), len(file))
+		}
+		x, err := (&Decoder{}).DecodeAt(r, r.Size())
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if s, _ := x.stringVal(Model); s != "Synth 1" {
+			t.Errorf("%v: Model = %q", name, s)
+		}
+		if st := r.Stats(); st.Bytes > 64<<10 {
+			t.Errorf("%v: fetched %d bytes in %d requests", name, st.Bytes, st.Fetches)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("no ranges"))
+	}))
+	defer srv.Close()
+	if _, err := NewHTTPRangeReader(nil, srv.URL); err == nil {
+		t.Errorf("NewHTTPRangeReader accepted a server without range support")
+	}
+}
+
+func TestHTTPRangeReaderShortFirstBlock(t *testing.T) {
+	file := make([]byte, 64<<10)
+	for i := range file {
+		file[i] = byte(i / 7)
+	}
+	first := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if first {
+			// Claim the whole first block but send only 100 bytes.
+			first = false
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", DefaultWindow-1, len(file)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(file[:100])
+			return
+		}
+		http.ServeContent(w, req, "file", time.Time{}, bytes.NewReader(file))
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPRangeReader(nil, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := make([]byte, 10)
+	if _, err := r.ReadAt(p, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, file[1000:1010]) {
+		t.Errorf("ReadAt = %v, want %v", p, file[1000:1010])
+	}
+}
@@ -0,0 +1,111 @@
+package exif
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// RationalFormat selects how an Exif renders rational values in
+// MarshalJSON and Format.
+type RationalFormat struct {
+	Mode      tiff.RatMode
+	Precision int // digits after the decimal point for tiff.RatsDecimal
+
+	// Units renders the rationals of ExposureTime, FNumber, FocalLength and
+	// ExposureBiasValue in photographic notation, e.g. "1/250s", "f/2.8",
+	// "50mm" and "+0.7EV", in place of Mode.
+	Units bool
+}
+
+// unitFormats render the single rational value of fields with
+// photographic notations.
+var unitFormats = map[FieldName]func(v float64) string{
+	ExposureTime: func(v float64) string {
+		if v > 0 && v < 1 {
+			if n := math.Round(1 / v); math.Abs(1/v-n) < 1e-6*n {
+				return "1/" + strconv.FormatFloat(n, 'f', -1, 64) + "s"
+			}
+		}
+		return formatTenths(v) + "s"
+	},
+	FNumber: func(v float64) string {
+		return "f/" + formatTenths(v)
+	},
+	FocalLength: func(v float64) string {
+		return formatTenths(v) + "mm"
+	},
+	ExposureBiasValue: func(v float64) string {
+		s := formatTenths(v)
+		if s != "0" && v > 0 {
+			s = "+" + s
+		}
+		return s + "EV"
+	},
+}
+
+// formatTenths formats v rounded to one decimal place, dropping a zero
+// fraction.
+func formatTenths(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+// SetRationals selects how MarshalJSON and Format render rational values.
+// By default they are rendered as stored, e.g. "10/2500".
+func (x *Exif) SetRationals(f RationalFormat) {
+	x.rationals = f
+}
+
+// jsonOptions returns the options x renders tags with.
+func (x *Exif) jsonOptions() tiff.JSONOptions {
+	return tiff.JSONOptions{
+		Strings:   x.jsonStrings,
+		Rats:      x.rationals.Mode,
+		Precision: x.rationals.Precision,
+	}
+}
+
+// marshalField returns the JSON rendering of the field name holding tag.
+func (x *Exif) marshalField(name FieldName, tag *tiff.Tag) ([]byte, error) {
+	if s, ok := x.unitString(name, tag); ok {
+		return json.Marshal(s)
+	}
+	return tag.MarshalJSONOptions(x.jsonOptions())
+}
+
+// unitString renders tag in photographic notation, if x.rationals.Units is
+// set and name has one.
+func (x *Exif) unitString(name FieldName, tag *tiff.Tag) (string, bool) {
+	format := unitFormats[name]
+	if !x.rationals.Units || format == nil || tag.Format() != tiff.RatVal || tag.Count != 1 {
+		return "", false
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil || den == 0 {
+		return "", false
+	}
+	return format(ratFloat(num, den)), true
+}
+
+// Format returns the value of the field name rendered like tiff.Tag.String,
+// honoring the string and rational formats selected for x.
+func (x *Exif) Format(name FieldName) (string, error) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := x.unitString(name, tag); ok {
+		return s, nil
+	}
+	data, err := tag.MarshalJSONOptions(x.jsonOptions())
+	if err != nil {
+		return "", err
+	}
+	s := string(data)
+	if tag.Count == 1 && len(s) > 1 && s[0] == '[' && s[len(s)-1] == ']' {
+		s = s[1 : len(s)-1]
+	}
+	return s, nil
+}
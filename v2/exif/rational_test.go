@@ -0,0 +1,60 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func testRational(t *testing.T, num, den uint32) *tiff.Tag {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, num)
+	binary.Write(&buf, binary.BigEndian, den)
+	return testTag(t, tiff.DTRational, 1, buf.Bytes())
+}
+
+func TestRationalFormat(t *testing.T) {
+	x := &Exif{}
+	x.setTag(ExposureTime, testRational(t, 10, 2500))
+	x.setTag(FNumber, testRational(t, 28, 10))
+	x.setTag(FocalLength, testRational(t, 500, 10))
+	x.setTag(ExposureBiasValue, testSRational(t, 2, 3))
+	x.setTag(XResolution, testRational(t, 144, 2))
+
+	tests := []struct {
+		f    RationalFormat
+		name FieldName
+		want string
+	}{
+		{RationalFormat{}, ExposureTime, `"10/2500"`},
+		{RationalFormat{Mode: tiff.RatsSimplified}, ExposureTime, `"1/250"`},
+		{RationalFormat{Mode: tiff.RatsSimplified}, XResolution, `"72"`},
+		{RationalFormat{Mode: tiff.RatsDecimal, Precision: 3}, ExposureBiasValue, `0.667`},
+		{RationalFormat{Units: true}, ExposureTime, `1/250s`},
+		{RationalFormat{Units: true}, FNumber, `f/2.8`},
+		{RationalFormat{Units: true}, FocalLength, `50mm`},
+		{RationalFormat{Units: true}, ExposureBiasValue, `+0.7EV`},
+		{RationalFormat{Units: true}, XResolution, `"144/2"`},
+	}
+	for _, tt := range tests {
+		x.SetRationals(tt.f)
+		got, err := x.Format(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%v with %+v: got %s, want %s", tt.name, tt.f, got, tt.want)
+		}
+	}
+
+	x.SetRationals(RationalFormat{Units: true})
+	b, err := x.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"FNumber":"f/2.8"`)) {
+		t.Errorf("MarshalJSON = %s", b)
+	}
+}
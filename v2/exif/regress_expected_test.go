@@ -1299,7 +1299,7 @@ var regressExpected = map[string]map[FieldName]string{
 		InteroperabilityIndex:            `"R98"`,
 		LightSource:                      `0`,
 		Make:                             `"FUJIFILM"`,
-		MakerNote:                        `"FUJIFILM0130" !"#,012NORMAL d"`,
+		MakerNote:                        `"FUJIFILM0130\" !\"#,012NORMAL d"`,
 		MaxApertureValue:                 `"300/100"`,
 		MeteringMode:                     `5`,
 		Model:                            `"FinePix E550   "`,
@@ -1630,6 +1630,7 @@ var regressExpected = map[string]map[FieldName]string{
 		FocalPlaneYResolution:            `"3744000/958"`,
 		GPSInfoIFDPointer:                `1152`,
 		GPSVersionID:                     `[2,2,0,0]`,
+		Gamma:                            `"22/10"`,
 		ISOSpeedRatings:                  `800`,
 		InteroperabilityIFDPointer:       `1120`,
 		InteroperabilityIndex:            `"R03"`,
@@ -1639,6 +1640,7 @@ var regressExpected = map[string]map[FieldName]string{
 		Orientation:                      `1`,
 		PixelXDimension:                  `576`,
 		PixelYDimension:                  `864`,
+		PrimaryChromaticities:            `["64/100","33/100","21/100","71/100","15/100","6/100"]`,
 		ResolutionUnit:                   `2`,
 		SceneCaptureType:                 `0`,
 		ShutterSpeedValue:                `"393216/65536"`,
@@ -1650,6 +1652,7 @@ var regressExpected = map[string]map[FieldName]string{
 		ThumbJPEGInterchangeFormatLength: `6186`,
 		UserComment:                      `""`,
 		WhiteBalance:                     `1`,
+		WhitePoint:                       `["313/1000","329/1000"]`,
 		XResolution:                      `"720000/10000"`,
 		YCbCrPositioning:                 `2`,
 		YResolution:                      `"720000/10000"`,
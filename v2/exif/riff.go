@@ -0,0 +1,208 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Older digital cameras record movies as AVI (RIFF) files and store a few
+// EXIF values in a "LIST" chunk of type "exif", usually inside the "hdrl"
+// header list.  Each value is a sub-chunk:
+//
+//    ecor - Make
+//    emdl - Model
+//    emnt - MakerNote
+//    etim - DateTimeOriginal
+//    eucm - UserComment
+//    ever - ExifVersion
+//
+// Many also record the creation time in an "IDIT" chunk.  RIFF chunk sizes
+// are little endian and chunks are padded to an even length.
+
+// riffExifChunks maps the sub-chunks of the exif list to their fields.
+var riffExifChunks = map[string]struct {
+	name  FieldName
+	group string
+	id    uint16
+	typ   tiff.DataType
+}{
+	"ecor": {Make, GroupIFD0, 0x010F, tiff.DTAscii},
+	"emdl": {Model, GroupIFD0, 0x0110, tiff.DTAscii},
+	"emnt": {MakerNote, GroupExif, MakerNoteID, tiff.DTUndefined},
+	"eucm": {UserComment, GroupExif, 0x9286, tiff.DTUndefined},
+	"ever": {ExifVersion, GroupExif, 0x9000, tiff.DTUndefined},
+}
+
+// riffTimeLayouts are the date formats found in etim and IDIT chunks.
+var riffTimeLayouts = []string{
+	"2006:01:02 15:04:05",
+	"Mon Jan 02 15:04:05 2006",
+	"Mon Jan _2 15:04:05 2006",
+	"2006/01/02 15:04:05",
+}
+
+// isAVI reports whether header (the first 12 bytes of a file) is the start
+// of an AVI file.
+func isAVI(header []byte) bool {
+	return len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "AVI "
+}
+
+// riffChunk is a RIFF chunk; typ is the list type for LIST chunks.
+type riffChunk struct {
+	id, typ string
+	data    []byte
+}
+
+// avi holds the exif values collected from an AVI file.
+type avi struct {
+	chunks map[string][]byte
+	idit   string
+}
+
+// decodeAVI streams through the chunks of an AVI file, collecting the exif
+// list and IDIT chunk.  Reading stops at the movie data once they have been
+// found.
+func decodeAVI(r io.Reader) (*avi, error) {
+	var hdr [12]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	if !isAVI(hdr[:]) {
+		return nil, errors.New("exif: not an AVI file")
+	}
+
+	a := &avi{chunks: map[string][]byte{}}
+	for {
+		var ch [12]byte
+		if _, err := io.ReadFull(r, ch[:8]); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		id := string(ch[:4])
+		size := int64(binary.LittleEndian.Uint32(ch[4:]))
+		padded := size + size%2
+
+		if id == "LIST" && size >= 4 {
+			if _, err := io.ReadFull(r, ch[8:]); err != nil {
+				return nil, err
+			}
+			if typ := string(ch[8:]); typ == "movi" {
+				if len(a.chunks) > 0 || a.idit != "" {
+					break
+				}
+			} else if typ == "hdrl" || typ == "exif" {
+				body, err := readN(r, padded-4)
+				if err != nil {
+					return nil, err
+				}
+				a.collect(riffChunk{id, typ, body})
+				continue
+			}
+			padded -= 4
+		} else if id == "IDIT" {
+			body, err := readN(r, padded)
+			if err != nil {
+				return nil, err
+			}
+			a.idit = strings.TrimRight(string(body[:size]), "\x00\n ")
+			continue
+		}
+		if _, err := io.CopyN(ioutil.Discard, r, padded); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+	if len(a.chunks) == 0 && a.idit == "" {
+		return nil, errors.New("exif: no EXIF data found in AVI file")
+	}
+	return a, nil
+}
+
+// collect records the exif values in the LIST chunk c and its sub-lists.
+func (a *avi) collect(c riffChunk) {
+	for _, sub := range riffChunks(c.data) {
+		switch {
+		case sub.id == "LIST":
+			a.collect(sub)
+		case sub.id == "IDIT":
+			a.idit = strings.TrimRight(string(sub.data), "\x00\n ")
+		case c.typ == "exif":
+			a.chunks[sub.id] = sub.data
+		}
+	}
+}
+
+// riffChunks splits data into its chunks.
+func riffChunks(data []byte) []riffChunk {
+	var chunks []riffChunk
+	for len(data) >= 8 {
+		c := riffChunk{id: string(data[:4])}
+		size := uint64(binary.LittleEndian.Uint32(data[4:]))
+		data = data[8:]
+		if size > uint64(len(data)) {
+			break
+		}
+		c.data = data[:size]
+		if c.id == "LIST" && size >= 4 {
+			c.typ, c.data = string(c.data[:4]), c.data[4:]
+		}
+		chunks = append(chunks, c)
+		if size%2 == 1 && size < uint64(len(data)) {
+			size++
+		}
+		data = data[size:]
+	}
+	return chunks
+}
+
+// loadAVI builds an Exif from the values found in an AVI file.
+func loadAVI(a *avi) (*Exif, error) {
+	x := &Exif{
+		Tiff: &tiff.Tiff{Order: binary.BigEndian, Dirs: []*tiff.Dir{{}}},
+	}
+	defer x.setGroup("")
+	for id, data := range a.chunks {
+		f, ok := riffExifChunks[id]
+		if !ok {
+			continue
+		}
+		var tag *tiff.Tag
+		var err error
+		if f.typ == tiff.DTAscii {
+			tag, err = asciiTag(f.id, strings.TrimRight(string(data), "\x00"))
+		} else {
+			tag, err = tiff.NewTag(f.id, f.typ, binary.BigEndian, data)
+		}
+		if err != nil {
+			continue
+		}
+		x.setGroup(f.group)
+		x.setTag(f.name, tag)
+	}
+
+	date := a.idit
+	if etim, ok := a.chunks["etim"]; ok {
+		date = strings.TrimRight(string(etim), "\x00\n ")
+	}
+	for _, layout := range riffTimeLayouts {
+		t, err := time.Parse(layout, date)
+		if err != nil {
+			continue
+		}
+		if tag, err := asciiTag(0x9003, t.Format("2006:01:02 15:04:05")); err == nil {
+			x.setGroup(GroupExif)
+			x.setTag(DateTimeOriginal, tag)
+		}
+		break
+	}
+	return x, nil
+}
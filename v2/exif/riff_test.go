@@ -0,0 +1,94 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"testing"
+)
+
+func riffChunkBytes(id string, body ...[]byte) []byte {
+	var b bytes.Buffer
+	size := 0
+	for _, p := range body {
+		size += len(p)
+	}
+	b.WriteString(id)
+	binary.Write(&b, binary.LittleEndian, uint32(size))
+	for _, p := range body {
+		b.Write(p)
+	}
+	if size%2 == 1 {
+		b.WriteByte(0)
+	}
+	return b.Bytes()
+}
+
+func riffList(typ string, chunks ...[]byte) []byte {
+	return riffChunkBytes("LIST", append([][]byte{[]byte(typ)}, chunks...)...)
+}
+
+func TestDecodeAVI(t *testing.T) {
+	exifList := riffList("exif",
+		riffChunkBytes("ever", []byte("0220")),
+		riffChunkBytes("ecor", []byte("FUJIFILM\x00")),
+		riffChunkBytes("emdl", []byte("FinePix S602 ZOOM\x00")),
+		riffChunkBytes("etim", []byte("2003:09:07 11:28:03\x00")),
+	)
+	hdrl := riffList("hdrl", riffChunkBytes("avih", make([]byte, 56)), exifList)
+	movi := riffList("movi", riffChunkBytes("00dc", make([]byte, 1001)))
+	body := append(append([]byte("AVI "), hdrl...), movi...)
+	file := riffChunkBytes("RIFF", body)
+
+	x, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[FieldName]string{
+		Make:             `"FUJIFILM"`,
+		Model:            `"FinePix S602 ZOOM"`,
+		ExifVersion:      `"0220"`,
+		DateTimeOriginal: `"2003:09:07 11:28:03"`,
+	} {
+		tag, err := x.Get(name)
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+		} else if got := tag.String(); got != want {
+			t.Errorf("%v = %s, want %s", name, got, want)
+		}
+	}
+
+	// IDIT only, in the format used by many camcorders
+	hdrl = riffList("hdrl", riffChunkBytes("avih", make([]byte, 56)), riffChunkBytes("IDIT", []byte("SAT DEC 25 15:42:10 2004\n\x00")))
+	file = riffChunkBytes("RIFF", append(append([]byte("AVI "), hdrl...), movi...))
+	x, err = Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm, err := x.DateTime(); err != nil || tm.Format("2006-01-02 15:04:05") != "2004-12-25 15:42:10" {
+		t.Errorf("DateTime = %v, %v", tm, err)
+	}
+
+	file = riffChunkBytes("RIFF", append([]byte("AVI "), movi...))
+	if _, err := Decode(bytes.NewReader(file)); err == nil {
+		t.Errorf("AVI without EXIF: got no error")
+	}
+}
+
+func TestDecodeAVIHugeChunk(t *testing.T) {
+	for name, chunk := range map[string]string{
+		"LIST": "LIST\xF0\xFF\xFF\xFFexif",
+		"IDIT": "IDIT\xF0\xFF\xFF\xFF",
+	} {
+		file := append([]byte("RIFF\xF0\xFF\xFF\xFFAVI "), chunk...)
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		if _, err := Decode(bytes.NewReader(file)); err == nil {
+			t.Errorf("%s: truncated file decoded", name)
+		}
+		runtime.ReadMemStats(&after)
+		if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+			t.Errorf("%s: decoding allocated %d bytes", name, n)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package exif
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Rights holds the authorship and licensing information of an image, as
+// recorded in the EXIF fields and the XMP packet.
+type Rights struct {
+	// Creators are the names of the Artist field, or else of the XMP
+	// dc:creator property.
+	Creators []string
+	// Copyright is the photographer copyright notice, or else the editor
+	// copyright notice or the XMP dc:rights property.
+	Copyright string
+	// License is the URL of the license or rights statement, from the XMP
+	// xmpRights:WebStatement or cc:license property.
+	License string
+	// UsageTerms are the instructions on how the image can be used, from
+	// the XMP xmpRights:UsageTerms property.
+	UsageTerms string
+	// Credit is the credit line to publish with the image, from the XMP
+	// photoshop:Credit property.
+	Credit string
+}
+
+// Rights returns the authorship and licensing information of x.  Fields
+// absent from x are left empty.
+func (x *Exif) Rights() Rights {
+	var r Rights
+	r.Creators, _ = x.Artists()
+	if len(r.Creators) == 0 {
+		r.Creators = xmpList(x.xmp, "dc:creator")
+	}
+	if c, err := x.Copyright(); err == nil {
+		r.Copyright = c.Photographer
+		if r.Copyright == "" {
+			r.Copyright = c.Editor
+		}
+	}
+	if r.Copyright == "" {
+		r.Copyright = xmpText(x.xmp, "dc:rights")
+	}
+	r.License = xmpText(x.xmp, "xmpRights:WebStatement")
+	if r.License == "" {
+		r.License = xmpText(x.xmp, "cc:license")
+	}
+	r.UsageTerms = xmpText(x.xmp, "xmpRights:UsageTerms")
+	r.Credit = xmpText(x.xmp, "photoshop:Credit")
+	return r
+}
+
+type schemaPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// SchemaOrg returns r as a schema.org ImageObject in JSON-LD, for embedding
+// in a web page alongside the image.  The usage terms have no schema.org
+// property and are left out.
+func (r Rights) SchemaOrg() ([]byte, error) {
+	obj := struct {
+		Context   string         `json:"@context"`
+		Type      string         `json:"@type"`
+		Creator   []schemaPerson `json:"creator,omitempty"`
+		Copyright string         `json:"copyrightNotice,omitempty"`
+		License   string         `json:"license,omitempty"`
+		Credit    string         `json:"creditText,omitempty"`
+	}{
+		Context:   "https://schema.org",
+		Type:      "ImageObject",
+		Copyright: r.Copyright,
+		License:   r.License,
+		Credit:    r.Credit,
+	}
+	for _, name := range r.Creators {
+		obj.Creator = append(obj.Creator, schemaPerson{"Person", name})
+	}
+	return json.Marshal(obj)
+}
+
+// IPTC returns r in the JSON format of the IPTC Photo Metadata Standard,
+// the properties held in an "ipmd_top" object.
+func (r Rights) IPTC() ([]byte, error) {
+	type top struct {
+		Creators   []string `json:"creatorNames,omitempty"`
+		Copyright  string   `json:"copyrightNotice,omitempty"`
+		License    string   `json:"webstatementRights,omitempty"`
+		UsageTerms string   `json:"usageTerms,omitempty"`
+		Credit     string   `json:"creditLine,omitempty"`
+	}
+	return json.Marshal(struct {
+		Top top `json:"ipmd_top"`
+	}{top{r.Creators, r.Copyright, r.License, r.UsageTerms, r.Credit}})
+}
+
+// xmpText returns the value of the property name of the XMP packet, given
+// as a simple value or as the first item of an array or language
+// alternative.
+func xmpText(xmp []byte, name string) string {
+	if v := xmpValue(xmp, name); v != "" {
+		return v
+	}
+	if items := xmpList(xmp, name); len(items) > 0 {
+		return items[0]
+	}
+	return ""
+}
+
+// xmpList returns the rdf:li items of the array property name of the XMP
+// packet.
+func xmpList(xmp []byte, name string) []string {
+	s := string(xmp)
+	i := strings.Index(s, "<"+name+">")
+	if i < 0 {
+		return nil
+	}
+	s = s[i:]
+	if j := strings.Index(s, "</"+name+">"); j >= 0 {
+		s = s[:j]
+	}
+	var items []string
+	for {
+		i := strings.Index(s, "<rdf:li")
+		if i < 0 {
+			break
+		}
+		s = s[i:]
+		start := strings.Index(s, ">")
+		end := strings.Index(s, "</rdf:li>")
+		if start < 0 || end < start {
+			break
+		}
+		if v := strings.TrimSpace(s[start+1 : end]); v != "" {
+			items = append(items, v)
+		}
+		s = s[end:]
+	}
+	return items
+}
@@ -0,0 +1,64 @@
+package exif
+
+import (
+	"reflect"
+	"testing"
+)
+
+const rightsXMP = `<rdf:Description xmpRights:WebStatement="https://creativecommons.org/licenses/by/4.0/" photoshop:Credit="Doe Photo">
+<dc:creator><rdf:Seq><rdf:li>Jane Doe</rdf:li><rdf:li>John Roe</rdf:li></rdf:Seq></dc:creator>
+<dc:rights><rdf:Alt><rdf:li xml:lang="x-default">© 2024 Jane Doe</rdf:li></rdf:Alt></dc:rights>
+<xmpRights:UsageTerms><rdf:Alt><rdf:li xml:lang="x-default">Attribution required</rdf:li></rdf:Alt></xmpRights:UsageTerms>
+</rdf:Description>`
+
+func TestRights(t *testing.T) {
+	x := &Exif{xmp: []byte(rightsXMP)}
+	want := Rights{
+		Creators:   []string{"Jane Doe", "John Roe"},
+		Copyright:  "© 2024 Jane Doe",
+		License:    "https://creativecommons.org/licenses/by/4.0/",
+		UsageTerms: "Attribution required",
+		Credit:     "Doe Photo",
+	}
+	if got := x.Rights(); !reflect.DeepEqual(got, want) {
+		t.Errorf("XMP rights: got %+v, want %+v", got, want)
+	}
+
+	// The EXIF fields take precedence.
+	x.setTag(Artist, testString(t, "Studio X"))
+	x.setTag(Copyright, testString(t, " \x00Studio X\x00"))
+	want.Creators, want.Copyright = []string{"Studio X"}, "Studio X"
+	if got := x.Rights(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EXIF rights: got %+v, want %+v", got, want)
+	}
+
+	if got := (&Exif{}).Rights(); !reflect.DeepEqual(got, Rights{}) {
+		t.Errorf("no rights: got %+v", got)
+	}
+}
+
+func TestRightsExport(t *testing.T) {
+	r := Rights{
+		Creators:   []string{"Jane Doe"},
+		Copyright:  "© 2024 Jane Doe",
+		License:    "https://creativecommons.org/licenses/by/4.0/",
+		UsageTerms: "Attribution required",
+	}
+	got, err := r.SchemaOrg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"@context":"https://schema.org","@type":"ImageObject","creator":[{"@type":"Person","name":"Jane Doe"}],"copyrightNotice":"© 2024 Jane Doe","license":"https://creativecommons.org/licenses/by/4.0/"}`
+	if string(got) != want {
+		t.Errorf("SchemaOrg:\ngot  %s\nwant %s", got, want)
+	}
+
+	got, err = r.IPTC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"ipmd_top":{"creatorNames":["Jane Doe"],"copyrightNotice":"© 2024 Jane Doe","webstatementRights":"https://creativecommons.org/licenses/by/4.0/","usageTerms":"Attribution required"}}`
+	if string(got) != want {
+		t.Errorf("IPTC:\ngot  %s\nwant %s", got, want)
+	}
+}
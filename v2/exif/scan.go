@@ -0,0 +1,196 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// scanMaxSize is the largest EXIF structure Scan can recover.  Larger
+// structures are truncated, which usually loses their tail values only.
+const scanMaxSize = 1 << 20
+
+// scanMinFields is the number of known fields a candidate must yield to be
+// accepted by Scan.  Random data matching a signature rarely decodes to
+// more than one.
+const scanMinFields = 2
+
+// scanSignatures are the byte sequences Scan looks for.
+var scanSignatures = [][]byte{
+	[]byte("II*\x00"),
+	[]byte("MM\x00*"),
+	[]byte("Exif\x00\x00"),
+}
+
+// A Carved is an EXIF structure located in a larger stream by Scan or
+// DecodeAll.
+type Carved struct {
+	// Offset is the position of the TIFF header in the scanned stream.
+	Offset int64
+	// Length is the size of the TIFF structure, up to and including the
+	// last byte referenced by its IFDs.
+	Length int64
+	Exif   *Exif
+}
+
+// Scan searches r for TIFF headers and "Exif\0\0" signatures and attempts
+// to decode an EXIF structure at each candidate position, returning the
+// structures that pass basic sanity checks (at least a couple of known
+// fields).  It is meant for forensic recovery of metadata from damaged
+// files, disk images and memory dumps, where the container around the EXIF
+// data can not be relied upon.  The registered parsers are run on each
+// structure as by Decode.
+//
+// Scan reads r to the end.  Structures larger than 1 MiB are truncated.  A
+// read error other than io.EOF ends the scan and is returned along with the
+// structures found so far.
+func Scan(r io.Reader) ([]Carved, error) {
+	var found []Carved
+	buf := make([]byte, 0, 2*scanMaxSize)
+	var base int64 // stream offset of buf[0]
+	var next int   // index in buf at which to continue searching
+	eof := false
+	for {
+		for !eof && len(buf) < cap(buf) {
+			n, err := r.Read(buf[len(buf):cap(buf)])
+			buf = buf[:len(buf)+n]
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return found, err
+			}
+		}
+
+		// Only search where a whole structure fits in the buffer.
+		limit := len(buf)
+		if !eof {
+			limit -= scanMaxSize
+		}
+		for next < limit {
+			i := scanCandidate(buf[next:])
+			if i < 0 || next+i >= limit {
+				next = limit
+				break
+			}
+			start := next + i
+			if buf[start] == 'E' {
+				start += 6
+			}
+			end := start + scanMaxSize
+			if end > len(buf) {
+				end = len(buf)
+			}
+			if c, ok := carve(buf[start:end]); ok {
+				c.Offset = base + int64(start)
+				found = append(found, c)
+				next = start + int(c.Length)
+			} else {
+				next += i + 1
+			}
+		}
+
+		if eof {
+			return found, nil
+		}
+		// Drop the searched part of the buffer.
+		keep := next
+		if keep > len(buf) {
+			keep = len(buf)
+		}
+		n := copy(buf, buf[keep:])
+		buf = buf[:n]
+		base += int64(keep)
+		next -= keep
+	}
+}
+
+// scanCandidate returns the index of the first signature in b, or -1.
+func scanCandidate(b []byte) int {
+	first := -1
+	for _, sig := range scanSignatures {
+		i := bytes.Index(b, sig)
+		if i >= 0 && (first < 0 || i < first) {
+			first = i
+			b = b[:i+len(sig)]
+		}
+	}
+	return first
+}
+
+// carve attempts to decode the TIFF structure at the start of data.
+func carve(data []byte) (Carved, bool) {
+	n, err := tiffExtent(data)
+	if err != nil || n <= 8 {
+		return Carved{}, false
+	}
+	data = data[:n]
+	x, err := Decode(bytes.NewReader(data))
+	if x == nil || (err != nil && IsCriticalError(err)) || len(x.main) < scanMinFields {
+		return Carved{}, false
+	}
+	return Carved{Length: int64(n), Exif: x}, true
+}
+
+// DecodeAll decodes every EXIF structure in the JPEG or TIFF stream r:
+// besides the main image's, files may carry EXIF data for embedded previews
+// (e.g. MPF images appended after the main image) or for edited copies.  For
+// a TIFF stream the structure at the start of the file comes first.  Every
+// APP1 segment holding EXIF data is then decoded, wherever it is in r; the
+// segment's marker and length must be consistent for it to be considered.
+// Structures that fail to decode are skipped.
+//
+// Unlike Scan, DecodeAll only accepts EXIF data in its proper containers.
+// The registered parsers are run on each structure as by Decode.
+func DecodeAll(r io.Reader) ([]Carved, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Carved
+	if len(data) >= 4 && (string(data[:4]) == "II*\x00" || string(data[:4]) == "MM\x00*") {
+		n, err := tiffExtent(data)
+		if err == nil {
+			x, err := Decode(bytes.NewReader(data))
+			if x != nil && (err == nil || !IsCriticalError(err)) {
+				found = append(found, Carved{Length: int64(n), Exif: x})
+			}
+		}
+	}
+
+	sig := []byte("Exif\x00\x00")
+	for off := 0; ; {
+		i := bytes.Index(data[off:], sig)
+		if i < 0 {
+			break
+		}
+		start := off + i
+		off = start + 1
+		// APP1 marker and segment length precede the signature.
+		if start < 4 || data[start-4] != 0xFF || data[start-3] != jpeg_APP1 {
+			continue
+		}
+		segLen := int(binary.BigEndian.Uint16(data[start-2:])) - 2
+		if segLen < len(sig)+8 || start+segLen > len(data) {
+			continue
+		}
+		tif := data[start+len(sig) : start+segLen]
+		x, err := Decode(bytes.NewReader(tif))
+		if x == nil || (err != nil && IsCriticalError(err)) {
+			continue
+		}
+		found = append(found, Carved{
+			Offset: int64(start + len(sig)),
+			Length: int64(len(tif)),
+			Exif:   x,
+		})
+		off = start + segLen
+	}
+
+	if len(found) == 0 {
+		return nil, errors.New("exif: no EXIF data found")
+	}
+	return found, nil
+}
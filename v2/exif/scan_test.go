@@ -0,0 +1,93 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScan(t *testing.T) {
+	jpeg, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := buildTIFF(asciiEntry(0x010F, "Canon"), asciiEntry(0x0110, "Canon EOS 5D"))
+
+	var stream bytes.Buffer
+	stream.WriteString("junk II*\x00\x08\x00\x00\x00\xff\xff more junk MM\x00*")
+	jpegOff := stream.Len()
+	stream.Write(jpeg)
+	// push the next structure past the first buffer fill
+	stream.Write(make([]byte, 3*scanMaxSize))
+	rawOff := stream.Len()
+	stream.Write(raw)
+	stream.WriteString("II*")
+
+	found, err := Scan(iotest.HalfReader(bytes.NewReader(stream.Bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("found %d structures, want 2", len(found))
+	}
+
+	// The JPEG's EXIF structure starts after the "Exif\0\0" header.
+	if want := int64(jpegOff + bytes.Index(jpeg, []byte("Exif\x00\x00")) + 6); found[0].Offset != want {
+		t.Errorf("first structure at %d, want %d", found[0].Offset, want)
+	}
+	if _, err := found[0].Exif.Get(Model); err != nil {
+		t.Errorf("first structure: %v", err)
+	}
+	if found[1].Offset != int64(rawOff) || found[1].Length != int64(len(raw)) {
+		t.Errorf("second structure at %d+%d, want %d+%d", found[1].Offset, found[1].Length, rawOff, len(raw))
+	}
+	if tag, err := found[1].Exif.Get(Model); err != nil {
+		t.Errorf("second structure: %v", err)
+	} else if s, _ := tag.StringVal(); s != "Canon EOS 5D" {
+		t.Errorf("second structure Model = %q", s)
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	jpeg, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A minimal JPEG preview appended to the main image, as MPF does.
+	raw := buildTIFF(asciiEntry(0x010F, "Canon"), asciiEntry(0x0110, "Preview"))
+	app1 := append([]byte{0xFF, 0xD8, 0xFF, jpeg_APP1, 0, 0}, "Exif\x00\x00"...)
+	binary.BigEndian.PutUint16(app1[4:], uint16(2+6+len(raw)))
+	preview := append(append(app1, raw...), 0xFF, 0xD9)
+	file := append(append([]byte{}, jpeg...), preview...)
+
+	found, err := DecodeAll(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("found %d structures, want 2", len(found))
+	}
+	if tag, err := found[1].Exif.Get(Model); err != nil {
+		t.Error(err)
+	} else if s, _ := tag.StringVal(); s != "Preview" {
+		t.Errorf("preview Model = %q", s)
+	}
+	if want := int64(len(jpeg) + len(app1)); found[1].Offset != want || found[1].Length != int64(len(raw)) {
+		t.Errorf("preview at %d+%d, want %d+%d", found[1].Offset, found[1].Length, want, len(raw))
+	}
+	main, err := Decode(bytes.NewReader(jpeg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(found[0].Exif.Raw, main.Raw) {
+		t.Errorf("main structure differs from Decode")
+	}
+
+	if _, err := DecodeAll(bytes.NewReader([]byte("no exif here"))); err == nil {
+		t.Errorf("no EXIF data: got no error")
+	}
+}
@@ -0,0 +1,50 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Signer produces a signature over canonicalized metadata.  The
+// signature algorithm (and any hashing of data) is up to the
+// implementation, e.g. a wrapper around crypto/ed25519.
+type Signer interface {
+	Sign(data []byte) (sig []byte, err error)
+}
+
+// A Verifier checks a signature produced by the matching Signer, returning
+// a non-nil error if it is not valid for data.
+type Verifier interface {
+	Verify(data, sig []byte) error
+}
+
+// ErrNoSignature is returned by VerifyEmbedded if the signature field is
+// missing.
+var ErrNoSignature = errors.New("exif: no embedded signature")
+
+// Sign returns a detached signature over the canonical encoding of x (see
+// Canonical).  To embed the signature in the file itself, exclude the field
+// it will be stored in, which must then be passed to VerifyEmbedded.
+func (x *Exif) Sign(s Signer, exclude ...FieldName) ([]byte, error) {
+	return s.Sign(x.Canonical(exclude...))
+}
+
+// Verify checks the detached signature sig over the canonical encoding of
+// x, excluding the given fields.
+func (x *Exif) Verify(v Verifier, sig []byte, exclude ...FieldName) error {
+	return v.Verify(x.Canonical(exclude...), sig)
+}
+
+// VerifyEmbedded checks the signature stored in the field sigField (e.g. a
+// namespaced private tag) over the canonical encoding of the other fields
+// of x.  It returns ErrNoSignature if sigField is missing.
+func (x *Exif) VerifyEmbedded(v Verifier, sigField FieldName) error {
+	tag, err := x.Get(sigField)
+	if err != nil {
+		return ErrNoSignature
+	}
+	if err := v.Verify(x.Canonical(sigField), tag.Val); err != nil {
+		return fmt.Errorf("exif: invalid signature in %v: %v", sigField, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package exif
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// hmacSigner signs with HMAC-SHA256, standing in for a real signature
+// algorithm.
+type hmacSigner []byte
+
+func (key hmacSigner) Sign(data []byte) ([]byte, error) {
+	m := hmac.New(sha256.New, key)
+	m.Write(data)
+	return m.Sum(nil), nil
+}
+
+func (key hmacSigner) Verify(data, sig []byte) error {
+	want, _ := key.Sign(data)
+	if !hmac.Equal(sig, want) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func TestSignVerify(t *testing.T) {
+	key := hmacSigner("secret")
+	x := &Exif{}
+	x.setTag(Model, testString(t, "Camera"))
+	x.setTag(DateTimeOriginal, testString(t, "2020:05:01 12:00:00"))
+
+	sig, err := x.Sign(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Verify(key, sig); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+
+	// Embed the signature, excluding its own field.
+	const sigField FieldName = "Signature"
+	if err := x.VerifyEmbedded(key, sigField); err != ErrNoSignature {
+		t.Errorf("missing signature: got %v", err)
+	}
+	sig, _ = x.Sign(key, sigField)
+	x.setTag(sigField, testTag(t, tiff.DTUndefined, uint32(len(sig)), sig))
+	if err := x.VerifyEmbedded(key, sigField); err != nil {
+		t.Errorf("valid embedded signature rejected: %v", err)
+	}
+
+	x.setTag(Model, testString(t, "Other"))
+	if err := x.Verify(key, sig, sigField); err == nil {
+		t.Errorf("signature accepted after Model changed")
+	}
+	if err := x.VerifyEmbedded(key, sigField); err == nil {
+		t.Errorf("embedded signature accepted after Model changed")
+	}
+	if bytes.Contains(x.Canonical(sigField), sig) {
+		t.Errorf("canonical form includes the excluded signature")
+	}
+}
@@ -0,0 +1,55 @@
+package exif
+
+import (
+	"unsafe"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// SizeBytes estimates the memory retained by x: its raw EXIF data, decoded
+// tags and other metadata.  It allows caches of decoded Exif objects to
+// evict by size.  Memory shared with other Exif objects (e.g. strings
+// interned by a Decoder) is counted for each of them.
+func (x *Exif) SizeBytes() int {
+	n := int(unsafe.Sizeof(*x)) + cap(x.Raw) + cap(x.xmp) + cap(x.mpf) + cap(x.jumbf) + cap(x.audio)
+	seen := map[*tiff.Tag]bool{}
+	if x.Tiff != nil {
+		n += x.Tiff.SizeBytes()
+		for _, d := range x.Tiff.Dirs {
+			for _, t := range d.Tags {
+				seen[t] = true
+			}
+		}
+	}
+
+	for _, fs := range []fields{x.main, x.shadowed} {
+		n += cap(fs) * int(unsafe.Sizeof(field{}))
+		for _, f := range fs {
+			n += len(f.name) + len(f.group)
+			if f.tag != nil && !seen[f.tag] {
+				seen[f.tag] = true
+				n += f.tag.SizeBytes()
+			}
+		}
+	}
+
+	n += cap(x.ctmd) * int(unsafe.Sizeof(CTMDRecord{}))
+	for _, r := range x.ctmd {
+		n += cap(r.Data)
+	}
+	n += cap(x.comments) * int(unsafe.Sizeof(""))
+	for _, c := range x.comments {
+		n += len(c)
+	}
+	n += cap(x.warnings) * int(unsafe.Sizeof(Warning{}))
+	for _, w := range x.warnings {
+		n += len(w.Msg)
+	}
+	if x.jpegFP != nil {
+		n += int(unsafe.Sizeof(*x.jpegFP)) + len(x.jpegFP.DQT) + len(x.jpegFP.DHT)
+	}
+	for name := range x.keep {
+		n += len(name) + int(unsafe.Sizeof(name))
+	}
+	return n
+}
@@ -0,0 +1,35 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSizeBytes(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := x.SizeBytes()
+	if n <= len(x.Raw) {
+		t.Errorf("SizeBytes = %d, no more than the %d raw bytes", n, len(x.Raw))
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	x, err = (&Decoder{KeepOnly: []FieldName{Model}}).Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := x.SizeBytes(); m >= n {
+		t.Errorf("SizeBytes is %d keeping only Model, %d keeping all fields", m, n)
+	}
+}
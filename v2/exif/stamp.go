@@ -0,0 +1,218 @@
+package exif
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Template is a set of descriptive fields stamped onto the images of a
+// batch, e.g. by a studio applying its copyright and contact details to a
+// shoot.  Each string may contain variables, written in braces, that are
+// replaced per image (see StampVars).  Empty strings are not stamped.
+type Template struct {
+	Artist    string
+	Copyright string // the photographer copyright notice
+	// UsageTerms and Contact have no EXIF field: they are stamped together
+	// as an ASCII UserComment, one "Usage terms:" and "Contact:" line each.
+	UsageTerms string
+	Contact    string
+	// Fields holds templates for other ASCII fields, e.g. ImageDescription.
+	Fields map[FieldName]string
+}
+
+// StampVars are the values substituted for the variables of a Template:
+//
+//	{seq}    Seq, in decimal
+//	{seq:N}  Seq, zero padded to N digits
+//	{date}   Date as YYYY-MM-DD
+//	{year}   the year of Date
+//	{name}   Name
+//	{key}    Vars[key]
+//
+// A literal brace is written doubled: "{{" or "}}".
+type StampVars struct {
+	Seq  int       // sequence number of the image in the batch
+	Date time.Time // usually the capture time
+	Name string    // usually the file name
+	Vars map[string]string
+}
+
+// Expand returns s with its variables replaced by their values in v.  It
+// fails on undefined variables, including {date} and {year} if Date is
+// zero.
+func (v StampVars) Expand(s string) (string, error) {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexAny(s, "{}")
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		if i+1 < len(s) && s[i+1] == s[i] {
+			b.WriteByte(s[i])
+			s = s[i+2:]
+			continue
+		}
+		if s[i] == '}' {
+			return "", fmt.Errorf("exif: unmatched '}' in template %q", s)
+		}
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			return "", fmt.Errorf("exif: unterminated variable in template %q", s)
+		}
+		val, err := v.lookup(s[i+1 : i+j])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(val)
+		s = s[i+j+1:]
+	}
+	return b.String(), nil
+}
+
+func (v StampVars) lookup(name string) (string, error) {
+	switch {
+	case name == "seq":
+		return strconv.Itoa(v.Seq), nil
+	case strings.HasPrefix(name, "seq:"):
+		width, err := strconv.Atoi(name[len("seq:"):])
+		if err != nil || width < 0 {
+			return "", fmt.Errorf("exif: invalid template variable {%s}", name)
+		}
+		return fmt.Sprintf("%0*d", width, v.Seq), nil
+	case name == "date" || name == "year":
+		if v.Date.IsZero() {
+			return "", fmt.Errorf("exif: template variable {%s} has no date", name)
+		}
+		if name == "year" {
+			return strconv.Itoa(v.Date.Year()), nil
+		}
+		return v.Date.Format("2006-01-02"), nil
+	case name == "name":
+		return v.Name, nil
+	}
+	if val, ok := v.Vars[name]; ok {
+		return val, nil
+	}
+	return "", fmt.Errorf("exif: undefined template variable {%s}", name)
+}
+
+// asciiComment is the character code prefix of ASCII UserComment values.
+var asciiComment = []byte("ASCII\x00\x00\x00")
+
+// Stamp sets the fields of t on x, with the variables replaced by their
+// values in v.  Either all fields are set or, on error, none.  Like Set, it
+// changes the decoded fields of x only.
+func (t *Template) Stamp(x *Exif, v StampVars) error {
+	order := binary.ByteOrder(binary.BigEndian)
+	if x.Tiff != nil {
+		order = x.Tiff.Order
+	}
+	type stamped struct {
+		name FieldName
+		tag  *tiff.Tag
+	}
+	var tags []stamped
+	add := func(name FieldName, id uint16, tmpl string) error {
+		if tmpl == "" {
+			return nil
+		}
+		s, err := v.Expand(tmpl)
+		if err != nil {
+			return err
+		}
+		var tag *tiff.Tag
+		switch name {
+		case Copyright:
+			tag, err = CopyrightNotice{Photographer: s}.Tag(order)
+		case UserComment:
+			tag, err = tiff.NewTag(id, tiff.DTUndefined, order, append(asciiComment[:len(asciiComment):len(asciiComment)], s...))
+		default:
+			tag, err = tiff.NewTag(id, tiff.DTAscii, order, s)
+		}
+		if err != nil {
+			return err
+		}
+		tags = append(tags, stamped{name, tag})
+		return nil
+	}
+
+	if err := add(Artist, 0x013B, t.Artist); err != nil {
+		return err
+	}
+	if err := add(Copyright, 0x8298, t.Copyright); err != nil {
+		return err
+	}
+	var comment []string
+	if t.UsageTerms != "" {
+		comment = append(comment, "Usage terms: "+t.UsageTerms)
+	}
+	if t.Contact != "" {
+		comment = append(comment, "Contact: "+t.Contact)
+	}
+	if err := add(UserComment, 0x9286, strings.Join(comment, "\n")); err != nil {
+		return err
+	}
+	names := make([]FieldName, 0, len(t.Fields))
+	for name := range t.Fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	for _, name := range names {
+		id, ok := asciiFieldID(name)
+		if !ok {
+			return fmt.Errorf("exif: cannot stamp %v: not a known ASCII field", name)
+		}
+		if err := add(name, id, t.Fields[name]); err != nil {
+			return err
+		}
+	}
+
+	return x.Edit(func(tx *ExifTx) error {
+		for _, s := range tags {
+			tx.Set(s.name, s.tag)
+		}
+		return nil
+	})
+}
+
+// StampAll stamps t on each image of xs, numbering them from first and
+// dating them by their capture time (see Exif.DateTime).  names, if not
+// nil, gives the {name} of each image.  It stops at the first error,
+// leaving the images before it stamped.
+func (t *Template) StampAll(xs []*Exif, names []string, first int) error {
+	for i, x := range xs {
+		v := StampVars{Seq: first + i}
+		if names != nil {
+			v.Name = names[i]
+		}
+		v.Date, _ = x.DateTime()
+		if err := t.Stamp(x, v); err != nil {
+			return fmt.Errorf("image %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// asciiFieldID returns the tag ID of the known field name if it holds ASCII
+// text.
+func asciiFieldID(name FieldName) (uint16, bool) {
+	for _, info := range Fields() {
+		if info.Name != name {
+			continue
+		}
+		for _, typ := range info.Types {
+			if typ == tiff.DTAscii {
+				return info.ID, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,74 @@
+package exif
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStampVarsExpand(t *testing.T) {
+	v := StampVars{
+		Seq:  7,
+		Date: time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC),
+		Name: "a.jpg",
+		Vars: map[string]string{"client": "Acme"},
+	}
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"{client} {seq}", "Acme 7"},
+		{"{seq:4}-{name}", "0007-a.jpg"},
+		{"(c) {year}, {date}", "(c) 2021, 2021-03-04"},
+		{"{{seq}}", "{seq}"},
+	}
+	for _, test := range tests {
+		got, err := v.Expand(test.in)
+		if err != nil || got != test.want {
+			t.Errorf("Expand(%q) = %q, %v; want %q", test.in, got, err, test.want)
+		}
+	}
+	for _, bad := range []string{"{nope}", "{seq", "x}", "{seq:x}"} {
+		if _, err := v.Expand(bad); err == nil {
+			t.Errorf("Expand(%q) succeeded", bad)
+		}
+	}
+	if _, err := (StampVars{}).Expand("{year}"); err == nil {
+		t.Errorf("{year} expanded without a date")
+	}
+}
+
+func TestTemplateStampAll(t *testing.T) {
+	a, b := &Exif{}, &Exif{}
+	a.setTag(DateTimeOriginal, testString(t, "2020:01:02 03:04:05"))
+	tmpl := &Template{
+		Artist:     "Studio {seq:3}",
+		Copyright:  "(c) {year} Studio",
+		UsageTerms: "Editorial use only",
+		Contact:    "{name}@example.com",
+		Fields:     map[FieldName]string{ImageDescription: "Shot {seq}"},
+	}
+
+	if err := tmpl.StampAll([]*Exif{a, b}, []string{"a", "b"}, 1); err == nil {
+		t.Fatal("stamped image without a date")
+	}
+	if s, err := a.stringVal(Artist); err != nil || s != "Studio 001" {
+		t.Errorf("Artist = %q, %v", s, err)
+	}
+	if c, _ := a.Copyright(); c.Photographer != "(c) 2020 Studio" {
+		t.Errorf("Copyright = %+v", c)
+	}
+	if s, _ := a.stringVal(ImageDescription); s != "Shot 1" {
+		t.Errorf("ImageDescription = %q", s)
+	}
+	tag, err := a.Get(UserComment)
+	if err != nil || !strings.HasSuffix(string(tag.Val), "Usage terms: Editorial use only\nContact: a@example.com") {
+		t.Errorf("UserComment = %q, %v", tag.Val, err)
+	}
+	if _, err := b.Get(Artist); err == nil {
+		t.Errorf("failed stamp applied")
+	}
+
+	tmpl.Fields[ExposureTime] = "1"
+	if err := tmpl.Stamp(a, StampVars{}); err == nil {
+		t.Errorf("stamped non-ASCII field")
+	}
+}
@@ -0,0 +1,43 @@
+package exif
+
+// Stats summarizes the amount of metadata of an image, as returned by
+// Exif.Stats.
+type Stats struct {
+	// Fields is the number of fields, including makernote fields and
+	// fields shadowed by or duplicating others.
+	Fields int
+	// ValueBytes is the total size of the field values.
+	ValueBytes int64
+	// Largest is the field with the largest value, of LargestBytes bytes.
+	Largest      FieldName
+	LargestBytes int64
+	// MakerNoteBytes is the size of the MakerNote field, or 0.
+	MakerNoteBytes int64
+	// RawBytes is the size of the raw EXIF data (x.Raw).
+	RawBytes int64
+}
+
+// Stats returns the number and size of the fields of x, so that storage
+// systems can enforce quotas on metadata and spot anomalous files.  Sizes
+// are those of the values as stored in the file, so values not yet loaded
+// by an Exif returned by DecodeIndex are counted.
+func (x *Exif) Stats() Stats {
+	s := Stats{RawBytes: int64(len(x.Raw))}
+	for _, fs := range []fields{x.main, x.shadowed, x.dups} {
+		for _, f := range fs {
+			if f.tag == nil {
+				continue
+			}
+			n := int64(f.tag.Type.Size()) * int64(f.tag.Count)
+			s.Fields++
+			s.ValueBytes += n
+			if n > s.LargestBytes {
+				s.Largest, s.LargestBytes = f.name, n
+			}
+			if f.name == MakerNote {
+				s.MakerNoteBytes = n
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package exif
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "samples", "2006-08-03-16-29-38-sep-2006-08-03-16-29-38a.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := x.Stats()
+	mn, err := x.Get(MakerNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Fields != len(x.main)+len(x.shadowed) {
+		t.Errorf("Fields = %d, want %d", s.Fields, len(x.main)+len(x.shadowed))
+	}
+	if s.Largest != MakerNote || s.LargestBytes != int64(len(mn.Val)) || s.MakerNoteBytes != s.LargestBytes {
+		t.Errorf("largest field %v of %d bytes, makernote of %d bytes; want MakerNote of %d bytes",
+			s.Largest, s.LargestBytes, s.MakerNoteBytes, len(mn.Val))
+	}
+	if s.ValueBytes <= s.LargestBytes || s.RawBytes != int64(len(x.Raw)) {
+		t.Errorf("ValueBytes = %d, RawBytes = %d", s.ValueBytes, s.RawBytes)
+	}
+
+	lazy, err := new(Decoder).DecodeIndex(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls := lazy.Stats(); ls.ValueBytes != s.ValueBytes || ls.Fields != s.Fields {
+		t.Errorf("DecodeIndex stats %+v, Decode stats %+v", ls, s)
+	}
+}
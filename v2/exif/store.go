@@ -0,0 +1,169 @@
+package exif
+
+import (
+	"sort"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// field is a decoded field and the group it was loaded from ("" if
+// unknown).
+type field struct {
+	name  FieldName
+	group string
+	tag   *tiff.Tag
+}
+
+// fields holds the decoded fields of an Exif as a slice sorted by name,
+// which takes a fraction of the memory of a map for the hundred or so
+// fields of a typical image.
+type fields []field
+
+func (fs fields) index(name FieldName) (int, bool) {
+	i := sort.Search(len(fs), func(i int) bool { return fs[i].name >= name })
+	return i, i < len(fs) && fs[i].name == name
+}
+
+func (fs fields) get(name FieldName) (field, bool) {
+	if i, ok := fs.index(name); ok {
+		return fs[i], true
+	}
+	return field{}, false
+}
+
+// set stores f, returning the field it replaced, if any.
+func (fs *fields) set(f field) (old field, replaced bool) {
+	i, ok := fs.index(f.name)
+	if ok {
+		old, (*fs)[i] = (*fs)[i], f
+		return old, true
+	}
+	*fs = append(*fs, field{})
+	copy((*fs)[i+1:], (*fs)[i:])
+	(*fs)[i] = f
+	return field{}, false
+}
+
+func (fs *fields) delete(name FieldName) {
+	if i, ok := fs.index(name); ok {
+		*fs = append((*fs)[:i], (*fs)[i+1:]...)
+	}
+}
+
+// qualified returns the field with the qualified name qname.
+func (fs fields) qualified(qname FieldName) (field, bool) {
+	group, bare := qname.Group()
+	if group == "" {
+		return field{}, false
+	}
+	for _, f := range fs {
+		if f.group != group {
+			continue
+		}
+		if _, name := f.name.Namespace(); name == bare {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// compact moves the values of the tags of x into a single shared buffer,
+// releasing the separately allocated (and often oversized) value slices.
+func (x *Exif) compact() {
+	seen := map[*tiff.Tag]bool{}
+	var tags []*tiff.Tag
+	add := func(tag *tiff.Tag) {
+		if tag != nil && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	for _, f := range x.main {
+		add(f.tag)
+	}
+	for _, fs := range []fields{x.shadowed, x.dups} {
+		for _, f := range fs {
+			add(f.tag)
+		}
+	}
+	if x.Tiff != nil {
+		for _, d := range x.Tiff.Dirs {
+			for _, tag := range d.Tags {
+				add(tag)
+			}
+		}
+	}
+
+	n := 0
+	for _, tag := range tags {
+		n += len(tag.Val)
+	}
+	arena := make([]byte, 0, n)
+	for _, tag := range tags {
+		i := len(arena)
+		arena = append(arena, tag.Val...)
+		tag.Val = arena[i:len(arena):len(arena)]
+	}
+}
+
+// pointerFields are the fields locating sub-IFDs, which are loaded whatever
+// the fields to keep.
+var pointerFields = map[FieldName]bool{
+	ExifIFDPointer:             true,
+	GPSInfoIFDPointer:          true,
+	InteroperabilityIFDPointer: true,
+}
+
+// keeps reports whether the field name loaded from group is to be kept.
+func (x *Exif) keeps(group string, name FieldName) bool {
+	return x.keep == nil || x.keep[name] || (group != "" && x.keep[Qualified(group, name)])
+}
+
+// keepFunc returns the filter selecting the tags of a sub-IFD in group to
+// read, or nil if all are to be read.
+func (x *Exif) keepFunc(group string, fieldMap map[uint16]FieldName) func(id uint16) bool {
+	if x.keep == nil {
+		return nil
+	}
+	return func(id uint16) bool {
+		name, ok := fieldMap[id]
+		switch {
+		case !ok:
+			return false
+		case pointerFields[name] || x.keeps(group, name):
+			return true
+		case name == MakerNote:
+			for k := range x.keep {
+				if ns, _ := k.Namespace(); ns != "" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// prune drops the fields not listed in x.keep.
+func (x *Exif) prune() {
+	var kept fields
+	for _, f := range x.main {
+		if x.keeps(f.group, f.name) {
+			kept = append(kept, f)
+		}
+	}
+	x.main = kept
+	kept = nil
+	for _, f := range x.shadowed {
+		if x.keep[f.name] {
+			kept = append(kept, f)
+		}
+	}
+	x.shadowed = kept
+	kept = nil
+	for _, f := range x.dups {
+		if x.keeps(f.group, f.name) {
+			kept = append(kept, f)
+		}
+	}
+	x.dups = kept
+}
@@ -0,0 +1,78 @@
+package exif
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestShadowedFields(t *testing.T) {
+	x := &Exif{}
+	ifd0, ifd1 := testString(t, "ifd0"), testString(t, "ifd1")
+	x.setGroup(GroupIFD0)
+	x.setTag(Orientation, ifd0)
+	x.setGroup(GroupIFD1)
+	x.setTag(Orientation, ifd1)
+	x.setGroup("")
+
+	if tag, _ := x.Get(Orientation); tag != ifd1 {
+		t.Errorf("Orientation is %v, want the IFD1 tag", tag)
+	}
+	if got := x.QualifiedName(Orientation); got != "IFD1/Orientation" {
+		t.Errorf("QualifiedName(Orientation) = %v", got)
+	}
+	for name, want := range map[FieldName]interface{}{"IFD0/Orientation": ifd0, "IFD1/Orientation": ifd1} {
+		if tag, err := x.Get(name); err != nil || tag != want {
+			t.Errorf("Get(%v) = %v, %v", name, tag, err)
+		}
+	}
+}
+
+func TestCompact(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(x.main); i++ {
+		if x.main[i-1].name >= x.main[i].name {
+			t.Fatalf("fields not sorted: %v before %v", x.main[i-1].name, x.main[i].name)
+		}
+	}
+	tag, _ := x.Get(Make)
+	if cap(tag.Val) != len(tag.Val) {
+		t.Errorf("Make value has spare capacity %v", cap(tag.Val)-len(tag.Val))
+	}
+	if s, _ := tag.StringVal(); s != "NIKON CORPORATION" {
+		t.Errorf("Make is %q after compaction", s)
+	}
+}
+
+func TestKeepOnly(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d := &Decoder{KeepOnly: []FieldName{Model, "Exif/ExposureTime", "GPS/Make"}}
+	x, err := d.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []FieldName
+	x.Walk(walkFunc(func(name FieldName, tag *tiff.Tag) error {
+		names = append(names, name)
+		return nil
+	}))
+	if len(names) != 2 || names[0] != ExposureTime || names[1] != Model {
+		t.Errorf("kept fields %v, want [ExposureTime Model]", names)
+	}
+}
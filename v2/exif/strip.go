@@ -0,0 +1,52 @@
+package exif
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// Strip copies the JPEG image src to dst without its EXIF segments, e.g. to
+// scrub the camera details and location from a photo before publishing it.
+// The other segments, including any XMP packet, and the image data are
+// copied unchanged.
+func Strip(src io.Reader, dst io.Writer) error {
+	return copyJPEG(dst, src, nil)
+}
+
+// StripGPS is like Strip, but removes only the fields of the GPS IFD,
+// keeping the other EXIF fields as written by WriteJPEG.  An image without
+// EXIF data is copied unchanged.
+func StripGPS(src io.Reader, dst io.Writer) error {
+	data, err := ioutil.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	x, err := Decode(bytes.NewReader(data))
+	if err == ErrNoExif {
+		return copyJPEG(dst, bytes.NewReader(data), nil)
+	} else if err != nil && IsCriticalError(err) {
+		return err
+	}
+	x.removeGroup(GroupGPS)
+	return WriteJPEG(dst, bytes.NewReader(data), x)
+}
+
+// removeGroup deletes the fields of group from x.
+func (x *Exif) removeGroup(group string) {
+	var names []FieldName
+	for _, fs := range []fields{x.main, x.shadowed, x.dups} {
+		for _, f := range fs {
+			if f.group != group {
+				continue
+			}
+			if g, _ := f.name.Group(); g == "" {
+				f.name = Qualified(group, f.name)
+			}
+			names = append(names, f.name)
+		}
+	}
+	for _, name := range names {
+		x.Delete(name)
+	}
+}
@@ -0,0 +1,173 @@
+package exif_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/exiftest"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// scanData returns the JPEG data from the SOS marker on.
+func scanData(t *testing.T, data []byte) []byte {
+	i := 2
+	for i+4 <= len(data) && data[i+1] != 0xDA {
+		i += 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+	}
+	if i+4 > len(data) {
+		t.Fatal("no SOS marker")
+	}
+	return data[i:]
+}
+
+func TestStrip(t *testing.T) {
+	src, err := exiftest.NewJPEG().
+		WithTag(exif.Model, "Synth 1").
+		WithTag(exif.GPSVersionID, 2, 2, 0, 0).
+		WithTag(exif.GPSLatitudeRef, "N").
+		WithTag(exif.GPSLatitude, [2]int64{35, 1}, [2]int64{30, 1}, [2]int64{0, 1}).
+		WithTag(exif.GPSLongitudeRef, "W").
+		WithTag(exif.GPSLongitude, [2]int64{120, 1}, [2]int64{15, 1}, [2]int64{0, 1}).
+		Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := exif.Strip(bytes.NewReader(src), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(scanData(t, buf.Bytes()), scanData(t, src)) {
+		t.Error("Strip: image data changed")
+	}
+	if _, err := exif.Decode(bytes.NewReader(buf.Bytes())); err != exif.ErrNoExif {
+		t.Errorf("Strip: Decode error %v, want ErrNoExif", err)
+	}
+
+	buf.Reset()
+	if err := exif.StripGPS(bytes.NewReader(src), &buf); err != nil {
+		t.Fatal(err)
+	}
+	x, err := exif.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := x.LatLong(); err == nil {
+		t.Error("StripGPS: location present")
+	}
+	for _, name := range []exif.FieldName{exif.GPSInfoIFDPointer, exif.GPSVersionID} {
+		if _, err := x.Get(name); err == nil {
+			t.Errorf("StripGPS: %v present", name)
+		}
+	}
+	if _, err := x.Get(exif.Model); err != nil {
+		t.Errorf("StripGPS: Model: %v", err)
+	}
+
+	img := append([]byte{0xFF, 0xD8}, scanData(t, src)...)
+	buf.Reset()
+	if err := exif.StripGPS(bytes.NewReader(img), &buf); err != nil || !bytes.Equal(buf.Bytes(), img) {
+		t.Errorf("StripGPS of image without EXIF: %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	ifd1 := exif.Qualified(exif.GroupIFD1, exif.Orientation)
+	build := func() *exif.Exif {
+		x := &exif.Exif{}
+		for i, name := range []exif.FieldName{ifd1, exif.Qualified(exif.GroupIFD0, exif.Orientation)} {
+			tag, err := tiff.NewTag(0x0112, tiff.DTShort, binary.BigEndian, uint16(i+1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			x.Set(name, tag)
+		}
+		tag, err := tiff.NewTag(0x0110, tiff.DTAscii, binary.BigEndian, "Synth")
+		if err != nil {
+			t.Fatal(err)
+		}
+		x.Set(exif.Model, tag)
+		return x
+	}
+
+	x := build()
+	x.Delete(exif.Orientation)
+	if _, err := x.Get(ifd1); err != nil {
+		t.Errorf("Delete removed %v: %v", ifd1, err)
+	}
+
+	x = build()
+	x.Remove(exif.Orientation)
+	for _, name := range []exif.FieldName{exif.Orientation, ifd1} {
+		if _, err := x.Get(name); err == nil {
+			t.Errorf("%v present after Remove", name)
+		}
+	}
+	if _, err := x.Get(exif.Model); err != nil {
+		t.Errorf("Model: %v", err)
+	}
+}
+
+func TestStripContinued(t *testing.T) {
+	b := exiftest.NewTIFF().
+		WithTag(exif.Model, "Synth 1").
+		WithTag(exif.GPSLatitudeRef, "N").
+		WithTag(exif.GPSLatitude, [2]int64{35, 1}, [2]int64{30, 1}, [2]int64{0, 1}).
+		WithTag(exif.GPSLongitudeRef, "W").
+		WithTag(exif.GPSLongitude, [2]int64{120, 1}, [2]int64{15, 1}, [2]int64{0, 1})
+	tif, err := b.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := exiftest.NewJPEG().Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plain bytes.Buffer
+	if err := exif.Strip(bytes.NewReader(src), &plain); err != nil {
+		t.Fatal(err)
+	}
+	seg := func(data []byte) []byte {
+		return append([]byte{0xFF, 0xE1, byte((len(data) + 2) >> 8), byte(len(data) + 2)}, data...)
+	}
+
+	// The EXIF data split in two APP1 segments, the second without intro.
+	tail := tif[len(tif)/2:]
+	jpg := []byte{0xFF, 0xD8}
+	jpg = append(jpg, seg(append([]byte("Exif\x00\x00"), tif[:len(tif)/2]...))...)
+	jpg = append(jpg, seg(tail)...)
+	jpg = append(jpg, plain.Bytes()[2:]...)
+	if x, err := exif.Decode(bytes.NewReader(jpg)); err != nil {
+		t.Fatal(err)
+	} else if _, _, err := x.LatLong(); err != nil {
+		t.Fatalf("split EXIF data: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := exif.Strip(bytes.NewReader(jpg), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), plain.Bytes()) {
+		t.Error("Strip: continuation segment kept")
+	}
+
+	buf.Reset()
+	if err := exif.StripGPS(bytes.NewReader(jpg), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), tail) {
+		t.Error("StripGPS: continuation segment kept")
+	}
+	x, err := exif.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := x.LatLong(); err == nil {
+		t.Error("StripGPS: location present")
+	}
+	if _, err := x.Get(exif.Model); err != nil {
+		t.Errorf("StripGPS: Model: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package exif
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// SubIFDs decodes the tree of IFDs pointed to by the SubIFDs field of IFD0,
+// as written by DNG and other raw formats for the full size images.  The
+// tree is bounded by the SubIFDLimits of the Decoder that decoded x; trees
+// exceeding them fail with tiff.ErrSubIFDDepth or tiff.ErrTooManySubIFDs.
+// It returns nil if IFD0 has no SubIFDs field.
+func (x *Exif) SubIFDs() ([]*tiff.SubDir, error) {
+	if x.Tiff == nil || len(x.Tiff.Dirs) == 0 {
+		return nil, errors.New("exif: no IFD0")
+	}
+	return tiff.DecodeSubIFDs(bytes.NewReader(x.Raw), x.Tiff.Order, x.Tiff.Dirs[0], x.subLimits)
+}
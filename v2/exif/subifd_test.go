@@ -0,0 +1,73 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestSubIFDs(t *testing.T) {
+	// IFD0 at 8 points to a sub-IFD at 26, which points to one at 44.
+	data := []byte("II*\x00\x08\x00\x00\x00" +
+		"\x01\x00\x4a\x01\x04\x00\x01\x00\x00\x00\x1a\x00\x00\x00\x00\x00\x00\x00" +
+		"\x01\x00\x4a\x01\x04\x00\x01\x00\x00\x00\x2c\x00\x00\x00\x00\x00\x00\x00" +
+		"\x00\x00\x00\x00\x00\x00")
+
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs, err := x.SubIFDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].Offset != 26 || len(subs[0].Subs) != 1 || subs[0].Subs[0].Offset != 44 {
+		t.Errorf("got sub-IFDs %v", subs)
+	}
+
+	x, err = (&Decoder{SubIFDLimits: tiff.SubIFDLimits{MaxDepth: 1}}).Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.SubIFDs(); err != tiff.ErrSubIFDDepth {
+		t.Errorf("got error %v, want %v", err, tiff.ErrSubIFDDepth)
+	}
+}
+
+func TestIFDTypedPointers(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Type the ExifIFDPointer of IFD0 as an IFD offset, as ERF and SRW
+	// files do.
+	le := binary.LittleEndian
+	ifd0 := le.Uint32(data[4:])
+	n := int(le.Uint16(data[ifd0:]))
+	found := false
+	for i := 0; i < n; i++ {
+		e := data[int(ifd0)+2+12*i:]
+		if le.Uint16(e) == ExifIFDPointerID {
+			le.PutUint16(e[2:], uint16(tiff.DTIFD))
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("no ExifIFDPointer in IFD0")
+	}
+
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Get(ExposureTime); err != nil {
+		t.Errorf("Exif sub-IFD not loaded: %v", err)
+	}
+	if ws := x.Warnings(); len(ws) != 0 {
+		t.Errorf("warnings %v", ws)
+	}
+}
@@ -0,0 +1,67 @@
+package exif
+
+//go:generate go run gen_samples.go -- testdata/synth
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// synthTests describes the files written by gen_samples.go.  err is
+// "" for a clean decode, "critical" or "partial" otherwise.
+var synthTests = []struct {
+	name   string
+	order  binary.ByteOrder
+	err    string
+	fields map[FieldName]string
+	thumb  bool
+}{
+	{"le_basic.tif", binary.LittleEndian, "", map[FieldName]string{Model: `"Synth 1"`, ExposureTime: `"1/250"`}, false},
+	{"be_basic.tif", binary.BigEndian, "", map[FieldName]string{Model: `"Synth 1"`, ExposureTime: `"1/250"`}, false},
+	{"le_values_first.tif", binary.LittleEndian, "", map[FieldName]string{Make: `"Example"`, ISOSpeedRatings: `200`}, false},
+	{"be_gps.jpg", binary.BigEndian, "", map[FieldName]string{GPSLatitude: `["45/1","30/1","0/1"]`, GPSLongitudeRef: `"W"`}, false},
+	{"le_thumbnail.jpg", binary.LittleEndian, "", map[FieldName]string{DateTimeOriginal: `"2001:02:03 04:05:06"`}, true},
+	{"corrupt_entry_count.jpg", nil, "critical", nil, false},
+	{"corrupt_exif_pointer.tif", binary.BigEndian, "partial", map[FieldName]string{Model: `"Synth 1"`}, false},
+	{"corrupt_truncated.tif", nil, "critical", nil, false},
+}
+
+func TestSynthSamples(t *testing.T) {
+	for _, test := range synthTests {
+		f, err := os.Open(filepath.Join(*dataDir, "testdata", "synth", test.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := Decode(f)
+		f.Close()
+
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+		case test.err == "critical" && (err == nil || !IsCriticalError(err)):
+			t.Errorf("%v: want critical error, got %v", test.name, err)
+		case test.err == "partial" && (err == nil || IsCriticalError(err)):
+			t.Errorf("%v: want non-critical error, got %v", test.name, err)
+		}
+		if test.err == "critical" {
+			continue
+		}
+
+		if x.Tiff.Order != test.order {
+			t.Errorf("%v: byte order is %v, want %v", test.name, x.Tiff.Order, test.order)
+		}
+		for name, want := range test.fields {
+			tag, err := x.Get(name)
+			if err != nil {
+				t.Errorf("%v: %v", test.name, err)
+			} else if got := tag.String(); got != want {
+				t.Errorf("%v: %v is %v, want %v", test.name, name, got, want)
+			}
+		}
+		if _, err := x.JpegThumbnail(); (err == nil) != test.thumb {
+			t.Errorf("%v: thumbnail error %v, want thumbnail %v", test.name, err, test.thumb)
+		}
+	}
+}
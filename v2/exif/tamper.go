@@ -0,0 +1,215 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Scores of the individual tamper checks.
+const (
+	tamperScoreSoftware  = 0.4
+	tamperScoreDateTime  = 0.3
+	tamperScoreThumbnail = 0.5
+	tamperScoreMakerNote = 0.3
+	tamperScoreExifIFD   = 0.3
+)
+
+// editorSoftware holds lower case substrings of the Software field values
+// written by image editors.
+var editorSoftware = []string{
+	"photoshop",
+	"lightroom",
+	"gimp",
+	"snapseed",
+	"paint.net",
+	"paintshop",
+	"pixelmator",
+	"affinity",
+	"capture one",
+	"darktable",
+	"rawtherapee",
+	"acdsee",
+	"picasa",
+	"photoscape",
+	"luminar",
+	"facetune",
+}
+
+// makerNoteMakes holds lower case Make prefixes of manufacturers whose
+// cameras always record a makernote.
+var makerNoteMakes = []string{
+	"canon",
+	"nikon",
+	"sony",
+	"olympus",
+	"om digital",
+	"panasonic",
+	"fujifilm",
+	"pentax",
+	"ricoh",
+	"samsung",
+	"leica",
+}
+
+// A TamperFinding is a single sign of metadata editing.
+type TamperFinding struct {
+	// Check names the heuristic that fired, e.g. "software".
+	Check string
+	// Detail describes the finding.
+	Detail string
+	// Score is the weight of the finding, between 0 and 1.
+	Score float64
+}
+
+// TamperReport is the result of CheckTampering.
+type TamperReport struct {
+	Findings []TamperFinding
+	// Score combines the finding scores as independent probabilities,
+	// 1 - (1-s1)(1-s2)...; 0 means no sign of editing was found.
+	Score float64
+}
+
+func (r *TamperReport) add(check string, score float64, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, TamperFinding{check, fmt.Sprintf(format, args...), score})
+	r.Score = 1 - (1-r.Score)*(1-score)
+}
+
+// CheckTampering applies heuristics that flag signs of the metadata (or
+// image) having been edited after capture, for forensic triage:
+//
+//	software  - the Software field names an image editor
+//	datetime  - DateTime (last modification) differs from DateTimeOriginal
+//	thumbnail - the thumbnail's aspect ratio does not match the image's
+//	makernote - the camera make always writes a makernote, but none is present
+//	exififd   - a camera make is recorded but the Exif sub-IFD is missing
+//
+// None of these prove tampering; the report is meant to rank images for
+// closer inspection.
+func (x *Exif) CheckTampering() TamperReport {
+	var r TamperReport
+
+	if sw := x.stringField(Software); sw != "" {
+		lower := strings.ToLower(sw)
+		for _, editor := range editorSoftware {
+			if strings.Contains(lower, editor) {
+				r.add("software", tamperScoreSoftware, "Software is an image editor (%q)", sw)
+				break
+			}
+		}
+	}
+
+	modified, err1 := time.Parse("2006:01:02 15:04:05", x.stringField(DateTime))
+	original, err2 := time.Parse("2006:01:02 15:04:05", x.stringField(DateTimeOriginal))
+	if err1 == nil && err2 == nil && !modified.Equal(original) {
+		r.add("datetime", tamperScoreDateTime, "DateTime is %v after DateTimeOriginal", modified.Sub(original))
+	}
+
+	if w, h, err := x.pixelDimensions(); err == nil {
+		if thumb, err := x.JpegThumbnail(); err == nil {
+			if tw, th, err := jpegDimensions(thumb); err == nil && !sameAspect(w, h, tw, th) {
+				r.add("thumbnail", tamperScoreThumbnail, "thumbnail is %dx%d but image is %dx%d", tw, th, w, h)
+			}
+		}
+	}
+
+	make := x.stringField(Make)
+	if make != "" {
+		lower := strings.ToLower(make)
+		for _, prefix := range makerNoteMakes {
+			if !strings.HasPrefix(lower, prefix) {
+				continue
+			}
+			if _, err := x.Get(MakerNote); err != nil {
+				r.add("makernote", tamperScoreMakerNote, "%s cameras record a makernote, but none is present", make)
+			}
+			break
+		}
+		if _, err := x.Get(ExifIFDPointer); err != nil {
+			r.add("exififd", tamperScoreExifIFD, "Make is %q but there is no Exif sub-IFD", make)
+		}
+	}
+	return r
+}
+
+// stringField returns the trimmed string value of the named field, or "".
+func (x *Exif) stringField(name FieldName) string {
+	tag, err := x.Get(name)
+	if err != nil {
+		return ""
+	}
+	s, err := tag.StringVal()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+// pixelDimensions returns the image size recorded in the Exif sub-IFD.
+func (x *Exif) pixelDimensions() (w, h int, err error) {
+	tw, err := x.Get(PixelXDimension)
+	if err != nil {
+		return 0, 0, err
+	}
+	th, err := x.Get(PixelYDimension)
+	if err != nil {
+		return 0, 0, err
+	}
+	if w, err = tw.Int(0); err != nil {
+		return 0, 0, err
+	}
+	if h, err = th.Int(0); err != nil {
+		return 0, 0, err
+	}
+	return w, h, nil
+}
+
+// sameAspect reports whether w1 x h1 and w2 x h2 have the same aspect
+// ratio, allowing for rounding of small thumbnails and for one of them
+// being rotated by 90 degrees.  Thumbnails padded to 160x120 (as the DCF
+// standard requires) match any 4:3 or 3:2 image.
+func sameAspect(w1, h1, w2, h2 int) bool {
+	if w1 <= 0 || h1 <= 0 || w2 <= 0 || h2 <= 0 {
+		return true
+	}
+	if w2 == 160 && h2 == 120 {
+		return true
+	}
+	a := float64(w1) / float64(h1)
+	b := float64(w2) / float64(h2)
+	const tolerance = 0.05
+	return math.Abs(a-b)/a < tolerance || math.Abs(a-1/b)/a < tolerance
+}
+
+// jpegDimensions returns the size of the JPEG image in data, read from its
+// start of frame segment.
+func jpegDimensions(data []byte) (w, h int, err error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, 0, errors.New("exif: not a JPEG image")
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0, 0, errors.New("exif: invalid JPEG segment marker")
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		// SOF0 through SOF15, except DHT (C4), JPG (C8) and DAC (CC)
+		if marker >= 0xC0 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC {
+			if i+9 > len(data) {
+				break
+			}
+			h = int(binary.BigEndian.Uint16(data[i+5:]))
+			w = int(binary.BigEndian.Uint16(data[i+7:]))
+			return w, h, nil
+		}
+		i += 2 + size
+	}
+	return 0, 0, errors.New("exif: no JPEG frame header found")
+}
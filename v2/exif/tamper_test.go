@@ -0,0 +1,88 @@
+package exif
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestCheckTampering(t *testing.T) {
+	x := &Exif{}
+	x.setTag(Make, testString(t, "Canon"))
+	x.setTag(Software, testString(t, "Adobe Photoshop CC 2019 (Windows)"))
+	x.setTag(DateTime, testString(t, "2020:05:02 09:00:00"))
+	x.setTag(DateTimeOriginal, testString(t, "2020:05:01 12:00:00"))
+
+	r := x.CheckTampering()
+	checks := map[string]bool{}
+	for _, f := range r.Findings {
+		checks[f.Check] = true
+	}
+	for _, want := range []string{"software", "datetime", "makernote", "exififd"} {
+		if !checks[want] {
+			t.Errorf("check %q did not fire", want)
+		}
+	}
+	if checks["thumbnail"] {
+		t.Errorf("check thumbnail fired without a thumbnail")
+	}
+	want := 1 - (1-tamperScoreSoftware)*(1-tamperScoreDateTime)*(1-tamperScoreMakerNote)*(1-tamperScoreExifIFD)
+	if math.Abs(r.Score-want) > 1e-9 {
+		t.Errorf("score = %v, want %v", r.Score, want)
+	}
+
+	clean := &Exif{}
+	clean.setTag(Make, testString(t, "GoPro"))
+	clean.setTag(ExifIFDPointer, testTag(t, tiff.DTLong, 1, []byte{0, 0, 0, 8}))
+	clean.setTag(DateTime, testString(t, "2020:05:01 12:00:00"))
+	clean.setTag(DateTimeOriginal, testString(t, "2020:05:01 12:00:00"))
+	if r := clean.CheckTampering(); len(r.Findings) != 0 || r.Score != 0 {
+		t.Errorf("unedited image: got %+v", r)
+	}
+}
+
+func TestTamperThumbnail(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err := x.JpegThumbnail()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw, th, err := jpegDimensions(thumb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tw != 128 || th != 96 {
+		t.Fatalf("thumbnail is %dx%d, want 128x96", tw, th)
+	}
+	// Pretend the image was cropped to a square.
+	x.setTag(PixelXDimension, testTag(t, tiff.DTLong, 1, []byte{0, 0, 4, 0}))
+	x.setTag(PixelYDimension, testTag(t, tiff.DTLong, 1, []byte{0, 0, 4, 0}))
+	found := false
+	for _, f := range x.CheckTampering().Findings {
+		found = found || f.Check == "thumbnail"
+	}
+	if !found {
+		t.Errorf("4:3 thumbnail of a square image not flagged")
+	}
+
+	// 4:3 portrait, as recorded by a rotated camera
+	x.setTag(PixelXDimension, testTag(t, tiff.DTLong, 1, []byte{0, 0, 0x0c, 0}))
+	x.setTag(PixelYDimension, testTag(t, tiff.DTLong, 1, []byte{0, 0, 0x10, 0}))
+	for _, f := range x.CheckTampering().Findings {
+		if f.Check == "thumbnail" {
+			t.Errorf("rotated 4:3 image flagged: %v", f.Detail)
+		}
+	}
+}
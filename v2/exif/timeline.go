@@ -0,0 +1,89 @@
+package exif
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimelineEntry is an image placed on a merged multi-camera timeline.
+type TimelineEntry struct {
+	// Index is the position of the image in the slice passed to Timeline.
+	Index int
+	// Camera identifies the camera that took the image (see CameraID).
+	Camera string
+	// Time is the capture time corrected by the camera's clock offset.  It
+	// is the zero time if the image has no usable capture time.
+	Time time.Time
+}
+
+// CameraID returns a string identifying the camera that took the image,
+// built from the Make and Model fields.  It is used to key the clock offsets
+// passed to Timeline.
+func (x *Exif) CameraID() string {
+	return x.camera()
+}
+
+// ClockOffset returns the offset that must be added to the capture times of
+// the camera that took x to correct its clock, given the actual time at
+// which x was taken (e.g. read off a GPS unit or phone clock shown in the
+// image).
+func ClockOffset(x *Exif, actual time.Time) (time.Duration, error) {
+	t, err := x.captureTime()
+	if err != nil {
+		return 0, err
+	}
+	return actual.Sub(t), nil
+}
+
+// ClockOffsets derives per-camera clock offsets from sync, a set of images
+// of the same moment taken by different cameras (e.g. everyone photographing
+// the first kiss).  The clock of the camera that took sync[0] is used as the
+// reference and gets a zero offset.  The result is keyed by CameraID and can
+// be passed to Timeline.
+func ClockOffsets(sync []*Exif) (map[string]time.Duration, error) {
+	if len(sync) == 0 {
+		return nil, errors.New("exif: no reference images")
+	}
+	ref, err := sync[0].captureTime()
+	if err != nil {
+		return nil, fmt.Errorf("exif: reference image: %v", err)
+	}
+	offsets := map[string]time.Duration{}
+	for i, x := range sync {
+		off, err := ClockOffset(x, ref)
+		if err != nil {
+			return nil, fmt.Errorf("exif: sync image %d: %v", i, err)
+		}
+		id := x.CameraID()
+		if prev, ok := offsets[id]; ok && prev != off {
+			return nil, fmt.Errorf("exif: conflicting clock offsets for camera %q", id)
+		}
+		offsets[id] = off
+	}
+	return offsets, nil
+}
+
+// Timeline merges images from several cameras into one timeline ordered by
+// capture time, adding offsets[x.CameraID()] to the capture time of each
+// image to correct for camera clocks that were not in sync.  Cameras
+// missing from offsets are assumed to be correct.
+//
+// Images without a usable capture time are placed after all other entries,
+// in their original order.
+func Timeline(xs []*Exif, offsets map[string]time.Duration) []TimelineEntry {
+	var timed, untimed []TimelineEntry
+	for i, x := range xs {
+		e := TimelineEntry{Index: i, Camera: x.CameraID()}
+		t, err := x.captureTime()
+		if err != nil {
+			untimed = append(untimed, e)
+			continue
+		}
+		e.Time = t.Add(offsets[e.Camera])
+		timed = append(timed, e)
+	}
+	sort.SliceStable(timed, func(i, j int) bool { return timed[i].Time.Before(timed[j].Time) })
+	return append(timed, untimed...)
+}
@@ -0,0 +1,62 @@
+package exif
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	t0 := time.Date(2020, 5, 1, 12, 0, 0, 0, time.Local)
+	canon := func(tm time.Time) *Exif { return burstExif(t, tm, 0) }
+	nikon := func(tm time.Time) *Exif {
+		x := burstExif(t, tm, 0)
+		x.setTag(Make, testString(t, "NIKON"))
+		x.setTag(Model, testString(t, "D850"))
+		return x
+	}
+
+	// The Nikon clock runs 90 seconds fast.
+	skew := 90 * time.Second
+	offsets, err := ClockOffsets([]*Exif{canon(t0), nikon(t0.Add(skew))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := offsets["NIKON D850"]; got != -skew {
+		t.Errorf("Nikon offset = %v, want %v", got, -skew)
+	}
+	if got := offsets["Canon Canon EOS 5D"]; got != 0 {
+		t.Errorf("Canon offset = %v, want 0", got)
+	}
+
+	untimed := canon(t0)
+	untimed.main.delete(DateTimeOriginal)
+	xs := []*Exif{
+		untimed,
+		nikon(t0.Add(skew + 10*time.Second)),
+		canon(t0.Add(20 * time.Second)),
+		nikon(t0.Add(skew + 30*time.Second)),
+		canon(t0.Add(5 * time.Second)),
+	}
+	got := Timeline(xs, offsets)
+	wantIdx := []int{4, 1, 2, 3, 0}
+	wantSec := []int{5, 10, 20, 30, -1}
+	if len(got) != len(wantIdx) {
+		t.Fatalf("got %d entries, want %d", len(got), len(wantIdx))
+	}
+	for i, e := range got {
+		if e.Index != wantIdx[i] {
+			t.Errorf("entry %d: index %d, want %d", i, e.Index, wantIdx[i])
+		}
+		if wantSec[i] < 0 {
+			if !e.Time.IsZero() {
+				t.Errorf("entry %d: time %v, want zero", i, e.Time)
+			}
+		} else if want := t0.Add(time.Duration(wantSec[i]) * time.Second); !e.Time.Equal(want) {
+			t.Errorf("entry %d: time %v, want %v", i, e.Time, want)
+		}
+	}
+
+	if _, err := ClockOffsets([]*Exif{canon(t0), canon(t0.Add(time.Second))}); err == nil {
+		t.Errorf("conflicting offsets: got no error")
+	}
+}
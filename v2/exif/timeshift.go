@@ -0,0 +1,142 @@
+package exif
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// ShiftTimes adds d to the time stamps of x (DateTimeOriginal,
+// DateTimeDigitized and DateTime), carrying into and out of their
+// sub-second fields, e.g. to fix the capture times of a camera whose clock
+// was off (see ClockOffset).  Sub-second fields keep their number of
+// digits unless d needs more; one is added if d has a sub-second part.
+//
+// If zone is true, the camera was set to the right time in the wrong time
+// zone: the OffsetTime fields present are moved by d as well, so the
+// instants recorded stay the same.  d must then be a whole number of
+// minutes.  The GPS time stamps, which are in UTC and set from satellites,
+// are never changed.
+//
+// Either all time stamps are shifted or, on error, none.  Like Set, it
+// changes the decoded fields of x only.  Blank time stamps are left alone.
+func (x *Exif) ShiftTimes(d time.Duration, zone bool) error {
+	if zone && d%time.Minute != 0 {
+		return fmt.Errorf("exif: time zone shift %v is not a whole number of minutes", d)
+	}
+	order := binary.ByteOrder(binary.BigEndian)
+	if x.Tiff != nil {
+		order = x.Tiff.Order
+	}
+	newTag := func(name FieldName, s string) (*tiff.Tag, error) {
+		id, ok := asciiFieldID(name)
+		if !ok {
+			return nil, fmt.Errorf("exif: %v is not an ASCII field", name)
+		}
+		return tiff.NewTag(id, tiff.DTAscii, order, s)
+	}
+
+	type shifted struct {
+		name FieldName
+		tag  *tiff.Tag
+	}
+	var tags []shifted
+	for _, fs := range timeFields {
+		dt, err := x.stringVal(fs[0])
+		if err != nil || strings.Trim(dt, " :") == "" {
+			// missing, or blank as written for unknown times
+			continue
+		}
+		t, err := time.Parse("2006:01:02 15:04:05", strings.TrimSpace(dt))
+		if err != nil {
+			return fmt.Errorf("exif: cannot shift %v: %v", fs[0], err)
+		}
+		digits := 0
+		if sub, err := x.stringVal(fs[1]); err == nil {
+			sub = strings.TrimSpace(sub)
+			if len(sub) > 9 {
+				sub = sub[:9]
+			}
+			if sub != "" {
+				ns, err := strconv.Atoi(sub + strings.Repeat("0", 9-len(sub)))
+				if err != nil {
+					return fmt.Errorf("exif: cannot shift %v: invalid value %q", fs[1], sub)
+				}
+				t = t.Add(time.Duration(ns))
+				digits = len(sub)
+			}
+		}
+		if n := fracDigits(d); n > digits {
+			digits = n
+		}
+		t = t.Add(d)
+
+		tag, err := newTag(fs[0], t.Format("2006:01:02 15:04:05"))
+		if err != nil {
+			return err
+		}
+		tags = append(tags, shifted{fs[0], tag})
+		if digits > 0 {
+			ns := fmt.Sprintf("%09d", t.Nanosecond())
+			if tag, err = newTag(fs[1], ns[:digits]); err != nil {
+				return err
+			}
+			tags = append(tags, shifted{fs[1], tag})
+		}
+
+		if !zone {
+			continue
+		}
+		off, err := x.stringVal(fs[2])
+		if err != nil {
+			continue
+		}
+		zt, err := time.Parse("-07:00", strings.TrimSpace(off))
+		if err != nil {
+			return fmt.Errorf("exif: cannot shift %v: invalid offset %q", fs[2], off)
+		}
+		_, secs := zt.Zone()
+		if tag, err = newTag(fs[2], formatOffset(secs+int(d/time.Second))); err != nil {
+			return err
+		}
+		tags = append(tags, shifted{fs[2], tag})
+	}
+
+	return x.Edit(func(tx *ExifTx) error {
+		for _, s := range tags {
+			tx.Set(s.name, s.tag)
+		}
+		return nil
+	})
+}
+
+// fracDigits returns the number of decimal digits of the sub-second part
+// of d.
+func fracDigits(d time.Duration) int {
+	ns := int64(d % time.Second)
+	if ns < 0 {
+		ns = -ns
+	}
+	if ns == 0 {
+		return 0
+	}
+	n := 9
+	for ; ns%10 == 0; ns /= 10 {
+		n--
+	}
+	return n
+}
+
+// formatOffset formats an offset from UTC in seconds as an OffsetTime value,
+// "±HH:MM".
+func formatOffset(secs int) string {
+	sign := '+'
+	if secs < 0 {
+		sign, secs = '-', -secs
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, secs/3600, secs/60%60)
+}
@@ -0,0 +1,57 @@
+package exif
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftTimes(t *testing.T) {
+	x := &Exif{}
+	x.setTag(DateTimeOriginal, testString(t, "2020:12:31 23:59:59"))
+	x.setTag(SubSecTimeOriginal, testString(t, "75"))
+	x.setTag(OffsetTimeOriginal, testString(t, "+01:00"))
+	x.setTag(DateTime, testString(t, "2020:12:31 20:00:00"))
+	before := x.timeStamp(DateTimeOriginal, SubSecTimeOriginal, OffsetTimeOriginal).Time
+
+	if err := x.ShiftTimes(1500*time.Millisecond, false); err != nil {
+		t.Fatal(err)
+	}
+	want := map[FieldName]string{
+		DateTimeOriginal:   "2021:01:01 00:00:01",
+		SubSecTimeOriginal: "25",
+		OffsetTimeOriginal: "+01:00",
+		DateTime:           "2020:12:31 20:00:01",
+		SubSecTime:         "5",
+	}
+	for name, w := range want {
+		if got, err := x.stringVal(name); err != nil || got != w {
+			t.Errorf("%v = %q, %v; want %q", name, got, err, w)
+		}
+	}
+
+	if err := x.ShiftTimes(-150*time.Minute, true); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := x.stringVal(OffsetTimeOriginal); got != "-01:30" {
+		t.Errorf("OffsetTimeOriginal = %q, want -01:30", got)
+	}
+	after := x.timeStamp(DateTimeOriginal, SubSecTimeOriginal, OffsetTimeOriginal).Time
+	if d := after.Sub(before); d != 1500*time.Millisecond {
+		t.Errorf("zone shift moved the capture instant by %v", d)
+	}
+
+	if err := x.ShiftTimes(time.Second, true); err == nil {
+		t.Errorf("shifted time zone by a second")
+	}
+	x.setTag(DateTimeDigitized, testString(t, "    :  :     :  :  "))
+	if err := x.ShiftTimes(0, false); err != nil {
+		t.Errorf("blank time stamp: %v", err)
+	}
+	x.setTag(DateTimeDigitized, testString(t, "yesterday"))
+	if err := x.ShiftTimes(time.Hour, false); err == nil {
+		t.Errorf("shifted invalid time stamp")
+	}
+	if got, _ := x.stringVal(DateTime); got != "2020:12:31 17:30:01" {
+		t.Errorf("failed shift applied: DateTime = %q", got)
+	}
+}
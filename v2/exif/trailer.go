@@ -0,0 +1,202 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// TrailerKind identifies the data found after the end of a JPEG image.
+type TrailerKind int
+
+const (
+	TrailerUnknown TrailerKind = iota
+	TrailerSamsung             // Samsung SEFH/SEFT tagged blocks
+	TrailerImage               // appended JPEG image (e.g. depth map)
+	TrailerVideo               // appended MP4 video (e.g. motion photo)
+	TrailerPadding             // zero bytes
+)
+
+var trailerNames = map[TrailerKind]string{
+	TrailerUnknown: "unknown",
+	TrailerSamsung: "Samsung",
+	TrailerImage:   "JPEG image",
+	TrailerVideo:   "MP4 video",
+	TrailerPadding: "padding",
+}
+
+func (k TrailerKind) String() string {
+	if s, ok := trailerNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("TrailerKind(%d)", int(k))
+}
+
+// Trailer is a block of data appended after the end of image (EOI) marker
+// of a JPEG file.
+type Trailer struct {
+	Kind TrailerKind
+	// Offset and Length give the byte range of the block in the file.
+	Offset, Length int64
+}
+
+func (t Trailer) String() string {
+	return fmt.Sprintf("%v trailer at %d (%d bytes)", t.Kind, t.Offset, t.Length)
+}
+
+// Trailers reports the non-standard data appended after the JPEG image of
+// size bytes in r, such as the tagged blocks of Samsung phones or the
+// images and videos of Google motion photos.  Programs rewriting JPEG files
+// need it to keep or strip such data deliberately.  It returns nil if the
+// image ends at EOI.
+func Trailers(r io.ReaderAt, size int64) ([]Trailer, error) {
+	end, err := jpegEnd(r, 0, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Samsung blocks always come last, as they are located from the end.
+	samsung := samsungTrailer(r, end, size)
+	limit := size
+	if samsung != nil {
+		limit = samsung.Offset
+	}
+
+	var ts []Trailer
+	for p := end; p < limit; {
+		var head [8]byte
+		n, _ := r.ReadAt(head[:], p)
+		switch {
+		case n >= 2 && head[0] == 0xFF && head[1] == 0xD8:
+			if e, err := jpegEnd(r, p, limit); err == nil {
+				ts = append(ts, Trailer{TrailerImage, p, e - p})
+				p = e
+				continue
+			}
+		case n == 8 && string(head[4:]) == "ftyp":
+			ts = append(ts, Trailer{TrailerVideo, p, limit - p})
+			p = limit
+			continue
+		case isZeros(r, p, limit):
+			ts = append(ts, Trailer{TrailerPadding, p, limit - p})
+			p = limit
+			continue
+		}
+		ts = append(ts, Trailer{TrailerUnknown, p, limit - p})
+		break
+	}
+	if samsung != nil {
+		ts = append(ts, *samsung)
+	}
+	return ts, nil
+}
+
+// jpegEnd returns the offset just past the EOI marker of the JPEG image
+// starting at start in r, walking its segments and scanning its entropy
+// coded data up to limit.
+func jpegEnd(r io.ReaderAt, start, limit int64) (int64, error) {
+	br := bufio.NewReader(io.NewSectionReader(r, start, limit-start))
+	var soi [2]byte
+	if _, err := io.ReadFull(br, soi[:]); err != nil || soi != [2]byte{0xFF, 0xD8} {
+		return 0, errors.New("exif: not a JPEG image")
+	}
+	pos := start + 2
+	inScan := false
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return 0, errors.New("exif: JPEG image has no EOI marker")
+		}
+		pos++
+		if c != 0xFF {
+			if inScan {
+				continue
+			}
+			return 0, errors.New("exif: invalid JPEG segment marker")
+		}
+		m, err := br.ReadByte()
+		if err != nil {
+			return 0, errors.New("exif: JPEG image has no EOI marker")
+		}
+		pos++
+		switch {
+		case m == jpegEOI:
+			return pos, nil
+		case m == 0xFF:
+			// fill byte
+			br.UnreadByte()
+			pos--
+			continue
+		case m == 0x00 || m == 0x01 || (m >= 0xD0 && m <= 0xD7):
+			// stuffed 0xFF byte or standalone marker
+			continue
+		}
+		var l [2]byte
+		if _, err := io.ReadFull(br, l[:]); err != nil {
+			return 0, errors.New("exif: truncated JPEG segment")
+		}
+		size := int(binary.BigEndian.Uint16(l[:]))
+		if size < 2 {
+			return 0, errors.New("exif: invalid JPEG segment length")
+		}
+		if _, err := br.Discard(size - 2); err != nil {
+			return 0, errors.New("exif: truncated JPEG segment")
+		}
+		pos += int64(size)
+		// Entropy coded data follows each SOS segment.
+		inScan = m == jpegSOS
+	}
+}
+
+// samsungTrailer returns the Samsung trailer ending r at size, if any.  It
+// ends with an "SEFH" directory of blocks, its length and "SEFT"; each
+// directory entry gives the distance back from the directory to a block.
+func samsungTrailer(r io.ReaderAt, end, size int64) *Trailer {
+	var tail [8]byte
+	if size-end < 8 {
+		return nil
+	}
+	if _, err := r.ReadAt(tail[:], size-8); err != nil || string(tail[4:]) != "SEFT" {
+		return nil
+	}
+	dirLen := int64(binary.LittleEndian.Uint32(tail[:]))
+	dir := size - 8 - dirLen
+	if dirLen < 12 || dir < end {
+		return nil
+	}
+	hdr := make([]byte, dirLen)
+	if _, err := r.ReadAt(hdr, dir); err != nil || string(hdr[:4]) != "SEFH" {
+		return nil
+	}
+	start := dir
+	n := int64(binary.LittleEndian.Uint32(hdr[8:]))
+	for i := int64(0); i < n && 12+12*i+12 <= dirLen; i++ {
+		e := hdr[12+12*i:]
+		if back := int64(binary.LittleEndian.Uint32(e[4:])); dir-back >= end && dir-back < start {
+			start = dir - back
+		}
+	}
+	return &Trailer{TrailerSamsung, start, size - start}
+}
+
+// isZeros reports whether r holds only zero bytes from start to end.
+func isZeros(r io.ReaderAt, start, end int64) bool {
+	buf := make([]byte, 4096)
+	for p := start; p < end; {
+		n := int64(len(buf))
+		if end-p < n {
+			n = end - p
+		}
+		if _, err := r.ReadAt(buf[:n], p); err != nil {
+			return false
+		}
+		if len(bytes.Trim(buf[:n], "\x00")) != 0 {
+			return false
+		}
+		p += n
+	}
+	return true
+}
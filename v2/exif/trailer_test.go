@@ -0,0 +1,73 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// samsungBlock returns a Samsung trailer holding a single block of data.
+func samsungBlock(data string) []byte {
+	le := binary.LittleEndian
+	dir := make([]byte, 24)
+	copy(dir, "SEFH")
+	le.PutUint32(dir[4:], 106)
+	le.PutUint32(dir[8:], 1)
+	le.PutUint16(dir[14:], 0x0A01)
+	le.PutUint32(dir[16:], uint32(len(data)))
+	le.PutUint32(dir[20:], uint32(len(data)))
+	tail := make([]byte, 8)
+	le.PutUint32(tail, uint32(len(dir)))
+	copy(tail[4:], "SEFT")
+	return append(append([]byte(data), dir...), tail...)
+}
+
+func TestTrailers(t *testing.T) {
+	img, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// image data with a stuffed 0xFF byte and a restart marker
+	depth := jpegNoExif(0)
+	depth = append(depth[:len(depth)-2], "\x12\xFF\x00\x34\xFF\xD0\x56\xFF\xD9"...)
+	video := []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	samsung := samsungBlock("Image_UTC_Data1500000000000")
+	n := int64(len(img))
+
+	tests := []struct {
+		name     string
+		trailers [][]byte
+		want     []Trailer
+	}{
+		{"none", nil, nil},
+		{"padding", [][]byte{make([]byte, 10)}, []Trailer{{TrailerPadding, n, 10}}},
+		{"samsung", [][]byte{samsung}, []Trailer{{TrailerSamsung, n, int64(len(samsung))}}},
+		{
+			"motion photo",
+			[][]byte{depth, video, samsung},
+			[]Trailer{
+				{TrailerImage, n, int64(len(depth))},
+				{TrailerVideo, n + int64(len(depth)), int64(len(video))},
+				{TrailerSamsung, n + int64(len(depth)+len(video)), int64(len(samsung))},
+			},
+		},
+		{"unknown", [][]byte{[]byte("garbage")}, []Trailer{{TrailerUnknown, n, 7}}},
+	}
+	for _, test := range tests {
+		data := append([]byte{}, img...)
+		for _, tr := range test.trailers {
+			data = append(data, tr...)
+		}
+		got, err := Trailers(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
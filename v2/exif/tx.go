@@ -0,0 +1,135 @@
+package exif
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// ExifTx stages changes to the fields of an Exif within Edit.
+type ExifTx struct {
+	x   *Exif
+	ops []txOp
+}
+
+// txOp is a staged change: a Set, or a Delete if tag is nil.
+type txOp struct {
+	name FieldName
+	tag  *tiff.Tag
+}
+
+// Set stages storing tag as the field name (see Exif.Set).
+func (tx *ExifTx) Set(name FieldName, tag *tiff.Tag) {
+	tx.ops = append(tx.ops, txOp{name, tag})
+}
+
+// Delete stages the removal of the field name (see Exif.Delete).
+func (tx *ExifTx) Delete(name FieldName) {
+	tx.ops = append(tx.ops, txOp{name, nil})
+}
+
+// Get returns the field name as it will be once the staged changes are
+// applied.
+func (tx *ExifTx) Get(name FieldName) (*tiff.Tag, error) {
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		if op := tx.ops[i]; op.name == name {
+			if op.tag == nil {
+				return nil, TagNotPresentError(name)
+			}
+			return op.tag, nil
+		}
+	}
+	return tx.x.Get(name)
+}
+
+// An EditError lists the problems that made Edit reject a transaction.
+type EditError []Warning
+
+func (e EditError) Error() string {
+	msgs := make([]string, len(e))
+	for i, w := range e {
+		msgs[i] = w.String()
+	}
+	return "exif: invalid edit: " + strings.Join(msgs, "; ")
+}
+
+// refFields pairs GPS fields with the fields giving their reference
+// (hemisphere, unit or direction), which must be present with them.
+var refFields = map[FieldName]FieldName{
+	GPSLatitude:      GPSLatitudeRef,
+	GPSLongitude:     GPSLongitudeRef,
+	GPSAltitude:      GPSAltitudeRef,
+	GPSSpeed:         GPSSpeedRef,
+	GPSTrack:         GPSTrackRef,
+	GPSImgDirection:  GPSImgDirectionRef,
+	GPSDestLatitude:  GPSDestLatitudeRef,
+	GPSDestLongitude: GPSDestLongitudeRef,
+	GPSDestBearing:   GPSDestBearingRef,
+	GPSDestDistance:  GPSDestDistanceRef,
+}
+
+// Edit calls fn to stage changes to the fields of x, then applies them all
+// if fn returns nil and they are valid, or none of them.  Changes are
+// invalid if a known field is set with a data type or number of values the
+// EXIF specification does not allow, or if a GPS field is left without its
+// reference field (e.g. GPSLatitude without GPSLatitudeRef); these are
+// reported as an EditError.  OnChange hooks see the changes as they are
+// applied.
+func (x *Exif) Edit(fn func(tx *ExifTx) error) error {
+	tx := &ExifTx{x: x}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if errs := tx.validate(); len(errs) > 0 {
+		return errs
+	}
+	for _, op := range tx.ops {
+		if op.tag == nil {
+			x.Delete(op.name)
+		} else {
+			x.Set(op.name, op.tag)
+		}
+	}
+	return nil
+}
+
+func (tx *ExifTx) validate() EditError {
+	var errs EditError
+	for _, op := range tx.ops {
+		if op.tag == nil {
+			continue
+		}
+		_, bare := op.name.Group()
+		spec, ok := fieldSpecs[bare]
+		if !ok {
+			continue
+		}
+		typeOK := false
+		for _, typ := range spec.types {
+			typeOK = typeOK || op.tag.Type == typ
+		}
+		if !typeOK {
+			errs = append(errs, Warning{op.name, fmt.Sprintf("type %d not allowed", op.tag.Type)})
+		} else if spec.count != 0 && op.tag.Count != spec.count && op.tag.Format() != tiff.StringVal {
+			errs = append(errs, Warning{op.name, fmt.Sprintf("%d values, want %d", op.tag.Count, spec.count)})
+		}
+	}
+
+	checked := map[FieldName]bool{}
+	for _, op := range tx.ops {
+		_, bare := op.name.Group()
+		for val, ref := range refFields {
+			if (bare != val && bare != ref) || checked[val] {
+				continue
+			}
+			checked[val] = true
+			_, valErr := tx.Get(val)
+			_, refErr := tx.Get(ref)
+			if valErr == nil && refErr != nil {
+				errs = append(errs, Warning{val, fmt.Sprintf("set without %v", ref)})
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,62 @@
+package exif
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestEdit(t *testing.T) {
+	x := &Exif{}
+	x.Set(Artist, testString(t, "A"))
+	var changes int
+	x.OnChange(func(FieldName, *tiff.Tag, *tiff.Tag) { changes++ })
+
+	lat := testTag(t, tiff.DTRational, 3, make([]byte, 24))
+	err := x.Edit(func(tx *ExifTx) error {
+		tx.Set(GPSLatitude, lat)
+		tx.Set(Copyright, testString(t, "C"))
+		tx.Delete(Artist)
+		if _, err := tx.Get(Artist); err == nil {
+			t.Errorf("staged delete not visible")
+		}
+		return nil
+	})
+	if verr, ok := err.(EditError); !ok || len(verr) != 1 || verr[0].Field != GPSLatitude {
+		t.Fatalf("got error %v, want missing GPSLatitudeRef", err)
+	}
+	if _, err := x.Get(Artist); err != nil || changes != 0 {
+		t.Errorf("rejected edit applied: %v, %d changes", err, changes)
+	}
+
+	err = x.Edit(func(tx *ExifTx) error {
+		tx.Set(Orientation, testString(t, "up"))
+		tx.Set(Orientation, testTag(t, tiff.DTShort, 2, []byte{0, 1, 0, 1}))
+		return nil
+	})
+	if verr, ok := err.(EditError); !ok || len(verr) != 2 {
+		t.Errorf("got error %v, want wrong type and count", err)
+	}
+
+	abort := errors.New("abort")
+	if err := x.Edit(func(tx *ExifTx) error {
+		tx.Delete(Artist)
+		return abort
+	}); err != abort {
+		t.Errorf("got error %v, want %v", err, abort)
+	}
+
+	err = x.Edit(func(tx *ExifTx) error {
+		tx.Set(GPSLatitude, lat)
+		tx.Set(GPSLatitudeRef, testString(t, "N"))
+		tx.Delete(Artist)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Get(GPSLatitudeRef); err != nil || changes != 3 {
+		t.Errorf("edit not applied: %v, %d changes", err, changes)
+	}
+}
@@ -0,0 +1,327 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// MP4 and QuickTime (MOV) videos do not carry EXIF data as such, but
+// cameras and phones record the same information in QuickTime atoms:
+//
+//    moov/mvhd             - creation time (seconds since 1904, UTC)
+//    moov/udta/©mak, ©mod  - make and model
+//    moov/udta/©day        - creation date (ISO 8601)
+//    moov/udta/©xyz        - location (ISO 6709)
+//    moov/meta/keys+ilst   - com.apple.quicktime.* metadata items
+//
+// Some cameras also embed a literal EXIF block as an Exif atom in udta.  The
+// values found are presented as the equivalent EXIF fields.
+
+// quickTimeEpoch is the zero time of QuickTime time stamps.
+var quickTimeEpoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// QuickTime metadata item keys (moov/meta/keys).
+const (
+	qtKeyLocation     = "com.apple.quicktime.location.ISO6709"
+	qtKeyMake         = "com.apple.quicktime.make"
+	qtKeyModel        = "com.apple.quicktime.model"
+	qtKeyCreationDate = "com.apple.quicktime.creationdate"
+)
+
+// quickTimeTopBoxes are the box types that may start a QuickTime file
+// lacking an ftyp box.
+var quickTimeTopBoxes = map[string]bool{
+	"moov": true,
+	"mdat": true,
+	"wide": true,
+	"free": true,
+	"skip": true,
+	"pnot": true,
+}
+
+// isQuickTime reports whether header (the first 8 bytes of a file) looks
+// like the start of an (old style, ftyp-less) QuickTime file.
+func isQuickTime(header []byte) bool {
+	return len(header) >= 8 && quickTimeTopBoxes[string(header[4:8])]
+}
+
+// video holds the metadata collected from an MP4/MOV container.
+type video struct {
+	make, model string
+	created     time.Time
+	createdStr  string // ISO 8601, keeps the recorded local time
+	location    string // ISO 6709
+	exif        []byte // literal Exif atom, if any
+}
+
+// decodeVideo streams through the top-level boxes of an MP4/MOV file and
+// parses its moov box.  Media data is skipped.
+func decodeVideo(r io.Reader) (*video, error) {
+	for {
+		b, _, err := readBoxHeader(r)
+		if err == io.EOF {
+			return nil, errors.New("exif: no moov box found")
+		} else if err != nil {
+			return nil, err
+		}
+		if b.size < 0 {
+			if b.typ != "moov" {
+				return nil, errors.New("exif: no moov box found")
+			}
+			body, err := ioutil.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return parseMoov(body)
+		}
+		if b.typ != "moov" {
+			if _, err := io.CopyN(ioutil.Discard, r, b.size); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		body, err := readN(r, b.size)
+		if err != nil {
+			return nil, err
+		}
+		return parseMoov(body)
+	}
+}
+
+func parseMoov(moov []byte) (*video, error) {
+	children, err := boxes(moov)
+	if err != nil {
+		return nil, err
+	}
+	v := &video{}
+
+	// mvhd: version(1), flags(3), then creation time as 32 or 64 bits.
+	if bs := children["mvhd"]; len(bs) > 0 {
+		mvhd := bs[0]
+		var secs uint64
+		switch {
+		case len(mvhd) >= 12 && mvhd[0] == 1:
+			secs = binary.BigEndian.Uint64(mvhd[4:])
+		case len(mvhd) >= 8:
+			secs = uint64(binary.BigEndian.Uint32(mvhd[4:]))
+		}
+		if secs != 0 {
+			v.created = quickTimeEpoch.Add(time.Duration(secs) * time.Second)
+		}
+	}
+
+	for _, udta := range children["udta"] {
+		items, err := boxes(udta)
+		if err != nil {
+			continue
+		}
+		for typ, dst := range map[string]*string{
+			"\xa9mak": &v.make,
+			"\xa9mod": &v.model,
+			"\xa9day": &v.createdStr,
+			"\xa9xyz": &v.location,
+		} {
+			if bs := items[typ]; len(bs) > 0 {
+				*dst = udtaString(bs[0])
+			}
+		}
+		for _, typ := range []string{"Exif", "exif"} {
+			if bs := items[typ]; len(bs) > 0 {
+				v.exif = bytes.TrimPrefix(bs[0], []byte("Exif\x00\x00"))
+			}
+		}
+	}
+
+	for _, meta := range children["meta"] {
+		for key, val := range metaItems(meta) {
+			switch key {
+			case qtKeyMake:
+				v.make = val
+			case qtKeyModel:
+				v.model = val
+			case qtKeyCreationDate:
+				v.createdStr = val
+			case qtKeyLocation:
+				v.location = val
+			}
+		}
+	}
+	return v, nil
+}
+
+// udtaString decodes a QuickTime user data text item: a 16 bit length, a
+// 16 bit language code and the text.
+func udtaString(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	data = data[4:]
+	if n > len(data) {
+		n = len(data)
+	}
+	return strings.TrimRight(string(data[:n]), "\x00")
+}
+
+// metaItems returns the UTF-8 metadata items of a QuickTime meta box, keyed
+// by their keys box names.
+func metaItems(meta []byte) map[string]string {
+	children, err := boxes(meta)
+	if err != nil || len(children["keys"]) == 0 || len(children["ilst"]) == 0 {
+		return nil
+	}
+
+	// keys: version/flags(4), count(4), then (size(4), namespace(4), name)
+	data := children["keys"][0]
+	if len(data) < 8 {
+		return nil
+	}
+	var keys []string
+	for data = data[8:]; len(data) >= 8; {
+		size := binary.BigEndian.Uint32(data)
+		if size < 8 || uint64(size) > uint64(len(data)) {
+			break
+		}
+		keys = append(keys, string(data[8:size]))
+		data = data[size:]
+	}
+
+	items, err := boxes(children["ilst"][0])
+	if err != nil {
+		return nil
+	}
+	m := map[string]string{}
+	for typ, bs := range items {
+		i := int(binary.BigEndian.Uint32([]byte(typ))) - 1
+		if i < 0 || i >= len(keys) {
+			continue
+		}
+		// data: type(4), locale(4), value; type 1 is UTF-8.
+		data := descend(bs[0], "data")
+		if len(data) < 8 || binary.BigEndian.Uint32(data) != 1 {
+			continue
+		}
+		m[keys[i]] = string(data[8:])
+	}
+	return m
+}
+
+// iso6709 matches the decimal degree form of ISO 6709 locations, e.g.
+// "+37.7749-122.4194+010.000/".
+var iso6709 = regexp.MustCompile(`^([+-][0-9.]+)([+-][0-9.]+)([+-][0-9.]+)?`)
+
+// parseISO6709 parses a decimal degree ISO 6709 location.  hasAlt is false
+// if the location carries no altitude.
+func parseISO6709(s string) (lat, long, alt float64, hasAlt bool, err error) {
+	m := iso6709.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, 0, false, fmt.Errorf("exif: malformed ISO 6709 location %q", s)
+	}
+	if lat, err = strconv.ParseFloat(m[1], 64); err != nil {
+		return
+	}
+	if long, err = strconv.ParseFloat(m[2], 64); err != nil {
+		return
+	}
+	if m[3] != "" {
+		if alt, err = strconv.ParseFloat(m[3], 64); err != nil {
+			return
+		}
+		hasAlt = true
+	}
+	if math.Abs(lat) > 90 || math.Abs(long) > 180 {
+		err = fmt.Errorf("exif: ISO 6709 location %q out of range", s)
+	}
+	return
+}
+
+// asciiTag builds a tag holding the text s.
+func asciiTag(id uint16, s string) (*tiff.Tag, error) {
+	return tiff.NewTag(id, tiff.DTAscii, binary.BigEndian, s)
+}
+
+// loadVideo builds an Exif from the metadata of an MP4/MOV file.  A literal
+// Exif atom becomes x.Tiff and x.Raw, so its fields are loaded by the
+// registered parsers and take precedence over the QuickTime metadata.
+func loadVideo(v *video) (*Exif, error) {
+	x := &Exif{}
+	if v.exif != nil {
+		tif, err := tiff.Decode(bytes.NewReader(v.exif))
+		if err != nil {
+			return nil, fmt.Errorf("exif: Exif atom decode failed: %v", err)
+		}
+		x.Tiff, x.Raw = tif, v.exif
+	} else {
+		x.Tiff = &tiff.Tiff{Order: binary.BigEndian, Dirs: []*tiff.Dir{{}}}
+	}
+
+	defer x.setGroup("")
+	add := func(name FieldName, group string, tag *tiff.Tag, err error) {
+		if err == nil {
+			x.setGroup(group)
+			x.setTag(name, tag)
+		}
+	}
+
+	if v.make != "" {
+		t, err := asciiTag(0x010F, v.make)
+		add(Make, GroupIFD0, t, err)
+	}
+	if v.model != "" {
+		t, err := asciiTag(0x0110, v.model)
+		add(Model, GroupIFD0, t, err)
+	}
+	created := v.created
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, v.createdStr); err == nil {
+			// keep the recorded local time, as EXIF does
+			created = t
+			break
+		}
+	}
+	if !created.IsZero() {
+		t, err := asciiTag(0x9003, created.Format("2006:01:02 15:04:05"))
+		add(DateTimeOriginal, GroupExif, t, err)
+	}
+	if lat, long, alt, hasAlt, err := parseISO6709(v.location); err == nil {
+		latRef, longRef := "N", "E"
+		if lat < 0 {
+			latRef = "S"
+		}
+		if long < 0 {
+			longRef = "W"
+		}
+		t, err := asciiTag(0x0001, latRef)
+		add(GPSLatitudeRef, GroupGPS, t, err)
+		t, err = tiff.NewTag(0x0002, tiff.DTRational, binary.BigEndian, degrees(lat)...)
+		add(GPSLatitude, GroupGPS, t, err)
+		t, err = asciiTag(0x0003, longRef)
+		add(GPSLongitudeRef, GroupGPS, t, err)
+		t, err = tiff.NewTag(0x0004, tiff.DTRational, binary.BigEndian, degrees(long)...)
+		add(GPSLongitude, GroupGPS, t, err)
+		if hasAlt {
+			ref := 0
+			if alt < 0 {
+				ref = 1
+			}
+			t, err = tiff.NewTag(0x0005, tiff.DTByte, binary.BigEndian, ref)
+			add(GPSAltitudeRef, GroupGPS, t, err)
+			mm := int64(math.Round(math.Abs(alt) * 1000))
+			t, err = tiff.NewTag(0x0006, tiff.DTRational, binary.BigEndian, [2]int64{mm, 1000})
+			add(GPSAltitude, GroupGPS, t, err)
+		}
+	}
+
+	return x, nil
+}
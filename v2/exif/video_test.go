@@ -0,0 +1,155 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+func udtaText(typ, s string) []byte {
+	hdr := make([]byte, 4)
+	binary.BigEndian.PutUint16(hdr, uint16(len(s)))
+	return box(typ, hdr, []byte(s))
+}
+
+// quickTimeMeta builds a moov/meta box holding UTF-8 items for keys.
+func quickTimeMeta(items map[string]string) []byte {
+	var keys, ilst bytes.Buffer
+	keys.Write(make([]byte, 4))
+	binary.Write(&keys, binary.BigEndian, uint32(len(items)))
+	i := uint32(0)
+	for k, v := range items {
+		i++
+		binary.Write(&keys, binary.BigEndian, uint32(8+len(k)))
+		keys.WriteString("mdta" + k)
+		idx := make([]byte, 4)
+		binary.BigEndian.PutUint32(idx, i)
+		ilst.Write(box(string(idx), box("data", []byte{0, 0, 0, 1, 0, 0, 0, 0}, []byte(v))))
+	}
+	return box("meta", fullBox("hdlr", 0, 0x6d647461), box("keys", keys.Bytes()), box("ilst", ilst.Bytes()))
+}
+
+func TestDecodeVideo(t *testing.T) {
+	// 2019-07-04 12:00:00 UTC in QuickTime time.
+	created := time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC)
+	mvhd := fullBox("mvhd", uint32(created.Sub(quickTimeEpoch)/time.Second))
+
+	tests := []struct {
+		name  string
+		file  []byte
+		make  string
+		model string
+		date  string
+		lat   float64
+		long  float64
+		alt   string
+	}{
+		{
+			name: "MP4 udta",
+			file: append(box("ftyp", []byte("mp42\x00\x00\x00\x00isommp42")),
+				box("moov", mvhd, box("udta",
+					udtaText("\xa9mak", "GoPro"),
+					udtaText("\xa9mod", "HERO9 Black"),
+					udtaText("\xa9xyz", "+37.7749-122.4194+010.500/"),
+				))...),
+			make: "GoPro", model: "HERO9 Black", date: "2019:07:04 12:00:00",
+			lat: 37.7749, long: -122.4194, alt: `"10500/1000"`,
+		},
+		{
+			// old style MOV: no ftyp and moov after mdat
+			name: "MOV keys",
+			file: append(box("mdat", make([]byte, 100)),
+				box("moov", mvhd, quickTimeMeta(map[string]string{
+					qtKeyMake:         "Apple",
+					qtKeyModel:        "iPhone 12",
+					qtKeyCreationDate: "2019-07-04T05:00:00-0700",
+					qtKeyLocation:     "-33.8688+151.2093/",
+				}))...),
+			make: "Apple", model: "iPhone 12", date: "2019:07:04 05:00:00",
+			lat: -33.8688, long: 151.2093,
+		},
+		{
+			name: "Exif atom",
+			file: append(box("ftyp", []byte("qt  \x00\x00\x00\x00qt  ")),
+				box("moov", mvhd, box("udta",
+					udtaText("\xa9mak", "QuickTime Make"),
+					box("Exif", []byte("Exif\x00\x00"), buildTIFF(asciiEntry(0x010F, "Exif Make"), asciiEntry(0x0110, "Exif Model"))),
+				))...),
+			make: "Exif Make", model: "Exif Model", date: "2019:07:04 12:00:00",
+		},
+	}
+
+	for _, tt := range tests {
+		x, err := Decode(bytes.NewReader(tt.file))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		for name, want := range map[FieldName]string{Make: tt.make, Model: tt.model, DateTimeOriginal: tt.date} {
+			tag, err := x.Get(name)
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+				continue
+			}
+			if got, _ := tag.StringVal(); got != want {
+				t.Errorf("%s: %v = %q, want %q", tt.name, name, got, want)
+			}
+		}
+		if _, err := x.Get("Exif/DateTimeOriginal"); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+
+		lat, long, err := x.LatLong()
+		if tt.lat == 0 {
+			if err == nil {
+				t.Errorf("%s: got a location", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if math.Abs(lat-tt.lat) > 1e-6 || math.Abs(long-tt.long) > 1e-6 {
+			t.Errorf("%s: LatLong = %v, %v; want %v, %v", tt.name, lat, long, tt.lat, tt.long)
+		}
+		tag, err := x.Get(GPSAltitude)
+		if tt.alt == "" {
+			if err == nil {
+				t.Errorf("%s: got an altitude", tt.name)
+			}
+		} else if err != nil || tag.String() != tt.alt {
+			t.Errorf("%s: GPSAltitude = %v, %v; want %v", tt.name, tag, err, tt.alt)
+		}
+	}
+}
+
+func TestDecodeVideoHugeMoov(t *testing.T) {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x00\x00"))
+	for name, moov := range map[string]string{
+		"largesize": "\x00\x00\x00\x01moov\x40\x00\x00\x00\x00\x00\x00\x00",
+		"size":      "\xFF\xFF\xFF\xF0moov",
+	} {
+		file := append(append([]byte{}, ftyp...), moov...)
+		if _, err := Decode(bytes.NewReader(file)); err == nil {
+			t.Errorf("%s: truncated file decoded", name)
+		}
+	}
+}
+
+func TestDecodeVideoDegrees(t *testing.T) {
+	file := append(box("ftyp", []byte("mp42\x00\x00\x00\x00isommp42")),
+		box("moov", box("udta", udtaText("\xa9xyz", "+00.99999999-010.5000000/")))...)
+	x, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[FieldName]string{
+		GPSLatitude:  `["1/1","0/1","0/1000"]`,
+		GPSLongitude: `["10/1","30/1","0/1000"]`,
+	} {
+		if tag, err := x.Get(name); err != nil || tag.String() != want {
+			t.Errorf("%v = %v, %v; want %v", name, tag, err, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package exif
+
+import "fmt"
+
+// A Warning describes a deviation from the EXIF specification that was
+// tolerated while decoding.
+type Warning struct {
+	// Field is the field concerned, if any.
+	Field FieldName
+	Msg   string
+}
+
+func (w Warning) String() string {
+	if w.Field != "" {
+		return fmt.Sprintf("%v: %v", w.Field, w.Msg)
+	}
+	return w.Msg
+}
+
+// Warnings returns the deviations from the EXIF specification tolerated
+// while decoding x.
+func (x *Exif) Warnings() []Warning {
+	return x.warnings
+}
@@ -0,0 +1,135 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestMalformedExifIntro(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, intro := range []string{"Exif\x00\xFF", "Exif\x00", "Exif"} {
+		seg := append([]byte(intro), data...)
+		jpg := append([]byte{0xFF, 0xD8, 0xFF, 0xE1, byte((len(seg) + 2) >> 8), byte(len(seg) + 2)}, seg...)
+
+		if _, err := Decode(bytes.NewReader(jpg)); err == nil {
+			t.Errorf("%q: strict decode succeeded", intro)
+		}
+
+		x, err := (&Decoder{Permissive: true}).Decode(bytes.NewReader(jpg))
+		if err != nil {
+			t.Errorf("%q: %v", intro, err)
+			continue
+		}
+		if tag, err := x.Get(Model); err != nil || tag.String() != `"Synth 1"` {
+			t.Errorf("%q: Model is %v, %v", intro, tag, err)
+		}
+		if ws := x.Warnings(); len(ws) != 1 || !strings.Contains(ws[0].String(), "malformed Exif intro") {
+			t.Errorf("%q: warnings %v", intro, ws)
+		}
+	}
+}
+
+// swapIFD rewrites the little endian IFD at off in data in big endian
+// order, values included.
+func swapIFD(data []byte, off uint32) {
+	le, be := binary.LittleEndian, binary.BigEndian
+	// typeSize and unit give the size of the values of each type and of the
+	// integers they are made of.
+	typeSize := map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+	unit := map[uint16]uint32{3: 2, 4: 4, 5: 4, 8: 2, 9: 4, 10: 4, 11: 4, 12: 8}
+
+	n := le.Uint16(data[off:])
+	be.PutUint16(data[off:], n)
+	e := off + 2
+	for i := uint16(0); i < n; i, e = i+1, e+12 {
+		typ, count := le.Uint16(data[e+2:]), le.Uint32(data[e+4:])
+		be.PutUint16(data[e:], le.Uint16(data[e:]))
+		be.PutUint16(data[e+2:], typ)
+		be.PutUint32(data[e+4:], count)
+
+		val := data[e+8 : e+12]
+		if size := typeSize[typ] * count; size > 4 {
+			valOff := le.Uint32(val)
+			be.PutUint32(val, valOff)
+			val = data[valOff : valOff+size]
+		}
+		if size := unit[typ]; size > 1 {
+			for k := uint32(0); k+size <= uint32(len(val)); k += size {
+				for j := uint32(0); j < size/2; j++ {
+					val[k+j], val[k+size-1-j] = val[k+size-1-j], val[k+j]
+				}
+			}
+		}
+	}
+	be.PutUint32(data[e:], le.Uint32(data[e:]))
+}
+
+func TestSwappedSubIFD(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_basic.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ptr, err := x.Get(ExifIFDPointer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	off, _ := ptr.Int(0)
+	swapIFD(data, uint32(off))
+
+	if x, _ := Decode(bytes.NewReader(data)); x != nil {
+		if _, err := x.Get(ExposureTime); err == nil {
+			t.Errorf("strict decode read swapped sub-IFD")
+		}
+	}
+	x, err = (&Decoder{Permissive: true}).Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag, err := x.Get(ExposureTime); err != nil || tag.String() != `"1/250"` {
+		t.Errorf("ExposureTime is %v, %v", tag, err)
+	}
+	if tag, err := x.Get(ISOSpeedRatings); err != nil || tag.String() != `200` {
+		t.Errorf("ISOSpeedRatings is %v, %v", tag, err)
+	}
+	if ws := x.Warnings(); len(ws) != 1 || ws[0].Field != ExifIFDPointer {
+		t.Errorf("warnings %v", ws)
+	}
+}
+
+func TestLoadTagsWarnings(t *testing.T) {
+	order := binary.BigEndian
+	first, _ := tiff.NewTag(0x0112, tiff.DTShort, order, 1)
+	second, _ := tiff.NewTag(0x0112, tiff.DTShort, order, 6)
+	res, _ := tiff.NewTag(0x011A, tiff.DTRational, order, [2]int64{72, 1}, [2]int64{72, 1})
+	model, _ := tiff.NewTag(0x0110, tiff.DTAscii, order, "Synth")
+
+	x := &Exif{}
+	x.LoadTags(&tiff.Dir{Tags: []*tiff.Tag{first, res, second, model}}, exifFields, false)
+	if tag, _ := x.Get(Orientation); tag != second {
+		t.Errorf("Orientation = %v, want the later tag", tag)
+	}
+	want := []string{"XResolution: 2 values, want 1", "Orientation: duplicate tag replaces the earlier one"}
+	ws := x.Warnings()
+	if len(ws) != len(want) {
+		t.Fatalf("warnings %v, want %v", ws, want)
+	}
+	for i, w := range ws {
+		if w.String() != want[i] {
+			t.Errorf("warning %d = %q, want %q", i, w, want[i])
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// jpegAPP0 is the marker of the JFIF segment.
+const jpegAPP0 = 0xE0
+
+// WriteJPEG copies the JPEG image src to dst with its EXIF APP1 segment
+// replaced by the fields of x, encoded like MarshalBinary.  The segment
+// takes the place of the first EXIF segment of src, whose continuation
+// segments are dropped, or is inserted after the JFIF segment (or the SOI
+// marker) if src has none.  The other segments and the entropy-coded image
+// data are copied unchanged, so the image is not re-encoded.
+func WriteJPEG(dst io.Writer, src io.Reader, x *Exif) error {
+	data, err := x.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return WriteJPEGRaw(dst, src, data)
+}
+
+// WriteJPEGRaw is like WriteJPEG, but writes the TIFF structure data, as
+// held by Exif.Raw, unchanged.  It places data that Encode would not
+// produce, such as deliberately corrupted test fixtures, in an image.
+func WriteJPEGRaw(dst io.Writer, src io.Reader, data []byte) error {
+	if 2+6+len(data) > 0xFFFF {
+		return errors.New("exif: EXIF data too large for an APP1 segment")
+	}
+	app1 := make([]byte, 4, 4+6+len(data))
+	app1[0], app1[1] = 0xFF, jpeg_APP1
+	binary.BigEndian.PutUint16(app1[2:], uint16(2+6+len(data)))
+	app1 = append(append(app1, "Exif\x00\x00"...), data...)
+	return copyJPEG(dst, src, app1)
+}
+
+// copyJPEG copies the JPEG image src to dst with its EXIF segments replaced
+// by the segment app1, as described for WriteJPEG.  A nil app1 drops them.
+func copyJPEG(dst io.Writer, src io.Reader, app1 []byte) error {
+	r := bufio.NewReader(src)
+	var soi [2]byte
+	if _, err := io.ReadFull(r, soi[:]); err != nil {
+		return err
+	}
+	if soi[0] != 0xFF || soi[1] != 0xD8 {
+		return errors.New("exif: not a JPEG image")
+	}
+	w := bufio.NewWriter(dst)
+	w.Write(soi[:])
+
+	// The segments preceding the first EXIF segment are held in head until
+	// it is found, as app1 goes after the JFIF segments, at jfifEnd, if
+	// there is none.
+	var head bytes.Buffer
+	jfifEnd, jfif := 0, true
+	written := false
+	for {
+		hdr, err := r.Peek(2)
+		if err != nil {
+			return errors.New("exif: JPEG image ends before its image data")
+		}
+		if hdr[0] != 0xFF {
+			return errors.New("exif: invalid JPEG segment marker")
+		}
+		m := hdr[1]
+		if m == 0xFF {
+			// fill byte
+			r.Discard(1)
+			continue
+		}
+		if m == jpegSOS || m == jpegEOI || m == 0x01 || (m >= 0xD0 && m <= 0xD7) {
+			break
+		}
+		hdr, err = r.Peek(4 + 6)
+		if len(hdr) < 4 {
+			return errors.New("exif: truncated JPEG segment")
+		}
+		n := int(binary.BigEndian.Uint16(hdr[2:]))
+		if n < 2 {
+			return errors.New("exif: invalid JPEG segment length")
+		}
+		if m == jpeg_APP1 && len(hdr) == 10 && string(hdr[4:]) == "Exif\x00\x00" {
+			if !written {
+				w.Write(head.Bytes())
+				w.Write(app1)
+				written = true
+			}
+			seg := make([]byte, 2+n)
+			if _, err := io.ReadFull(r, seg); err != nil {
+				return err
+			}
+			// Drop the segments continuing the EXIF data too, which
+			// lack the intro.
+			sec := &appSec{marker: jpeg_APP1, data: seg[4:]}
+			sec.appendContinuations(r)
+			continue
+		}
+		if written {
+			if _, err := io.CopyN(w, r, int64(2+n)); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := io.CopyN(&head, r, int64(2+n)); err != nil {
+			return err
+		}
+		if jfif = jfif && m == jpegAPP0; jfif {
+			jfifEnd = head.Len()
+		}
+	}
+	if !written {
+		w.Write(head.Bytes()[:jfifEnd])
+		w.Write(app1)
+		w.Write(head.Bytes()[jfifEnd:])
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return err
+	}
+	return w.Flush()
+}
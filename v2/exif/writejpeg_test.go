@@ -0,0 +1,182 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// jpegScan returns the image data of the JPEG image data, from its SOS
+// marker on.
+func jpegScan(t *testing.T, data []byte) []byte {
+	i := 2
+	for i+4 <= len(data) && data[i+1] != jpegSOS {
+		i += 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+	}
+	if i+4 > len(data) {
+		t.Fatal("no SOS marker")
+	}
+	return data[i:]
+}
+
+func TestWriteJPEG(t *testing.T) {
+	src, err := ioutil.ReadFile(filepath.Join(*dataDir, "testdata", "synth", "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := Decode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := tiff.NewTag(0x0131, tiff.DTAscii, binary.LittleEndian, "goexif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.Set(Software, tag)
+	x.Delete(Model)
+
+	for name, img := range map[string][]byte{
+		"replace": src,
+		"insert":  jpegNoExif(100),
+	} {
+		var buf bytes.Buffer
+		if err := WriteJPEG(&buf, bytes.NewReader(img), x); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := buf.Bytes()
+		if !bytes.Equal(jpegScan(t, out), jpegScan(t, img)) {
+			t.Errorf("%s: image data changed", name)
+		}
+		if n := bytes.Count(out, []byte("Exif\x00\x00")); n != 1 {
+			t.Errorf("%s: %d EXIF segments", name, n)
+		}
+		if name == "insert" && !bytes.HasPrefix(out[2:], img[2:20]) {
+			t.Errorf("%s: EXIF segment inserted before the JFIF segment", name)
+		}
+
+		y, err := Decode(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if tag, err := y.Get(Software); err != nil || tag.String() != `"goexif"` {
+			t.Errorf("%s: Software = %v, %v", name, tag, err)
+		}
+		if _, err := y.Get(Model); err == nil {
+			t.Errorf("%s: deleted Model present", name)
+		}
+		if tag, err := y.Get(ISOSpeedRatings); err != nil || tag.String() != "200" {
+			t.Errorf("%s: ISOSpeedRatings = %v, %v", name, tag, err)
+		}
+		want, _ := x.JpegThumbnail()
+		if got, _ := y.JpegThumbnail(); !bytes.Equal(got, want) {
+			t.Errorf("%s: thumbnail not copied", name)
+		}
+	}
+
+	if err := WriteJPEG(&bytes.Buffer{}, bytes.NewReader([]byte("II*\x00")), x); err == nil {
+		t.Error("non-JPEG source accepted")
+	}
+}
+
+func TestWriteJPEGRaw(t *testing.T) {
+	img := jpegNoExif(100)
+	x := &Exif{}
+	x.Set(Model, testString(t, "Synth 1"))
+	data, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw, enc bytes.Buffer
+	if err := WriteJPEGRaw(&raw, bytes.NewReader(img), data); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJPEG(&enc, bytes.NewReader(img), x); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw.Bytes(), enc.Bytes()) {
+		t.Error("WriteJPEGRaw and WriteJPEG differ")
+	}
+
+	raw.Reset()
+	if err := WriteJPEGRaw(&raw, bytes.NewReader(img), []byte("MM\x00\x2a")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(bytes.NewReader(raw.Bytes())); err == nil || err == ErrNoExif {
+		t.Errorf("Decode of truncated data = %v", err)
+	}
+	if err := WriteJPEGRaw(&bytes.Buffer{}, bytes.NewReader(img), make([]byte, 0x10000)); err == nil {
+		t.Error("oversized data accepted")
+	}
+}
+
+func TestWriteJPEGSegmentOrder(t *testing.T) {
+	ascii := func(id uint16, s string) *tiff.Tag {
+		tag, err := tiff.NewTag(id, tiff.DTAscii, binary.BigEndian, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tag
+	}
+	old := &Exif{}
+	old.Set(Model, ascii(0x0110, "Synth 1"))
+	old.Set(Software, ascii(0x0131, "an old version of a slow editor"))
+	data, err := old.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := func(m byte, data []byte) []byte {
+		return append([]byte{0xFF, m, byte((len(data) + 2) >> 8), byte(len(data) + 2)}, data...)
+	}
+	img := jpegNoExif(10)
+	jfif := img[2:20]
+	icc := seg(0xE2, []byte("ICC_PROFILE\x00\x01\x01"))
+	split := len(data) / 2
+
+	x := &Exif{}
+	x.Set(Model, ascii(0x0110, "Synth 2"))
+	for name, tc := range map[string]struct {
+		src     [][]byte
+		markers []byte
+	}{
+		"replace": {
+			[][]byte{jfif, icc, seg(jpeg_APP1, append([]byte("Exif\x00\x00"), data[:split]...)), seg(jpeg_APP1, data[split:])},
+			[]byte{jpegAPP0, 0xE2, jpeg_APP1},
+		},
+		"insert": {
+			[][]byte{jfif, icc},
+			[]byte{jpegAPP0, jpeg_APP1, 0xE2},
+		},
+		"no JFIF": {
+			[][]byte{icc},
+			[]byte{jpeg_APP1, 0xE2},
+		},
+	} {
+		src := append(bytes.Join(append([][]byte{img[:2]}, tc.src...), nil), img[20:]...)
+		var buf bytes.Buffer
+		if err := WriteJPEG(&buf, bytes.NewReader(src), x); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := buf.Bytes()
+		var markers []byte
+		for i := 2; out[i+1] != 0xDB; i += 2 + int(binary.BigEndian.Uint16(out[i+2:])) {
+			markers = append(markers, out[i+1])
+		}
+		if !bytes.Equal(markers, tc.markers) {
+			t.Errorf("%s: segments % x, want % x", name, markers, tc.markers)
+		}
+		y, err := Decode(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if tag, err := y.Get(Model); err != nil || tag.String() != `"Synth 2"` {
+			t.Errorf("%s: Model = %v, %v", name, tag, err)
+		}
+		if _, err := y.Get(Software); err == nil {
+			t.Errorf("%s: Software of the replaced data present", name)
+		}
+	}
+}
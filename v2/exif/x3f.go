@@ -0,0 +1,115 @@
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+// Sigma X3F files end with a 4 byte offset to a section directory.  Each
+// directory entry points to a section; PROP sections hold camera settings as
+// a list of UTF-16 name/value pairs.  All values are little endian.
+
+// X3FProperties reads the PROP sections of the Sigma/Foveon X3F file in r
+// (of the given size) and returns their name/value pairs.
+func X3FProperties(r io.ReaderAt, size int64) (map[string]string, error) {
+	var sig [4]byte
+	if _, err := r.ReadAt(sig[:], 0); err != nil {
+		return nil, err
+	}
+	if string(sig[:]) != "FOVb" {
+		return nil, errors.New("exif: not an X3F file")
+	}
+	if size < 8 {
+		return nil, errors.New("exif: short X3F file")
+	}
+
+	var off uint32
+	if err := readAtLE(r, size-4, &off); err != nil {
+		return nil, err
+	}
+	var dir struct {
+		Sig     [4]byte
+		Version uint32
+		N       uint32
+	}
+	if err := readAtLE(r, int64(off), &dir); err != nil {
+		return nil, err
+	}
+	if string(dir.Sig[:]) != "SECd" {
+		return nil, errors.New("exif: X3F section directory not found")
+	}
+	if int64(dir.N)*12 > size {
+		return nil, errors.New("exif: invalid X3F directory entry count")
+	}
+
+	props := map[string]string{}
+	for i := int64(0); i < int64(dir.N); i++ {
+		var entry struct {
+			Offset, Length uint32
+			Type           [4]byte
+		}
+		if err := readAtLE(r, int64(off)+12+i*12, &entry); err != nil {
+			return nil, err
+		}
+		if string(entry.Type[:]) != "PROP" {
+			continue
+		}
+		if int64(entry.Offset)+int64(entry.Length) > size {
+			return nil, errors.New("exif: X3F PROP section exceeds file size")
+		}
+		sec := make([]byte, entry.Length)
+		if _, err := r.ReadAt(sec, int64(entry.Offset)); err != nil {
+			return nil, err
+		}
+		if err := parseX3FProp(sec, props); err != nil {
+			return nil, err
+		}
+	}
+	return props, nil
+}
+
+// parseX3FProp decodes a single PROP section into props.
+func parseX3FProp(sec []byte, props map[string]string) error {
+	const hdrLen = 24
+	if len(sec) < hdrLen || string(sec[:4]) != "SECp" {
+		return errors.New("exif: invalid X3F PROP section")
+	}
+	n := binary.LittleEndian.Uint32(sec[8:])
+	if format := binary.LittleEndian.Uint32(sec[12:]); format != 0 {
+		return fmt.Errorf("exif: unsupported X3F PROP character format %d", format)
+	}
+	nchars := binary.LittleEndian.Uint32(sec[20:])
+
+	entriesEnd := hdrLen + 8*int64(n)
+	if entriesEnd+2*int64(nchars) > int64(len(sec)) {
+		return errors.New("exif: X3F PROP section is truncated")
+	}
+	chars := make([]uint16, nchars)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(sec[entriesEnd+2*int64(i):])
+	}
+	str := func(at uint32) string {
+		if at >= nchars {
+			return ""
+		}
+		end := at
+		for end < nchars && chars[end] != 0 {
+			end++
+		}
+		return string(utf16.Decode(chars[at:end]))
+	}
+
+	for i := int64(0); i < int64(n); i++ {
+		name := binary.LittleEndian.Uint32(sec[hdrLen+8*i:])
+		val := binary.LittleEndian.Uint32(sec[hdrLen+8*i+4:])
+		props[str(name)] = str(val)
+	}
+	return nil
+}
+
+func readAtLE(r io.ReaderAt, off int64, data interface{}) error {
+	return binary.Read(io.NewSectionReader(r, off, int64(binary.Size(data))), binary.LittleEndian, data)
+}
@@ -0,0 +1,51 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestX3FProperties(t *testing.T) {
+	le := binary.LittleEndian
+	pairs := [][2]string{{"CAMMODEL", "SIGMA SD14"}, {"ISO", "100"}}
+
+	var chars []uint16
+	var offsets []uint32
+	for _, p := range pairs {
+		for _, s := range p {
+			offsets = append(offsets, uint32(len(chars)))
+			chars = append(chars, utf16.Encode([]rune(s))...)
+			chars = append(chars, 0)
+		}
+	}
+	var prop bytes.Buffer
+	prop.WriteString("SECp")
+	binary.Write(&prop, le, []uint32{0x00010000, uint32(len(pairs)), 0, 0, uint32(len(chars))})
+	binary.Write(&prop, le, offsets)
+	binary.Write(&prop, le, chars)
+
+	var file bytes.Buffer
+	file.WriteString("FOVb")
+	file.Write(make([]byte, 60))
+	propOff := file.Len()
+	file.Write(prop.Bytes())
+	dirOff := file.Len()
+	file.WriteString("SECd")
+	binary.Write(&file, le, []uint32{0x00020000, 1, uint32(propOff), uint32(prop.Len())})
+	file.WriteString("PROP")
+	binary.Write(&file, le, uint32(dirOff))
+
+	props, err := X3FProperties(bytes.NewReader(file.Bytes()), int64(file.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(props) != 2 || props["CAMMODEL"] != "SIGMA SD14" || props["ISO"] != "100" {
+		t.Errorf("unexpected properties %v", props)
+	}
+
+	if _, err := X3FProperties(bytes.NewReader([]byte("not an x3f file")), 15); err == nil {
+		t.Error("no error for non-X3F data")
+	}
+}
@@ -0,0 +1,169 @@
+// Package exifcache caches decoded EXIF data, so that servers serving the
+// metadata of the same images over and over decode each only once.  Entries
+// are keyed by file path, size and modification time, or by a hash of the
+// content, and evicted least recently used first.
+//
+// Cached Exif objects are shared by all callers and must not be modified.
+package exifcache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// Cache is an LRU cache of decoded EXIF data.  It is safe for concurrent
+// use.
+type Cache struct {
+	// Decoder decodes the files not found in the cache; nil means the zero
+	// Decoder.
+	Decoder *exif.Decoder
+
+	maxEntries int
+	maxBytes   int
+
+	mu     sync.Mutex
+	lru    *list.List // of *entry, most recently used first
+	items  map[string]*list.Element
+	size   int // sum of the sizes of the entries
+	hits   int64
+	misses int64
+}
+
+type entry struct {
+	key  string
+	x    *exif.Exif
+	err  error
+	size int
+}
+
+// New returns a Cache holding at most maxEntries decoded files whose
+// estimated size (see exif.Exif.SizeBytes) totals at most maxBytes.  A
+// limit of zero or less is no limit.
+func New(maxEntries, maxBytes int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		items:      map[string]*list.Element{},
+	}
+}
+
+// DecodeFile returns the decoded EXIF data of the named file, decoding it
+// unless the cache holds it for the same path, size and modification time.
+func (c *Cache) DecodeFile(name string) (*exif.Exif, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("file:%s:%d:%d", name, fi.Size(), fi.ModTime().UnixNano())
+	if e, ok := c.get(key); ok {
+		return e.x, e.err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	x, err := c.decoder().Decode(f)
+	c.add(key, x, err)
+	return x, err
+}
+
+// Decode reads r to EOF and returns its decoded EXIF data, decoding it
+// unless the cache holds data of the same content.
+func (c *Cache) Decode(r io.Reader) (*exif.Exif, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	key := fmt.Sprintf("sha256:%x", sum)
+	if e, ok := c.get(key); ok {
+		return e.x, e.err
+	}
+
+	x, err := c.decoder().Decode(bytes.NewReader(data))
+	c.add(key, x, err)
+	return x, err
+}
+
+// Stats returns the number of lookups served from the cache and the number
+// that required decoding.
+func (c *Cache) Stats() (hits, misses int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Len returns the number of decoded files in the cache and their estimated
+// total size in bytes.
+func (c *Cache) Len() (n, size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len(), c.size
+}
+
+// Purge empties the cache.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.items = map[string]*list.Element{}
+	c.size = 0
+}
+
+func (c *Cache) decoder() *exif.Decoder {
+	if c.Decoder == nil {
+		return &exif.Decoder{}
+	}
+	return c.Decoder
+}
+
+func (c *Cache) get(key string) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*entry), true
+}
+
+// add caches the result of a decode.  Failures without a usable Exif are
+// not cached, as they may be due to transient read errors.
+func (c *Cache) add(key string, x *exif.Exif, err error) {
+	if x == nil {
+		return
+	}
+	e := &entry{key: key, x: x, err: err, size: x.SizeBytes()}
+	if c.maxBytes > 0 && e.size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		// Another goroutine decoded the same file concurrently.
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.lru.PushFront(e)
+	c.size += e.size
+	for (c.maxEntries > 0 && c.lru.Len() > c.maxEntries) || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		old := c.lru.Remove(c.lru.Back()).(*entry)
+		delete(c.items, old.key)
+		c.size -= old.size
+	}
+}
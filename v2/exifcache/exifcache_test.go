@@ -0,0 +1,91 @@
+package exifcache
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const synth = "../exif/testdata/synth"
+
+func TestDecodeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exifcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var names []string
+	for _, base := range []string{"le_basic.tif", "be_basic.tif", "be_gps.jpg"} {
+		data, err := ioutil.ReadFile(filepath.Join(synth, base))
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := filepath.Join(dir, base)
+		if err := ioutil.WriteFile(name, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	c := New(2, 0)
+	x1, err := c.DecodeFile(names[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, err := c.DecodeFile(names[0]); err != nil || x != x1 {
+		t.Errorf("second decode returned %p, %v, want cached %p", x, err, x1)
+	}
+	if hits, misses := c.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Stats = %d, %d, want 1, 1", hits, misses)
+	}
+
+	// Adding two more files evicts the first one.
+	for _, name := range names[1:] {
+		if _, err := c.DecodeFile(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, size := c.Len(); n != 2 || size <= 0 {
+		t.Errorf("Len = %d, %d", n, size)
+	}
+	if x, _ := c.DecodeFile(names[0]); x == x1 {
+		t.Errorf("evicted file served from cache")
+	}
+
+	// A modified file is decoded again.
+	x2, _ := c.DecodeFile(names[2])
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(names[2], later, later); err != nil {
+		t.Fatal(err)
+	}
+	if x, _ := c.DecodeFile(names[2]); x == x2 {
+		t.Errorf("modified file served from cache")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(synth, "le_thumbnail.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(0, 0)
+	x1, err := c.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x, _ := c.Decode(bytes.NewReader(data)); x != x1 {
+		t.Errorf("same content decoded again")
+	}
+
+	// Entries larger than the size limit are not cached.
+	c = New(0, x1.SizeBytes()-1)
+	c.Decode(bytes.NewReader(data))
+	if n, _ := c.Len(); n != 0 {
+		t.Errorf("cache holds %d entries over the size limit", n)
+	}
+}
@@ -0,0 +1,194 @@
+package exiftest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// A Builder builds an in-memory JPEG or TIFF file holding chosen EXIF
+// fields, as a test fixture:
+//
+//	data, err := exiftest.NewJPEG().
+//	    WithTag(exif.Model, "Synth 1").
+//	    WithTag(exif.FNumber, [2]int64{28, 10}).
+//	    WithCorruption(exiftest.Truncate(40)).
+//	    Bytes()
+//
+// The fields are laid out by exif.Encode, in the IFDs the EXIF
+// specification places them in.  Errors are reported by Bytes.
+type Builder struct {
+	jpeg        bool
+	order       binary.ByteOrder
+	x           *exif.Exif
+	corruptions []Corruption
+	err         error
+}
+
+// A Corruption modifies the TIFF structure b of a Builder's file, encoded in
+// byte order order, and returns the result.
+type Corruption func(order binary.ByteOrder, b []byte) []byte
+
+// NewJPEG returns a Builder of a small JPEG image with the EXIF data in an
+// APP1 segment.  The byte order of the EXIF data is big endian.
+func NewJPEG() *Builder {
+	return &Builder{jpeg: true, order: binary.BigEndian, x: &exif.Exif{}}
+}
+
+// NewTIFF returns a Builder of a TIFF file, holding the EXIF data only.  The
+// byte order is big endian.
+func NewTIFF() *Builder {
+	return &Builder{order: binary.BigEndian, x: &exif.Exif{}}
+}
+
+// WithOrder sets the byte order of the EXIF data.
+func (b *Builder) WithOrder(order binary.ByteOrder) *Builder {
+	b.order = order
+	return b
+}
+
+// WithTag adds the known field name holding vals, stored as the preferred
+// data type of the field (see exif.FieldInfo), accepting the values
+// tiff.NewTag does.  name may be qualified, e.g. to place it in IFD1.
+func (b *Builder) WithTag(name exif.FieldName, vals ...interface{}) *Builder {
+	_, bare := name.Group()
+	info, ok := exif.LookupField(bare)
+	if !ok {
+		b.fail(fmt.Errorf("exiftest: unknown field %v", name))
+		return b
+	}
+	return b.WithTagType(name, info.Types[0], vals...)
+}
+
+// WithTagType is like WithTag, but stores the values as type typ, which
+// need not be a type the field is specified with.
+func (b *Builder) WithTagType(name exif.FieldName, typ tiff.DataType, vals ...interface{}) *Builder {
+	_, bare := name.Group()
+	info, ok := exif.LookupField(bare)
+	if !ok {
+		b.fail(fmt.Errorf("exiftest: unknown field %v", name))
+		return b
+	}
+	tag, err := tiff.NewTag(info.ID, typ, b.order, vals...)
+	if err != nil {
+		b.fail(fmt.Errorf("exiftest: %v: %v", name, err))
+		return b
+	}
+	b.x.Set(name, tag)
+	return b
+}
+
+// WithCorruption adds c to the corruptions applied, in order, to the
+// encoded TIFF structure.
+func (b *Builder) WithCorruption(c Corruption) *Builder {
+	b.corruptions = append(b.corruptions, c)
+	return b
+}
+
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Bytes returns the content of the file, or the first error met while
+// building it.
+func (b *Builder) Bytes() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	var buf bytes.Buffer
+	if err := exif.Encode(&buf, b.x, b.order); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+	for _, c := range b.corruptions {
+		data = c(b.order, data)
+	}
+	if !b.jpeg {
+		return data, nil
+	}
+
+	img, err := tinyJPEG()
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := exif.WriteJPEGRaw(&out, bytes.NewReader(img), data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// tinyJPEG encodes an 8x8 gradient.
+func tinyJPEG() ([]byte, error) {
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			img.SetGray(x, y, color.Gray{uint8(x*32 + y)})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Truncate cuts the TIFF structure to its first n bytes.
+func Truncate(n int) Corruption {
+	return func(order binary.ByteOrder, b []byte) []byte {
+		if n < len(b) {
+			return b[:n]
+		}
+		return b
+	}
+}
+
+// EntryCount sets the number of entries IFD0 claims to hold to n.  It
+// leaves b unchanged if b is too short to hold the count, e.g. after
+// Truncate.
+func EntryCount(n uint16) Corruption {
+	return func(order binary.ByteOrder, b []byte) []byte {
+		off, ok := ifd0(order, b)
+		if ok {
+			order.PutUint16(b[off:], n)
+		}
+		return b
+	}
+}
+
+// PatchEntry sets the value (or value offset) of the IFD0 entry with tag ID
+// id to val, e.g. to point a sub-IFD past the end of the data.  Entries
+// cut off by the end of b are left alone.
+func PatchEntry(id uint16, val uint32) Corruption {
+	return func(order binary.ByteOrder, b []byte) []byte {
+		off, ok := ifd0(order, b)
+		if !ok {
+			return b
+		}
+		n := uint64(order.Uint16(b[off:]))
+		for e := off + 2; e < off+2+12*n && e+12 <= uint64(len(b)); e += 12 {
+			if order.Uint16(b[e:]) == id {
+				order.PutUint32(b[e+8:], val)
+			}
+		}
+		return b
+	}
+}
+
+// ifd0 returns the offset of IFD0 in b, and whether b holds its entry
+// count.
+func ifd0(order binary.ByteOrder, b []byte) (uint64, bool) {
+	if len(b) < 8 {
+		return 0, false
+	}
+	off := uint64(order.Uint32(b[4:]))
+	return off, off+2 <= uint64(len(b))
+}
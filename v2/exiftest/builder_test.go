@@ -0,0 +1,102 @@
+package exiftest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestBuilder(t *testing.T) {
+	for _, b := range []*Builder{NewJPEG(), NewTIFF().WithOrder(binary.LittleEndian)} {
+		data, err := b.
+			WithTag(exif.Model, "Synth 1").
+			WithTag(exif.FNumber, [2]int64{28, 10}).
+			WithTag(exif.GPSLatitudeRef, "N").
+			WithTagType(exif.Orientation, tiff.DTLong, 6).
+			Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := exif.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := exif.ContainerTIFF
+		if b.jpeg {
+			want = exif.ContainerJPEG
+		}
+		if got := x.Container(); got != want {
+			t.Errorf("container %v, want %v", got, want)
+		}
+		if x.ByteOrder() != b.order {
+			t.Errorf("byte order %v, want %v", x.ByteOrder(), b.order)
+		}
+		for name, want := range map[exif.FieldName]string{
+			exif.Model:          `"Synth 1"`,
+			exif.FNumber:        `"28/10"`,
+			exif.GPSLatitudeRef: `"N"`,
+			exif.Orientation:    `6`,
+		} {
+			if tag, err := x.Get(name); err != nil || tag.String() != want {
+				t.Errorf("%v = %v, %v; want %v", name, tag, err, want)
+			}
+		}
+		if tag, _ := x.Get(exif.Orientation); tag.Type != tiff.DTLong {
+			t.Errorf("Orientation of type %v", tag.Type)
+		}
+	}
+}
+
+func TestBuilderCorruption(t *testing.T) {
+	data, err := NewJPEG().
+		WithTag(exif.Model, "Synth 1").
+		WithTag(exif.ISOSpeedRatings, 200).
+		WithCorruption(PatchEntry(exif.ExifIFDPointerID, 0xFFFFFF00)).
+		Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := exif.Decode(bytes.NewReader(data))
+	if !exif.IsExifError(err) {
+		t.Errorf("bad Exif pointer: got error %v", err)
+	}
+	if tag, err := x.Get(exif.Model); err != nil || tag.String() != `"Synth 1"` {
+		t.Errorf("Model = %v, %v", tag, err)
+	}
+
+	for _, c := range []Corruption{Truncate(20), EntryCount(0x4000)} {
+		data, err := NewTIFF().WithTag(exif.Model, "Synth 1").WithCorruption(c).Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := exif.Decode(bytes.NewReader(data)); err == nil || !exif.IsCriticalError(err) {
+			t.Errorf("corrupt data decoded with error %v", err)
+		}
+	}
+
+	if _, err := NewJPEG().WithTag("NoSuchField", 1).WithTag(exif.Model, "x").Bytes(); err == nil {
+		t.Error("unknown field accepted")
+	}
+}
+
+func TestCorruptionAfterTruncate(t *testing.T) {
+	for _, n := range []int{0, 3, 8, 9, 10, 16, 30} {
+		for _, c := range []Corruption{EntryCount(7), PatchEntry(exif.ExifIFDPointerID, 0xFFFF)} {
+			b := NewTIFF().
+				WithTag(exif.Model, "Synth 1").
+				WithTag(exif.ISOSpeedRatings, 200).
+				WithCorruption(Truncate(n)).
+				WithCorruption(c)
+			data, err := b.Bytes()
+			if err != nil {
+				t.Fatalf("truncated to %d: %v", n, err)
+			}
+			if len(data) != n {
+				t.Errorf("truncated to %d: got %d bytes", n, len(data))
+			}
+		}
+	}
+}
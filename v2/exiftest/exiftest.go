@@ -0,0 +1,194 @@
+// Package exiftest runs the exif decoder against a corpus of images and
+// compares the decoded fields with previously recorded (golden) values.  It
+// lets users of the exif package check that an upgrade decodes their own
+// images as before:
+//
+//	func TestCorpus(t *testing.T) {
+//	    exiftest.Run(t, "/path/to/photos", "testdata/golden.json")
+//	}
+//
+// Running the test once with exiftest.Update set records the golden file.
+//
+// A Builder constructs small files with chosen fields, possibly corrupted,
+// for tests that need precise fixtures rather than a corpus.
+package exiftest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Update makes Run rewrite the golden file from the corpus instead of
+// comparing against it.
+var Update = false
+
+// Golden holds the expected field values of the files in a corpus, keyed
+// by slash separated path relative to the corpus directory.  Values are
+// formatted with tiff.Tag.String, as in the exif package's own regression
+// tests.
+type Golden map[string]map[exif.FieldName]string
+
+// Mismatch is a difference between a decoded field and its golden value.
+// Got or Want is empty if the field is missing from the decoded file or
+// the golden values respectively.  Err is set if the file could not be
+// decoded at all.
+type Mismatch struct {
+	File      string
+	Field     exif.FieldName
+	Got, Want string
+	Err       error
+}
+
+func (m Mismatch) String() string {
+	switch {
+	case m.Err != nil:
+		return fmt.Sprintf("%v: %v", m.File, m.Err)
+	case m.Got == "":
+		return fmt.Sprintf("%v: %v missing, want %v", m.File, m.Field, m.Want)
+	case m.Want == "":
+		return fmt.Sprintf("%v: unexpected %v %v", m.File, m.Field, m.Got)
+	}
+	return fmt.Sprintf("%v: %v is %v, want %v", m.File, m.Field, m.Got, m.Want)
+}
+
+type walkFunc func(exif.FieldName, *tiff.Tag) error
+
+func (f walkFunc) Walk(name exif.FieldName, tag *tiff.Tag) error {
+	return f(name, tag)
+}
+
+// decodeFile returns the field values of the file at path.  Non-critical
+// decode errors are ignored, as the fields decoded are still recorded.
+func decodeFile(path string) (map[exif.FieldName]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	x, err := exif.Decode(f)
+	if x == nil || (err != nil && exif.IsCriticalError(err)) {
+		return nil, err
+	}
+	fields := map[exif.FieldName]string{}
+	x.Walk(walkFunc(func(name exif.FieldName, tag *tiff.Tag) error {
+		fields[name] = tag.String()
+		return nil
+	}))
+	return fields, nil
+}
+
+// Snapshot decodes every file under dir and returns their field values.
+// Files without EXIF data (or that fail to decode) are left out.
+func Snapshot(dir string) (Golden, error) {
+	g := Golden{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if fields, err := decodeFile(path); err == nil {
+			g[filepath.ToSlash(rel)] = fields
+		}
+		return nil
+	})
+	return g, err
+}
+
+// Compare decodes the files of g in dir and returns the differences from
+// their golden values, sorted by file and field.  Files under dir that are
+// not in g are not checked.
+func Compare(dir string, g Golden) []Mismatch {
+	var ms []Mismatch
+	for file, want := range g {
+		got, err := decodeFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			ms = append(ms, Mismatch{File: file, Err: err})
+			continue
+		}
+		for name, w := range want {
+			if v := got[name]; v != w {
+				ms = append(ms, Mismatch{File: file, Field: name, Got: v, Want: w})
+			}
+		}
+		for name, v := range got {
+			if _, ok := want[name]; !ok {
+				ms = append(ms, Mismatch{File: file, Field: name, Got: v})
+			}
+		}
+	}
+	sort.Slice(ms, func(i, j int) bool {
+		if ms[i].File != ms[j].File {
+			return ms[i].File < ms[j].File
+		}
+		return ms[i].Field < ms[j].Field
+	})
+	return ms
+}
+
+// ReadGolden reads golden values written by WriteTo.
+func ReadGolden(r io.Reader) (Golden, error) {
+	var g Golden
+	if err := json.NewDecoder(r).Decode(&g); err != nil {
+		return nil, fmt.Errorf("exiftest: invalid golden data: %v", err)
+	}
+	return g, nil
+}
+
+// WriteTo writes g as indented JSON.
+func (g Golden) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// Run compares the files in the corpus directory dir with the golden
+// values stored in the file golden, reporting each difference as a test
+// error.  If Update is set, the golden file is rewritten from the corpus
+// instead.
+func Run(t testing.TB, dir, golden string) {
+	t.Helper()
+	if Update {
+		g, err := Snapshot(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Create(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := g.WriteTo(f); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("recorded %d files in %v", len(g), golden)
+		return
+	}
+
+	f, err := os.Open(golden)
+	if err != nil {
+		t.Fatalf("%v (set exiftest.Update to record it)", err)
+	}
+	defer f.Close()
+	g, err := ReadGolden(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range Compare(dir, g) {
+		t.Error(m)
+	}
+}
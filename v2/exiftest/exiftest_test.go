@@ -0,0 +1,83 @@
+package exiftest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+const corpus = "../exif/samples"
+
+func TestCompare(t *testing.T) {
+	g, err := Snapshot(corpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g) == 0 {
+		t.Fatal("no files decoded")
+	}
+
+	var buf bytes.Buffer
+	if _, err := g.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	g, err = ReadGolden(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms := Compare(corpus, g); len(ms) != 0 {
+		t.Fatalf("unexpected mismatches: %v", ms)
+	}
+
+	var file string
+	for f, fields := range g {
+		if fields[exif.Model] != "" && fields[exif.Make] != "" && (file == "" || f < file) {
+			file = f
+		}
+	}
+	want := g[file][exif.Model]
+	g[file][exif.Model] = "changed"
+	delete(g[file], exif.Make)
+	g["missing.jpg"] = map[exif.FieldName]string{}
+
+	ms := Compare(corpus, g)
+	if len(ms) != 3 {
+		t.Fatalf("got %d mismatches, want 3: %v", len(ms), ms)
+	}
+	for _, m := range ms {
+		switch {
+		case m.File == "missing.jpg":
+			if m.Err == nil {
+				t.Errorf("missing file: no error")
+			}
+		case m.Field == exif.Model:
+			if m.Got != want || m.Want != "changed" {
+				t.Errorf("Model mismatch is %v", m)
+			}
+		case m.Field == exif.Make:
+			if m.Want != "" || m.Got == "" {
+				t.Errorf("Make mismatch is %v", m)
+			}
+		default:
+			t.Errorf("unexpected mismatch %v", m)
+		}
+	}
+}
+
+func TestRunUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exiftest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "golden.json")
+
+	Update = true
+	Run(t, corpus, golden)
+	Update = false
+	Run(t, corpus, golden)
+}
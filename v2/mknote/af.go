@@ -0,0 +1,131 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// AFPoint is an autofocus point or area of the image.  X and Y locate its
+// center and W and H give its size, as fractions of the image width and
+// height with the origin at the top left corner.
+type AFPoint struct {
+	X, Y, W, H float64
+	Selected   bool // selected for focusing
+	InFocus    bool // focus was achieved on it
+	Primary    bool // the point focus was set on
+}
+
+// ErrNoAFPoints is returned by AFPoints for images without AF point
+// information it can locate.
+var ErrNoAFPoints = errors.New("mknote: no AF point positions")
+
+// AFPoints returns the AF points recorded in the Canon AFInfo field (the
+// AFInfo2 structure of EOS cameras) or the Nikon AFInfo2 field of x, so that
+// culling tools can show which ones were used.
+//
+// Canon records the layout of all the points of the camera.  Nikon only
+// records the position of the contrast detect (live view) AF area, which
+// is returned as a single point: the phase detect points of the viewfinder
+// are identified by number only, and fail with ErrNoAFPoints.
+func AFPoints(x *exif.Exif) ([]AFPoint, error) {
+	if _, err := x.Get(Canon_AFInfo); err == nil {
+		return canonAFPoints(x)
+	}
+	if _, err := x.Get(Nikon_AFInfo2); err == nil {
+		return nikonAFPoints(x)
+	}
+	return nil, ErrNoAFPoints
+}
+
+// canonAFPoints decodes the Canon AFInfo2 structure, an array of shorts:
+// its size in bytes, the AF area mode, the number of points n, the number
+// of valid points, the image width and height, the width and height of the
+// image the points are laid out on, then n widths, heights, x and y
+// positions (signed, relative to the center, y up), the bit masks of the
+// points in focus and selected, and the primary point.
+func canonAFPoints(x *exif.Exif) ([]AFPoint, error) {
+	tag, err := x.Get(Canon_AFInfo)
+	if err != nil {
+		return nil, err
+	}
+	word := func(i int) int {
+		v, _ := tag.Int(i)
+		return v
+	}
+	signed := func(i int) float64 { return float64(int16(word(i))) }
+
+	count := int(tag.Count)
+	if count < 8 {
+		return nil, errors.New("mknote: Canon AFInfo is too short")
+	}
+	n, valid := word(2), word(3)
+	w, h := word(6), word(7)
+	if w == 0 || h == 0 {
+		w, h = word(4), word(5)
+	}
+	masks := (n + 15) / 16
+	if n == 0 || w == 0 || h == 0 || count < 8+4*n+masks {
+		return nil, ErrNoAFPoints
+	}
+	if valid == 0 || valid > n {
+		valid = n
+	}
+	bit := func(start, i int) bool {
+		return start+masks <= count && word(start+i/16)&(1<<uint(i%16)) != 0
+	}
+	primary := -1
+	if count > 8+4*n+2*masks {
+		primary = word(8 + 4*n + 2*masks)
+	}
+
+	pts := make([]AFPoint, valid)
+	for i := range pts {
+		pts[i] = AFPoint{
+			X:        0.5 + signed(8+2*n+i)/float64(w),
+			Y:        0.5 - signed(8+3*n+i)/float64(h),
+			W:        signed(8+i) / float64(w),
+			H:        signed(8+n+i) / float64(h),
+			InFocus:  bit(8+4*n, i),
+			Selected: bit(8+4*n+masks, i),
+			Primary:  i == primary,
+		}
+	}
+	return pts, nil
+}
+
+// nikonAFPoints decodes the version 01xx Nikon AFInfo2 structure: the
+// version, contrast detect AF, AF area mode, phase detect AF, primary AF
+// point and points used bytes, then, for contrast detect AF, the size of
+// the image the area is located on, the center and size of the area and
+// whether it is in focus, as shorts.
+func nikonAFPoints(x *exif.Exif) ([]AFPoint, error) {
+	tag, err := x.Get(Nikon_AFInfo2)
+	if err != nil {
+		return nil, err
+	}
+	b := tag.Val
+	if len(b) < 0x1e || !bytes.HasPrefix(b, []byte("01")) || b[4] == 0 {
+		return nil, ErrNoAFPoints
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if m, err := x.Get(exif.MakerNote); err == nil && len(m.Val) >= 12 && string(m.Val[10:12]) == "II" {
+		order = binary.LittleEndian
+	}
+	u16 := func(off int) float64 { return float64(order.Uint16(b[off:])) }
+	w, h := u16(0x10), u16(0x12)
+	if w == 0 || h == 0 {
+		return nil, ErrNoAFPoints
+	}
+	return []AFPoint{{
+		X:        u16(0x14) / w,
+		Y:        u16(0x16) / h,
+		W:        u16(0x18) / w,
+		H:        u16(0x1a) / h,
+		Selected: true,
+		InFocus:  u16(0x1c) == 1,
+		Primary:  true,
+	}}, nil
+}
@@ -0,0 +1,84 @@
+package mknote
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestAFPoints(t *testing.T) {
+	neg := func(v int16) uint16 { return uint16(v) }
+	canon := buildExif(be, "Canon", func(off uint32) []byte {
+		return ifd(be, off, short(be, 0x0026,
+			48, 1, 3, 3, 4000, 3000, 1000, 1000,
+			100, 100, 100, // widths
+			50, 50, 50, // heights
+			neg(-300), 0, 300, // x positions
+			0, 100, 0, // y positions
+			2, // in focus
+			3, // selected
+			1, // primary
+		))
+	})
+
+	nikonAF := make([]byte, 0x1e)
+	copy(nikonAF, "0100")
+	nikonAF[4] = 1 // contrast detect AF on
+	for i, v := range []uint16{6000, 4000, 3000, 1000, 600, 400, 1} {
+		be.PutUint16(nikonAF[0x10+2*i:], v)
+	}
+	nikon := buildExif(le, "NIKON CORPORATION", func(off uint32) []byte {
+		note := []byte("Nikon\x00\x02\x10\x00\x00")
+		note = append(note, tiffHeader(be)...)
+		return append(note, ifd(be, 8, entry{0x00b7, tiff.DTUndefined, uint32(len(nikonAF)), nikonAF})...)
+	})
+
+	for _, tt := range []struct {
+		name string
+		data []byte
+		want []AFPoint
+	}{
+		{"Canon", canon, []AFPoint{
+			{X: 0.2, Y: 0.5, W: 0.1, H: 0.05, Selected: true},
+			{X: 0.5, Y: 0.4, W: 0.1, H: 0.05, Selected: true, InFocus: true, Primary: true},
+			{X: 0.8, Y: 0.5, W: 0.1, H: 0.05},
+		}},
+		{"Nikon", nikon, []AFPoint{
+			{X: 0.5, Y: 0.25, W: 0.1, H: 0.1, Selected: true, InFocus: true, Primary: true},
+		}},
+	} {
+		x, err := exif.Decode(bytes.NewReader(tt.data))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		pts, err := AFPoints(x)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(pts) != len(tt.want) {
+			t.Fatalf("%s: got %d points, want %d", tt.name, len(pts), len(tt.want))
+		}
+		for i, p := range pts {
+			w := tt.want[i]
+			if !near(p.X, w.X) || !near(p.Y, w.Y) || !near(p.W, w.W) || !near(p.H, w.H) ||
+				p.Selected != w.Selected || p.InFocus != w.InFocus || p.Primary != w.Primary {
+				t.Errorf("%s: point %d = %+v, want %+v", tt.name, i, p, w)
+			}
+		}
+	}
+
+	x, err := exif.Decode(bytes.NewReader(buildExif(le, "SIGMA", func(off uint32) []byte {
+		return append([]byte("SIGMA\x00\x00\x00\x01\x00"), ifd(le, off+10, ascii(0x0002, "1234567"))...)
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AFPoints(x); err != ErrNoAFPoints {
+		t.Errorf("AFPoints without AF info = %v", err)
+	}
+}
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
@@ -0,0 +1,66 @@
+package mknote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// ErrNoBulb is returned by BulbDuration for images without a recorded bulb
+// exposure.
+var ErrNoBulb = errors.New("mknote: no bulb duration")
+
+// BulbDuration returns the duration of a bulb exposure, recorded by Canon
+// cameras in tenths of a second in the ShotInfo makernote field.  Cameras
+// record no duration for images shot at set shutter speeds.
+//
+// Intervalometer and star tracking (e.g. Pentax Astrotracer) settings are
+// kept in makernotes this package has no parser for, and are not exposed.
+func BulbDuration(x *exif.Exif) (time.Duration, error) {
+	tag, err := x.Get(Canon_ShotInfo)
+	if err != nil {
+		return 0, ErrNoBulb
+	}
+	v, err := tag.Int(24)
+	if err != nil {
+		return 0, ErrNoBulb
+	}
+	if v = int(int16(v)); v <= 0 {
+		return 0, ErrNoBulb
+	}
+	return time.Duration(v) * time.Second / 10, nil
+}
+
+// EffectiveExposure returns the time the image of x collected light for:
+// the bulb duration if recorded, the exposure time of a frame times the
+// number of frames of an Olympus Live Composite or Live Time image, and the
+// ExposureTime field otherwise.  Astrophotography catalogs can use it to
+// total the integration time of a session.
+func EffectiveExposure(x *exif.Exif) (time.Duration, error) {
+	if d, err := BulbDuration(x); err == nil {
+		return d, nil
+	}
+	tag, err := x.Get(exif.ExposureTime)
+	if err != nil {
+		return 0, err
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil {
+		return 0, err
+	}
+	if num <= 0 || den <= 0 {
+		return 0, errors.New("mknote: invalid exposure time")
+	}
+	d := time.Duration(num) * time.Second / time.Duration(den)
+	if tag, err := x.Get(Olympus_StackedImage); err == nil && tag.Count >= 2 {
+		// Live Composite and Live Time/Bulb images, with their number of
+		// frames
+		kind, _ := tag.Int(0)
+		n, _ := tag.Int(1)
+		if (kind == 1 || kind == 4) && n > 0 {
+			d *= time.Duration(n)
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,65 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestEffectiveExposure(t *testing.T) {
+	canon := func(bulb uint16) func(off uint32) []byte {
+		return func(off uint32) []byte {
+			shot := make([]uint16, 30)
+			shot[24] = bulb
+			return ifd(be, off, short(be, 0x0004, shot...))
+		}
+	}
+	olympus := func(kind, n uint32) func(off uint32) []byte {
+		return func(off uint32) []byte {
+			note := []byte("OLYMPUS\x00II\x03\x00")
+			note = append(note, ifd(le, 12, long(le, 0x2020, 30))...)
+			return append(note, ifd(le, 30, long(le, 0x0804, kind, n))...)
+		}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		order binary.ByteOrder
+		make  string
+		note  func(off uint32) []byte
+		bulb  time.Duration
+		exp   time.Duration
+	}{
+		{"bulb", be, "Canon", canon(1805), 180*time.Second + 500*time.Millisecond, 180*time.Second + 500*time.Millisecond},
+		{"timed", be, "Canon", canon(0), 0, 2 * time.Second},
+		{"composite", le, "OLYMPUS", olympus(1, 40), 0, 80 * time.Second},
+		{"highres", le, "OLYMPUS", olympus(8, 8), 0, 2 * time.Second},
+	} {
+		x, err := exif.Decode(bytes.NewReader(buildExif(tt.order, tt.make, tt.note)))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		d, err := BulbDuration(x)
+		if tt.bulb == 0 && err != ErrNoBulb || tt.bulb != 0 && (err != nil || d != tt.bulb) {
+			t.Errorf("%s: BulbDuration = %v, %v; want %v", tt.name, d, err, tt.bulb)
+		}
+
+		if tt.bulb == 0 {
+			if _, err := EffectiveExposure(x); err == nil {
+				t.Errorf("%s: EffectiveExposure without ExposureTime succeeded", tt.name)
+			}
+		}
+		tag, err := tiff.NewTag(0x829A, tiff.DTRational, tt.order, [2]int64{2, 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		x.Set(exif.ExposureTime, tag)
+		if d, err := EffectiveExposure(x); err != nil || d != tt.exp {
+			t.Errorf("%s: EffectiveExposure = %v, %v; want %v", tt.name, d, err, tt.exp)
+		}
+	}
+}
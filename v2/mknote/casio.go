@@ -0,0 +1,41 @@
+package mknote
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+type casio struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *casio) Name() string { return "Casio" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *casio) CanParse(make string, header []byte) bool {
+	return strings.HasPrefix(strings.ToUpper(make), "CASIO")
+}
+
+// Parse decodes all Casio makernote data found in x and adds it to x.  Both
+// the original header-less notes and the newer "QVC" notes are supported.
+func (p *casio) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Casio notes are an IFD, optionally preceded by a 6 byte header.
+	var hdrLen int64
+	fields := makerNoteCasioFields
+	if bytes.HasPrefix(m.Val, []byte("QVC\000")) || bytes.HasPrefix(m.Val, []byte("DCI\000")) {
+		hdrLen = 6
+		fields = makerNoteCasio2Fields
+	}
+	mkNotesDir, err := noteDir(x, m, hdrLen, baseTIFF)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, fields, false)
+	return nil
+}
@@ -0,0 +1,690 @@
+package mknote
+
+import "github.com/rwcarlsen/goexif/v2/exif"
+
+// Useful resources used in creating these tables:
+//    http://www.exiv2.org/makernote.html
+//    http://www.exiv2.org/tags-canon.html
+//    http://www.exiv2.org/tags-nikon.html
+//    http://www.exiv2.org/tags-sigma.html
+//    https://exiftool.org/TagNames/Ricoh.html
+//    https://exiftool.org/TagNames/Hasselblad.html
+//    https://exiftool.org/TagNames/PhaseOne.html
+//    https://exiftool.org/TagNames/Kodak.html
+//    https://exiftool.org/TagNames/Minolta.html
+//    https://exiftool.org/TagNames/Casio.html
+//    https://exiftool.org/TagNames/Olympus.html
+//    https://exiftool.org/TagNames/Sony.html
+
+// Known Maker Note fields
+const (
+	// common fields
+	ISOSpeed                   exif.FieldName = "ISOSpeed"
+	ColorMode                  exif.FieldName = "ColorMode"
+	Quality                    exif.FieldName = "Quality"
+	Sharpening                 exif.FieldName = "Sharpening"
+	Focus                      exif.FieldName = "Focus"
+	FlashSetting               exif.FieldName = "FlashSetting"
+	FlashDevice                exif.FieldName = "FlashDevice"
+	WhiteBalanceBias           exif.FieldName = "WhiteBalanceBias"
+	WB_RBLevels                exif.FieldName = "WB_RBLevels"
+	ProgramShift               exif.FieldName = "ProgramShift"
+	ExposureDiff               exif.FieldName = "ExposureDiff"
+	ISOSelection               exif.FieldName = "ISOSelection"
+	DataDump                   exif.FieldName = "DataDump"
+	Preview                    exif.FieldName = "Preview"
+	FlashComp                  exif.FieldName = "FlashComp"
+	ISOSettings                exif.FieldName = "ISOSettings"
+	ImageBoundary              exif.FieldName = "ImageBoundary"
+	FlashExposureComp          exif.FieldName = "FlashExposureComp"
+	FlashBracketComp           exif.FieldName = "FlashBracketComp"
+	ExposureBracketComp        exif.FieldName = "ExposureBracketComp"
+	ImageProcessing            exif.FieldName = "ImageProcessing"
+	CropHiSpeed                exif.FieldName = "CropHiSpeed"
+	ExposureTuning             exif.FieldName = "ExposureTuning"
+	SerialNumber               exif.FieldName = "SerialNumber"
+	ImageAuthentication        exif.FieldName = "ImageAuthentication"
+	ActiveDLighting            exif.FieldName = "ActiveDLighting"
+	VignetteControl            exif.FieldName = "VignetteControl"
+	ImageAdjustment            exif.FieldName = "ImageAdjustment"
+	ToneComp                   exif.FieldName = "ToneComp"
+	AuxiliaryLens              exif.FieldName = "AuxiliaryLens"
+	LensType                   exif.FieldName = "LensType"
+	Lens                       exif.FieldName = "Lens"
+	FocusDistance              exif.FieldName = "FocusDistance"
+	DigitalZoom                exif.FieldName = "DigitalZoom"
+	FlashMode                  exif.FieldName = "FlashMode"
+	ShootingMode               exif.FieldName = "ShootingMode"
+	AutoBracketRelease         exif.FieldName = "AutoBracketRelease"
+	LensFStops                 exif.FieldName = "LensFStops"
+	ContrastCurve              exif.FieldName = "ContrastCurve"
+	ColorHue                   exif.FieldName = "ColorHue"
+	SceneMode                  exif.FieldName = "SceneMode"
+	HueAdjustment              exif.FieldName = "HueAdjustment"
+	NEFCompression             exif.FieldName = "NEFCompression"
+	NoiseReduction             exif.FieldName = "NoiseReduction"
+	LinearizationTable         exif.FieldName = "LinearizationTable"
+	RawImageCenter             exif.FieldName = "RawImageCenter"
+	SensorPixelSize            exif.FieldName = "SensorPixelSize"
+	SceneAssist                exif.FieldName = "SceneAssist"
+	RetouchHistory             exif.FieldName = "RetouchHistory"
+	ImageDataSize              exif.FieldName = "ImageDataSize"
+	ImageCount                 exif.FieldName = "ImageCount"
+	DeletedImageCount          exif.FieldName = "DeletedImageCount"
+	ShutterCount               exif.FieldName = "ShutterCount"
+	ImageOptimization          exif.FieldName = "ImageOptimization"
+	SaturationText             exif.FieldName = "SaturationText"
+	VariProgram                exif.FieldName = "VariProgram"
+	ImageStabilization         exif.FieldName = "ImageStabilization"
+	AFResponse                 exif.FieldName = "AFResponse"
+	HighISONoiseReduction      exif.FieldName = "HighISONoiseReduction"
+	ToningEffect               exif.FieldName = "ToningEffect"
+	PrintIM                    exif.FieldName = "PrintIM"
+	CaptureData                exif.FieldName = "CaptureData"
+	CaptureVersion             exif.FieldName = "CaptureVersion"
+	CaptureOffsets             exif.FieldName = "CaptureOffsets"
+	ScanIFD                    exif.FieldName = "ScanIFD"
+	ICCProfile                 exif.FieldName = "ICCProfile"
+	CaptureOutput              exif.FieldName = "CaptureOutput"
+	Panorama                   exif.FieldName = "Panorama"
+	ImageType                  exif.FieldName = "ImageType"
+	FirmwareVersion            exif.FieldName = "FirmwareVersion"
+	FileNumber                 exif.FieldName = "FileNumber"
+	OwnerName                  exif.FieldName = "OwnerName"
+	CameraInfo                 exif.FieldName = "CameraInfo"
+	CustomFunctions            exif.FieldName = "CustomFunctions"
+	ModelID                    exif.FieldName = "ModelID"
+	PictureInfo                exif.FieldName = "PictureInfo"
+	ThumbnailImageValidArea    exif.FieldName = "ThumbnailImageValidArea"
+	SerialNumberFormat         exif.FieldName = "SerialNumberFormat"
+	SuperMacro                 exif.FieldName = "SuperMacro"
+	OriginalDecisionDataOffset exif.FieldName = "OriginalDecisionDataOffset"
+	WhiteBalanceTable          exif.FieldName = "WhiteBalanceTable"
+	LensModel                  exif.FieldName = "LensModel"
+	InternalSerialNumber       exif.FieldName = "InternalSerialNumber"
+	DustRemovalData            exif.FieldName = "DustRemovalData"
+	ProcessingInfo             exif.FieldName = "ProcessingInfo"
+	MeasuredColor              exif.FieldName = "MeasuredColor"
+	VRDOffset                  exif.FieldName = "VRDOffset"
+	SensorInfo                 exif.FieldName = "SensorInfo"
+	ColorData                  exif.FieldName = "ColorData"
+
+	// Nikon-specific fields
+	Nikon_Version        exif.FieldName = "Nikon.Version"
+	Nikon_WhiteBalance   exif.FieldName = "Nikon.WhiteBalance"
+	Nikon_ColorSpace     exif.FieldName = "Nikon.ColorSpace"
+	Nikon_LightSource    exif.FieldName = "Nikon.LightSource"
+	Nikon_Saturation     exif.FieldName = "Nikon_Saturation"
+	Nikon_ShotInfo       exif.FieldName = "Nikon.ShotInfo"       // A sub-IFD
+	Nikon_VRInfo         exif.FieldName = "Nikon.VRInfo"         // A sub-IFD
+	Nikon_PictureControl exif.FieldName = "Nikon.PictureControl" // A sub-IFD
+	Nikon_WorldTime      exif.FieldName = "Nikon.WorldTime"      // A sub-IFD
+	Nikon_ISOInfo        exif.FieldName = "Nikon.ISOInfo"        // A sub-IFD
+	Nikon_AFInfo         exif.FieldName = "Nikon.AFInfo"         // A sub-IFD
+	Nikon_ColorBalance   exif.FieldName = "Nikon.ColorBalance"   // A sub-IFD
+	Nikon_LensData       exif.FieldName = "Nikon.LensData"       // A sub-IFD
+	Nikon_SerialNO       exif.FieldName = "Nikon.SerialNO"       // usually starts with "NO="
+	Nikon_FlashInfo      exif.FieldName = "Nikon.FlashInfo"      // A sub-IFD
+	Nikon_MultiExposure  exif.FieldName = "Nikon.MultiExposure"  // A sub-IFD
+	Nikon_AFInfo2        exif.FieldName = "Nikon.AFInfo2"        // A sub-IFD
+	Nikon_FileInfo       exif.FieldName = "Nikon.FileInfo"       // A sub-IFD
+	Nikon_AFTune         exif.FieldName = "Nikon.AFTune"         // A sub-IFD
+	Nikon3_0x000a        exif.FieldName = "Nikon3.0x000a"
+	Nikon3_0x009b        exif.FieldName = "Nikon3.0x009b"
+	Nikon3_0x009f        exif.FieldName = "Nikon3.0x009f"
+	Nikon3_0x00a3        exif.FieldName = "Nikon3.0x00a3"
+
+	// Canon-specific fiends
+	Canon_CameraSettings exif.FieldName = "Canon.CameraSettings" // A sub-IFD
+	Canon_ShotInfo       exif.FieldName = "Canon.ShotInfo"       // A sub-IFD
+	Canon_AFInfo         exif.FieldName = "Canon.AFInfo"
+	Canon_TimeInfo       exif.FieldName = "Canon.TimeInfo"
+	Canon_0x0000         exif.FieldName = "Canon.0x0000"
+	Canon_0x0003         exif.FieldName = "Canon.0x0003"
+	Canon_0x00b5         exif.FieldName = "Canon.0x00b5"
+	Canon_0x00c0         exif.FieldName = "Canon.0x00c0"
+	Canon_0x00c1         exif.FieldName = "Canon.0x00c1"
+
+	// Sigma-specific fields
+	Sigma_DriveMode            exif.FieldName = "Sigma.DriveMode"
+	Sigma_ResolutionMode       exif.FieldName = "Sigma.ResolutionMode"
+	Sigma_AFMode               exif.FieldName = "Sigma.AFMode"
+	Sigma_FocusSetting         exif.FieldName = "Sigma.FocusSetting"
+	Sigma_WhiteBalance         exif.FieldName = "Sigma.WhiteBalance"
+	Sigma_ExposureMode         exif.FieldName = "Sigma.ExposureMode"
+	Sigma_MeteringMode         exif.FieldName = "Sigma.MeteringMode"
+	Sigma_LensFocalRange       exif.FieldName = "Sigma.LensFocalRange"
+	Sigma_ColorSpace           exif.FieldName = "Sigma.ColorSpace"
+	Sigma_ExposureCompensation exif.FieldName = "Sigma.ExposureCompensation"
+	Sigma_Contrast             exif.FieldName = "Sigma.Contrast"
+	Sigma_Shadow               exif.FieldName = "Sigma.Shadow"
+	Sigma_Highlight            exif.FieldName = "Sigma.Highlight"
+	Sigma_Saturation           exif.FieldName = "Sigma.Saturation"
+	Sigma_Sharpness            exif.FieldName = "Sigma.Sharpness"
+	Sigma_X3FillLight          exif.FieldName = "Sigma.X3FillLight"
+	Sigma_ColorAdjustment      exif.FieldName = "Sigma.ColorAdjustment"
+	Sigma_AdjustmentMode       exif.FieldName = "Sigma.AdjustmentMode"
+	Sigma_Quality              exif.FieldName = "Sigma.Quality"
+	Sigma_Software             exif.FieldName = "Sigma.Software"
+	Sigma_AutoBracket          exif.FieldName = "Sigma.AutoBracket"
+
+	// GoPro-specific fields
+	GoPro_Model                   exif.FieldName = "GoPro.Model"
+	GoPro_MediaUniqueID           exif.FieldName = "GoPro.MediaUniqueID"
+	GoPro_Protune                 exif.FieldName = "GoPro.Protune"
+	GoPro_FieldOfView             exif.FieldName = "GoPro.FieldOfView"
+	GoPro_WhiteBalance            exif.FieldName = "GoPro.WhiteBalance"
+	GoPro_Sharpness               exif.FieldName = "GoPro.Sharpness"
+	GoPro_ColorMode               exif.FieldName = "GoPro.ColorMode"
+	GoPro_ExposureType            exif.FieldName = "GoPro.ExposureType"
+	GoPro_ISOMax                  exif.FieldName = "GoPro.ISOMax"
+	GoPro_ISOMin                  exif.FieldName = "GoPro.ISOMin"
+	GoPro_ExposureCompensation    exif.FieldName = "GoPro.ExposureCompensation"
+	GoPro_ElectronicStabilization exif.FieldName = "GoPro.ElectronicStabilization"
+	GoPro_DiagonalFieldOfView     exif.FieldName = "GoPro.DiagonalFieldOfView"
+	GoPro_LensProjection          exif.FieldName = "GoPro.LensProjection"
+	GoPro_AutoRotation            exif.FieldName = "GoPro.AutoRotation"
+	GoPro_DigitalZoom             exif.FieldName = "GoPro.DigitalZoom"
+
+	// Ricoh-specific fields
+	Ricoh_MakerNoteType exif.FieldName = "Ricoh.MakerNoteType"
+	Ricoh_ImageInfo     exif.FieldName = "Ricoh.ImageInfo"
+	Ricoh_Sharpness     exif.FieldName = "Ricoh.Sharpness"
+	Ricoh_Subdir        exif.FieldName = "Ricoh.Subdir"      // A sub-IFD
+	Ricoh_ThetaSubdir   exif.FieldName = "Ricoh.ThetaSubdir" // A sub-IFD
+
+	// Ricoh Theta-specific fields
+	Theta_Accelerometer exif.FieldName = "Theta.Accelerometer" // roll and pitch in degrees (zenith correction)
+	Theta_Compass       exif.FieldName = "Theta.Compass"       // heading in degrees
+	Theta_TimeZone      exif.FieldName = "Theta.TimeZone"
+	Theta_ISO           exif.FieldName = "Theta.ISO"
+	Theta_FNumber       exif.FieldName = "Theta.FNumber"
+	Theta_ExposureTime  exif.FieldName = "Theta.ExposureTime"
+	Theta_SerialNumber  exif.FieldName = "Theta.SerialNumber"
+
+	// Hasselblad-specific fields
+	Hasselblad_SensorCode      exif.FieldName = "Hasselblad.SensorCode"
+	Hasselblad_CameraModelID   exif.FieldName = "Hasselblad.CameraModelID"
+	Hasselblad_CameraModelName exif.FieldName = "Hasselblad.CameraModelName"
+	Hasselblad_CoatingCode     exif.FieldName = "Hasselblad.CoatingCode"
+
+	// Phase One-specific fields
+	PhaseOne_CameraOrientation    exif.FieldName = "PhaseOne.CameraOrientation"
+	PhaseOne_ISO                  exif.FieldName = "PhaseOne.ISO"
+	PhaseOne_ColorMatrix1         exif.FieldName = "PhaseOne.ColorMatrix1"
+	PhaseOne_WB_RGBLevels         exif.FieldName = "PhaseOne.WB_RGBLevels"
+	PhaseOne_SensorWidth          exif.FieldName = "PhaseOne.SensorWidth"
+	PhaseOne_SensorHeight         exif.FieldName = "PhaseOne.SensorHeight"
+	PhaseOne_SensorLeftMargin     exif.FieldName = "PhaseOne.SensorLeftMargin"
+	PhaseOne_SensorTopMargin      exif.FieldName = "PhaseOne.SensorTopMargin"
+	PhaseOne_ImageWidth           exif.FieldName = "PhaseOne.ImageWidth"
+	PhaseOne_ImageHeight          exif.FieldName = "PhaseOne.ImageHeight"
+	PhaseOne_RawFormat            exif.FieldName = "PhaseOne.RawFormat"
+	PhaseOne_DateTimeOriginal     exif.FieldName = "PhaseOne.DateTimeOriginal"
+	PhaseOne_ImageNumber          exif.FieldName = "PhaseOne.ImageNumber"
+	PhaseOne_Software             exif.FieldName = "PhaseOne.Software"
+	PhaseOne_System               exif.FieldName = "PhaseOne.System"
+	PhaseOne_SensorTemperature    exif.FieldName = "PhaseOne.SensorTemperature"
+	PhaseOne_SensorTemperature2   exif.FieldName = "PhaseOne.SensorTemperature2"
+	PhaseOne_BlackLevel           exif.FieldName = "PhaseOne.BlackLevel"
+	PhaseOne_ColorMatrix2         exif.FieldName = "PhaseOne.ColorMatrix2"
+	PhaseOne_FirmwareVersions     exif.FieldName = "PhaseOne.FirmwareVersions"
+	PhaseOne_ShutterSpeedValue    exif.FieldName = "PhaseOne.ShutterSpeedValue"
+	PhaseOne_ApertureValue        exif.FieldName = "PhaseOne.ApertureValue"
+	PhaseOne_ExposureCompensation exif.FieldName = "PhaseOne.ExposureCompensation"
+	PhaseOne_FocalLength          exif.FieldName = "PhaseOne.FocalLength"
+	PhaseOne_CameraModel          exif.FieldName = "PhaseOne.CameraModel"
+	PhaseOne_LensModel            exif.FieldName = "PhaseOne.LensModel"
+	PhaseOne_MaxApertureValue     exif.FieldName = "PhaseOne.MaxApertureValue"
+	PhaseOne_MinApertureValue     exif.FieldName = "PhaseOne.MinApertureValue"
+
+	// Kodak-specific fields
+	Kodak_Model                exif.FieldName = "Kodak.Model"
+	Kodak_BurstMode            exif.FieldName = "Kodak.BurstMode"
+	Kodak_ImageWidth           exif.FieldName = "Kodak.ImageWidth"
+	Kodak_ImageHeight          exif.FieldName = "Kodak.ImageHeight"
+	Kodak_YearCreated          exif.FieldName = "Kodak.YearCreated"
+	Kodak_MonthDayCreated      exif.FieldName = "Kodak.MonthDayCreated"
+	Kodak_TimeCreated          exif.FieldName = "Kodak.TimeCreated"
+	Kodak_ShutterMode          exif.FieldName = "Kodak.ShutterMode"
+	Kodak_MeteringMode         exif.FieldName = "Kodak.MeteringMode"
+	Kodak_SequenceNumber       exif.FieldName = "Kodak.SequenceNumber"
+	Kodak_FNumber              exif.FieldName = "Kodak.FNumber"      // in 1/100ths
+	Kodak_ExposureTime         exif.FieldName = "Kodak.ExposureTime" // in 1/100000ths of a second
+	Kodak_ExposureCompensation exif.FieldName = "Kodak.ExposureCompensation"
+	Kodak_FocusMode            exif.FieldName = "Kodak.FocusMode"
+	Kodak_WhiteBalance         exif.FieldName = "Kodak.WhiteBalance"
+	Kodak_FlashFired           exif.FieldName = "Kodak.FlashFired"
+	Kodak_TotalZoom            exif.FieldName = "Kodak.TotalZoom"
+	Kodak_DateTimeStamp        exif.FieldName = "Kodak.DateTimeStamp"
+
+	// Minolta-specific fields
+	Minolta_MakerNoteVersion       exif.FieldName = "Minolta.MakerNoteVersion"
+	Minolta_CameraSettings         exif.FieldName = "Minolta.CameraSettings" // fixed structure
+	Minolta_CompressedImageSize    exif.FieldName = "Minolta.CompressedImageSize"
+	Minolta_PreviewImageStart      exif.FieldName = "Minolta.PreviewImageStart"
+	Minolta_PreviewImageLength     exif.FieldName = "Minolta.PreviewImageLength"
+	Minolta_SceneMode              exif.FieldName = "Minolta.SceneMode"
+	Minolta_Quality                exif.FieldName = "Minolta.Quality"
+	Minolta_ImageSize              exif.FieldName = "Minolta.ImageSize"
+	Minolta_ExposureMode           exif.FieldName = "Minolta.ExposureMode"
+	Minolta_WhiteBalance           exif.FieldName = "Minolta.WhiteBalance"
+	Minolta_DriveMode              exif.FieldName = "Minolta.DriveMode"
+	Minolta_MeteringMode           exif.FieldName = "Minolta.MeteringMode"
+	Minolta_ExposureTime           exif.FieldName = "Minolta.ExposureTime"
+	Minolta_FNumber                exif.FieldName = "Minolta.FNumber"
+	Minolta_MacroMode              exif.FieldName = "Minolta.MacroMode"
+	Minolta_ExposureCompensation   exif.FieldName = "Minolta.ExposureCompensation"
+	Minolta_BracketStep            exif.FieldName = "Minolta.BracketStep"
+	Minolta_IntervalLength         exif.FieldName = "Minolta.IntervalLength"
+	Minolta_IntervalNumber         exif.FieldName = "Minolta.IntervalNumber"
+	Minolta_FocalLength            exif.FieldName = "Minolta.FocalLength"
+	Minolta_FlashFired             exif.FieldName = "Minolta.FlashFired"
+	Minolta_Date                   exif.FieldName = "Minolta.Date"
+	Minolta_Time                   exif.FieldName = "Minolta.Time"
+	Minolta_MaxAperture            exif.FieldName = "Minolta.MaxAperture"
+	Minolta_FileNumberMemory       exif.FieldName = "Minolta.FileNumberMemory"
+	Minolta_LastFileNumber         exif.FieldName = "Minolta.LastFileNumber"
+	Minolta_ColorBalanceRed        exif.FieldName = "Minolta.ColorBalanceRed"
+	Minolta_ColorBalanceGreen      exif.FieldName = "Minolta.ColorBalanceGreen"
+	Minolta_ColorBalanceBlue       exif.FieldName = "Minolta.ColorBalanceBlue"
+	Minolta_Saturation             exif.FieldName = "Minolta.Saturation"
+	Minolta_Contrast               exif.FieldName = "Minolta.Contrast"
+	Minolta_SubjectProgram         exif.FieldName = "Minolta.SubjectProgram"
+	Minolta_Brightness             exif.FieldName = "Minolta.Brightness"
+	Minolta_FocusMode              exif.FieldName = "Minolta.FocusMode"
+	Minolta_FocusArea              exif.FieldName = "Minolta.FocusArea"
+	Minolta_FlashExposureComp      exif.FieldName = "Minolta.FlashExposureComp"
+	Minolta_ColorTemperature       exif.FieldName = "Minolta.ColorTemperature"
+	Minolta_ZoneMatching           exif.FieldName = "Minolta.ZoneMatching"
+	Minolta_ImageStabilizationMode exif.FieldName = "Minolta.ImageStabilization"
+
+	// Casio-specific fields
+	Casio_RecordingMode    exif.FieldName = "Casio.RecordingMode"
+	Casio_FocusMode        exif.FieldName = "Casio.FocusMode"
+	Casio_FlashIntensity   exif.FieldName = "Casio.FlashIntensity"
+	Casio_ObjectDistance   exif.FieldName = "Casio.ObjectDistance"
+	Casio_WhiteBalance     exif.FieldName = "Casio.WhiteBalance"
+	Casio_Contrast         exif.FieldName = "Casio.Contrast"
+	Casio_Saturation       exif.FieldName = "Casio.Saturation"
+	Casio_PreviewImageSize exif.FieldName = "Casio.PreviewImageSize"
+	Casio_PreviewImage     exif.FieldName = "Casio.PreviewImage"
+	Casio_ImageSize        exif.FieldName = "Casio.ImageSize"
+	Casio_FocalLength      exif.FieldName = "Casio.FocalLength"
+	Casio_FirmwareDate     exif.FieldName = "Casio.FirmwareDate"
+
+	// Olympus-specific fields
+	Olympus_MakerNoteVersion exif.FieldName = "Olympus.MakerNoteVersion"
+	Olympus_CameraType       exif.FieldName = "Olympus.CameraType"
+	Olympus_CameraSettings   exif.FieldName = "Olympus.CameraSettings" // A sub-IFD
+	Olympus_FocusMode        exif.FieldName = "Olympus.FocusMode"
+	Olympus_DriveMode        exif.FieldName = "Olympus.DriveMode"
+	Olympus_StackedImage     exif.FieldName = "Olympus.StackedImage"
+
+	// Sony-specific fields
+	Sony_FileFormat     exif.FieldName = "Sony.FileFormat"
+	Sony_ModelID        exif.FieldName = "Sony.ModelID"
+	Sony_PixelShiftInfo exif.FieldName = "Sony.PixelShiftInfo"
+)
+
+var makerNoteCanonFields = map[uint16]exif.FieldName{
+	0x0000: Canon_0x0000,
+	0x0001: Canon_CameraSettings,
+	0x0002: exif.FocalLength,
+	0x0003: Canon_0x0003,
+	0x0004: Canon_ShotInfo,
+	0x0005: Panorama,
+	0x0006: ImageType,
+	0x0007: FirmwareVersion,
+	0x0008: FileNumber,
+	0x0009: OwnerName,
+	0x000c: SerialNumber,
+	0x000d: CameraInfo,
+	0x000f: CustomFunctions,
+	0x0010: ModelID,
+	0x0012: PictureInfo,
+	0x0013: ThumbnailImageValidArea,
+	0x0015: SerialNumberFormat,
+	0x001a: SuperMacro,
+	0x0026: Canon_AFInfo,
+	0x0035: Canon_TimeInfo,
+	0x0083: OriginalDecisionDataOffset,
+	0x00a4: WhiteBalanceTable,
+	0x0095: LensModel,
+	0x0096: InternalSerialNumber,
+	0x0097: DustRemovalData,
+	0x0099: CustomFunctions,
+	0x00a0: ProcessingInfo,
+	0x00aa: MeasuredColor,
+	0x00b4: exif.ColorSpace,
+	0x00b5: Canon_0x00b5,
+	0x00c0: Canon_0x00c0,
+	0x00c1: Canon_0x00c1,
+	0x00d0: VRDOffset,
+	0x00e0: SensorInfo,
+	0x4001: ColorData,
+}
+
+// Nikon version 3 Maker Notes fields (used by E5400, SQ, D2H, D70, and newer)
+var makerNoteNikon3Fields = map[uint16]exif.FieldName{
+	0x0001: Nikon_Version,
+	0x0002: ISOSpeed,
+	0x0003: ColorMode,
+	0x0004: Quality,
+	0x0005: Nikon_WhiteBalance,
+	0x0006: Sharpening,
+	0x0007: Focus,
+	0x0008: FlashSetting,
+	0x0009: FlashDevice,
+	0x000a: Nikon3_0x000a,
+	0x000b: WhiteBalanceBias,
+	0x000c: WB_RBLevels,
+	0x000d: ProgramShift,
+	0x000e: ExposureDiff,
+	0x000f: ISOSelection,
+	0x0010: DataDump,
+	0x0011: Preview,
+	0x0012: FlashComp,
+	0x0013: ISOSettings,
+	0x0016: ImageBoundary,
+	0x0017: FlashExposureComp,
+	0x0018: FlashBracketComp,
+	0x0019: ExposureBracketComp,
+	0x001a: ImageProcessing,
+	0x001b: CropHiSpeed,
+	0x001c: ExposureTuning,
+	0x001d: SerialNumber,
+	0x001e: Nikon_ColorSpace,
+	0x001f: Nikon_VRInfo,
+	0x0020: ImageAuthentication,
+	0x0022: ActiveDLighting,
+	0x0023: Nikon_PictureControl,
+	0x0024: Nikon_WorldTime,
+	0x0025: Nikon_ISOInfo,
+	0x002a: VignetteControl,
+	0x0080: ImageAdjustment,
+	0x0081: ToneComp,
+	0x0082: AuxiliaryLens,
+	0x0083: LensType,
+	0x0084: Lens,
+	0x0085: FocusDistance,
+	0x0086: DigitalZoom,
+	0x0087: FlashMode,
+	0x0088: Nikon_AFInfo,
+	0x0089: ShootingMode,
+	0x008a: AutoBracketRelease,
+	0x008b: LensFStops,
+	0x008c: ContrastCurve,
+	0x008d: ColorHue,
+	0x008f: SceneMode,
+	0x0090: Nikon_LightSource,
+	0x0091: Nikon_ShotInfo,
+	0x0092: HueAdjustment,
+	0x0093: NEFCompression,
+	0x0094: Nikon_Saturation,
+	0x0095: NoiseReduction,
+	0x0096: LinearizationTable,
+	0x0097: Nikon_ColorBalance,
+	0x0098: Nikon_LensData,
+	0x0099: RawImageCenter,
+	0x009a: SensorPixelSize,
+	0x009b: Nikon3_0x009b,
+	0x009c: SceneAssist,
+	0x009e: RetouchHistory,
+	0x009f: Nikon3_0x009f,
+	0x00a0: Nikon_SerialNO,
+	0x00a2: ImageDataSize,
+	0x00a3: Nikon3_0x00a3,
+	0x00a5: ImageCount,
+	0x00a6: DeletedImageCount,
+	0x00a7: ShutterCount,
+	0x00a8: Nikon_FlashInfo,
+	0x00a9: ImageOptimization,
+	0x00aa: SaturationText,
+	0x00ab: VariProgram,
+	0x00ac: ImageStabilization,
+	0x00ad: AFResponse,
+	0x00b0: Nikon_MultiExposure,
+	0x00b1: HighISONoiseReduction,
+	0x00b3: ToningEffect,
+	0x00b7: Nikon_AFInfo2,
+	0x00b8: Nikon_FileInfo,
+	0x00b9: Nikon_AFTune,
+	0x0e00: PrintIM,
+	0x0e01: CaptureData,
+	0x0e09: CaptureVersion,
+	0x0e0e: CaptureOffsets,
+	0x0e10: ScanIFD,
+	0x0e1d: ICCProfile,
+	0x0e1e: CaptureOutput,
+}
+
+// Sigma/Foveon Maker Notes fields
+var makerNoteSigmaFields = map[uint16]exif.FieldName{
+	0x0002: SerialNumber,
+	0x0003: Sigma_DriveMode,
+	0x0004: Sigma_ResolutionMode,
+	0x0005: Sigma_AFMode,
+	0x0006: Sigma_FocusSetting,
+	0x0007: Sigma_WhiteBalance,
+	0x0008: Sigma_ExposureMode,
+	0x0009: Sigma_MeteringMode,
+	0x000a: Sigma_LensFocalRange,
+	0x000b: Sigma_ColorSpace,
+	0x000c: Sigma_ExposureCompensation,
+	0x000d: Sigma_Contrast,
+	0x000e: Sigma_Shadow,
+	0x000f: Sigma_Highlight,
+	0x0010: Sigma_Saturation,
+	0x0011: Sigma_Sharpness,
+	0x0012: Sigma_X3FillLight,
+	0x0014: Sigma_ColorAdjustment,
+	0x0015: Sigma_AdjustmentMode,
+	0x0016: Sigma_Quality,
+	0x0017: FirmwareVersion,
+	0x0018: Sigma_Software,
+	0x0019: Sigma_AutoBracket,
+}
+
+// GoPro GPMF keys mapped to the (synthetic) tag IDs used when loading them.
+var goProKeys = map[string]uint16{
+	"FMWR": 0x0001,
+	"CASN": 0x0002,
+	"MINF": 0x0003,
+	"MUID": 0x0004,
+	"PRTN": 0x0005,
+	"VFOV": 0x0006,
+	"PTWB": 0x0007,
+	"PTSH": 0x0008,
+	"PTCL": 0x0009,
+	"EXPT": 0x000a,
+	"PIMX": 0x000b,
+	"PIMN": 0x000c,
+	"PTEV": 0x000d,
+	"EISA": 0x000e,
+	"ZFOV": 0x000f,
+	"PRJT": 0x0010,
+	"AUTO": 0x0011,
+	"DZOM": 0x0012,
+}
+
+// GoPro Maker Notes fields
+var makerNoteGoProFields = map[uint16]exif.FieldName{
+	0x0001: FirmwareVersion,
+	0x0002: SerialNumber,
+	0x0003: GoPro_Model,
+	0x0004: GoPro_MediaUniqueID,
+	0x0005: GoPro_Protune,
+	0x0006: GoPro_FieldOfView,
+	0x0007: GoPro_WhiteBalance,
+	0x0008: GoPro_Sharpness,
+	0x0009: GoPro_ColorMode,
+	0x000a: GoPro_ExposureType,
+	0x000b: GoPro_ISOMax,
+	0x000c: GoPro_ISOMin,
+	0x000d: GoPro_ExposureCompensation,
+	0x000e: GoPro_ElectronicStabilization,
+	0x000f: GoPro_DiagonalFieldOfView,
+	0x0010: GoPro_LensProjection,
+	0x0011: GoPro_AutoRotation,
+	0x0012: GoPro_DigitalZoom,
+}
+
+// thetaSubdirID is the Ricoh makernote tag pointing to the Theta sub-IFD.
+const thetaSubdirID = 0x4001
+
+// Ricoh Maker Notes fields
+var makerNoteRicohFields = map[uint16]exif.FieldName{
+	0x0001:        Ricoh_MakerNoteType,
+	0x0002:        FirmwareVersion,
+	0x0005:        SerialNumber,
+	0x0e00:        PrintIM,
+	0x1001:        Ricoh_ImageInfo,
+	0x1003:        Ricoh_Sharpness,
+	0x2001:        Ricoh_Subdir,
+	thetaSubdirID: Ricoh_ThetaSubdir,
+}
+
+// Ricoh Theta sub-IFD fields
+var makerNoteThetaFields = map[uint16]exif.FieldName{
+	0x0003: Theta_Accelerometer,
+	0x0004: Theta_Compass,
+	0x0005: Theta_TimeZone,
+	0x0101: Theta_ISO,
+	0x0102: Theta_FNumber,
+	0x0103: Theta_ExposureTime,
+	0x0104: Theta_SerialNumber,
+}
+
+// Hasselblad Maker Notes fields
+var makerNoteHasselbladFields = map[uint16]exif.FieldName{
+	0x0011: Hasselblad_SensorCode,
+	0x0012: Hasselblad_CameraModelID,
+	0x0015: Hasselblad_CameraModelName,
+	0x0016: Hasselblad_CoatingCode,
+}
+
+// Phase One Maker Notes fields
+var makerNotePhaseOneFields = map[uint16]exif.FieldName{
+	0x0100: PhaseOne_CameraOrientation,
+	0x0102: SerialNumber,
+	0x0105: PhaseOne_ISO,
+	0x0106: PhaseOne_ColorMatrix1,
+	0x0107: PhaseOne_WB_RGBLevels,
+	0x0108: PhaseOne_SensorWidth,
+	0x0109: PhaseOne_SensorHeight,
+	0x010a: PhaseOne_SensorLeftMargin,
+	0x010b: PhaseOne_SensorTopMargin,
+	0x010c: PhaseOne_ImageWidth,
+	0x010d: PhaseOne_ImageHeight,
+	0x010e: PhaseOne_RawFormat,
+	0x0112: PhaseOne_DateTimeOriginal,
+	0x0113: PhaseOne_ImageNumber,
+	0x0203: PhaseOne_Software,
+	0x0204: PhaseOne_System,
+	0x0210: PhaseOne_SensorTemperature,
+	0x0211: PhaseOne_SensorTemperature2,
+	0x021d: PhaseOne_BlackLevel,
+	0x0226: PhaseOne_ColorMatrix2,
+	0x0301: PhaseOne_FirmwareVersions,
+	0x0400: PhaseOne_ShutterSpeedValue,
+	0x0401: PhaseOne_ApertureValue,
+	0x0402: PhaseOne_ExposureCompensation,
+	0x0403: PhaseOne_FocalLength,
+	0x0410: PhaseOne_CameraModel,
+	0x0412: PhaseOne_LensModel,
+	0x0414: PhaseOne_MaxApertureValue,
+	0x0415: PhaseOne_MinApertureValue,
+}
+
+// phaseOneFloats lists the Phase One tags whose 4 byte values are floats.
+var phaseOneFloats = map[uint16]bool{
+	0x0106: true,
+	0x0107: true,
+	0x0210: true,
+	0x0211: true,
+	0x0226: true,
+	0x0400: true,
+	0x0401: true,
+	0x0402: true,
+	0x0403: true,
+	0x0414: true,
+	0x0415: true,
+}
+
+// Minolta Maker Notes fields (the camera settings structure is described by
+// minoltaSettingsLayout)
+var makerNoteMinoltaFields = map[uint16]exif.FieldName{
+	0x0000: Minolta_MakerNoteVersion,
+	0x0001: Minolta_CameraSettings,
+	0x0003: Minolta_CameraSettings,
+	0x0040: Minolta_CompressedImageSize,
+	0x0088: Minolta_PreviewImageStart,
+	0x0089: Minolta_PreviewImageLength,
+	0x0100: Minolta_SceneMode,
+	0x0101: ColorMode,
+	0x0102: Minolta_Quality,
+	0x0103: Minolta_ImageSize,
+	0x0104: Minolta_FlashExposureComp,
+	0x0107: Minolta_ImageStabilizationMode,
+	0x010a: Minolta_ZoneMatching,
+	0x010b: Minolta_ColorTemperature,
+	0x010c: LensType,
+	0x0e00: PrintIM,
+}
+
+// Casio (type 1) Maker Notes fields
+var makerNoteCasioFields = map[uint16]exif.FieldName{
+	0x0001: Casio_RecordingMode,
+	0x0002: Quality,
+	0x0003: Casio_FocusMode,
+	0x0004: FlashMode,
+	0x0005: Casio_FlashIntensity,
+	0x0006: Casio_ObjectDistance,
+	0x0007: Casio_WhiteBalance,
+	0x000a: DigitalZoom,
+	0x000b: Sharpening,
+	0x000c: Casio_Contrast,
+	0x000d: Casio_Saturation,
+	0x0014: ISOSpeed,
+}
+
+// Casio (type 2, "QVC" header) Maker Notes fields
+var makerNoteCasio2Fields = map[uint16]exif.FieldName{
+	0x0002: Casio_PreviewImageSize,
+	0x0008: Quality,
+	0x0009: Casio_ImageSize,
+	0x000d: Casio_FocusMode,
+	0x0014: ISOSpeed,
+	0x0019: Casio_WhiteBalance,
+	0x001d: Casio_FocalLength,
+	0x001f: Casio_Saturation,
+	0x0020: Casio_Contrast,
+	0x0021: Sharpening,
+	0x0e00: PrintIM,
+	0x2000: Casio_PreviewImage,
+	0x2001: Casio_FirmwareDate,
+}
+
+// Olympus Maker Notes fields
+var makerNoteOlympusFields = map[uint16]exif.FieldName{
+	0x0000: Olympus_MakerNoteVersion,
+	0x0207: Olympus_CameraType,
+	0x2020: Olympus_CameraSettings,
+}
+
+// Olympus CameraSettings sub-IFD fields
+var makerNoteOlympusCameraSettingsFields = map[uint16]exif.FieldName{
+	0x0301: Olympus_FocusMode,
+	0x0600: Olympus_DriveMode,
+	0x0804: Olympus_StackedImage,
+}
+
+// Sony Maker Notes fields
+var makerNoteSonyFields = map[uint16]exif.FieldName{
+	0x0102: Quality,
+	0x202f: Sony_PixelShiftInfo,
+	0xb000: Sony_FileFormat,
+	0xb001: Sony_ModelID,
+	0xb027: LensType,
+}
@@ -0,0 +1,165 @@
+package mknote
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// Feature flags reported by Firmware.
+const (
+	FeatureGPS            = "gps"             // position recorded
+	FeatureStabilization  = "stabilization"   // image stabilization on
+	FeatureAFPoints       = "af points"       // AF point positions recorded
+	FeatureFocusBracket   = "focus bracket"   // focus bracketing or stacking
+	FeaturePixelShift     = "pixel shift"     // pixel shift or high resolution shot
+	FeatureContrastDetect = "contrast detect" // live view AF
+)
+
+// FirmwareInfo identifies the firmware of the camera body that took an
+// image.
+type FirmwareInfo struct {
+	// Version is the dotted version number, e.g. "1.1.1", or the whole
+	// field if it holds none.
+	Version string
+	// Field is the field the version was read from.
+	Field exif.FieldName
+	// Features lists the capabilities of the body in use for the image,
+	// as the Feature constants, in their order.
+	Features []string
+}
+
+// ErrNoFirmware is returned by Firmware for images without a firmware
+// version.
+var ErrNoFirmware = errors.New("mknote: no firmware version")
+
+// versionRE matches dotted version numbers.
+var versionRE = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// firmwareFields are the makernote fields holding the firmware version, in
+// priority order.
+var firmwareFields = []exif.FieldName{FirmwareVersion, Casio_FirmwareDate}
+
+// Firmware returns the firmware version of the camera body that took the
+// image of x, read from the vendor makernote fields (Canon, GoPro, Ricoh,
+// Casio) or else from the Software field when it names no other program,
+// as written by most other cameras, with the features of the body recorded
+// in use.  Fleets of cameras can check it for compliance with AtLeast.
+func Firmware(x *exif.Exif) (FirmwareInfo, error) {
+	var f FirmwareInfo
+	for _, name := range firmwareFields {
+		if v, ok := stringField(x, name); ok {
+			f.Version, f.Field = version(v), name
+			break
+		}
+	}
+	if f.Field == "" {
+		if v, ok := stringField(x, exif.Software); ok && firmwareSoftware(x, v) {
+			f.Version, f.Field = version(v), exif.Software
+		}
+	}
+	if f.Field == "" {
+		return f, ErrNoFirmware
+	}
+	f.Features = features(x)
+	return f, nil
+}
+
+// AtLeast reports whether the firmware version is v or later, comparing
+// the numbers of dotted versions one by one.  Versions without numbers
+// are never at least v.
+func (f FirmwareInfo) AtLeast(v string) bool {
+	have, want := versionRE.FindString(f.Version), versionRE.FindString(v)
+	if have == "" || want == "" {
+		return false
+	}
+	a, b := strings.Split(have, "."), strings.Split(want, ".")
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x, _ = strconv.Atoi(a[i])
+		}
+		if i < len(b) {
+			y, _ = strconv.Atoi(b[i])
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return true
+}
+
+// version returns the dotted version number in s, or s itself trimmed if
+// there is none.
+func version(s string) string {
+	if v := versionRE.FindString(s); v != "" {
+		return v
+	}
+	return strings.TrimSpace(s)
+}
+
+// firmwareSoftware reports whether the Software field value s of x is a
+// camera firmware version: a version number preceded by nothing but words
+// like "Ver." or the camera make or model, as opposed to the name of an
+// editing program.
+func firmwareSoftware(x *exif.Exif, s string) bool {
+	loc := versionRE.FindStringIndex(s)
+	if loc == nil {
+		return false
+	}
+	prefix := strings.ToLower(s[:loc[0]])
+	for _, name := range []exif.FieldName{exif.Make, exif.Model} {
+		if v, ok := stringField(x, name); ok && v != "" {
+			prefix = strings.Replace(prefix, strings.ToLower(v), "", -1)
+		}
+	}
+	for _, word := range []string{"firmware", "version", "ver", "v", ".", ":", "-"} {
+		prefix = strings.Replace(prefix, word, "", -1)
+	}
+	return strings.TrimSpace(prefix) == ""
+}
+
+// features returns the Feature flags of the capabilities in use for the
+// image of x.
+func features(x *exif.Exif) []string {
+	var fs []string
+	if _, _, err := x.LatLong(); err == nil {
+		fs = append(fs, FeatureGPS)
+	}
+	if tag, err := x.Get(Minolta_ImageStabilizationMode); err == nil {
+		if v, err := tag.Int(0); err == nil && v != 0 {
+			fs = append(fs, FeatureStabilization)
+		}
+	}
+	if _, err := AFPoints(x); err == nil {
+		fs = append(fs, FeatureAFPoints)
+	}
+	if s, err := StackInfo(x); err == nil {
+		switch s.Kind {
+		case StackFocusBracket, StackFocusStacked:
+			fs = append(fs, FeatureFocusBracket)
+		case StackPixelShift, StackHighRes:
+			fs = append(fs, FeaturePixelShift)
+		}
+	}
+	if tag, err := x.Get(Nikon_AFInfo2); err == nil && len(tag.Val) > 4 && tag.Val[4] != 0 {
+		fs = append(fs, FeatureContrastDetect)
+	}
+	return fs
+}
+
+// stringField returns the trimmed value of the ASCII field name of x.
+func stringField(x *exif.Exif, name exif.FieldName) (string, bool) {
+	tag, err := x.Get(name)
+	if err != nil {
+		return "", false
+	}
+	v, err := tag.StringVal()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(v), true
+}
@@ -0,0 +1,70 @@
+package mknote
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+func TestFirmware(t *testing.T) {
+	canon, err := exif.Decode(bytes.NewReader(buildExif(be, "Canon", func(off uint32) []byte {
+		return ifd(be, off, ascii(0x0007, "Firmware Version 1.1.1"))
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Firmware(canon)
+	if err != nil || f.Version != "1.1.1" || f.Field != FirmwareVersion || len(f.Features) != 0 {
+		t.Errorf("Canon Firmware = %+v, %v", f, err)
+	}
+	for v, want := range map[string]bool{"1.1": true, "1.1.1": true, "1.1.0.9": true, "1.1.2": false, "1.10": false, "2": false, "new": false} {
+		if f.AtLeast(v) != want {
+			t.Errorf("AtLeast(%q) = %v, want %v", v, !want, want)
+		}
+	}
+
+	sony, err := exif.Decode(bytes.NewReader(buildExif(le, "SONY", mknoteNote("Sony"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Firmware(sony); err != ErrNoFirmware {
+		t.Errorf("Firmware without version = %v", err)
+	}
+	model, _ := tiff.NewTag(0x0110, tiff.DTAscii, le, "ILCE-7RM4")
+	sony.Set(exif.Model, model)
+	for software, want := range map[string]string{
+		"Ver.1.10":           "1.10",
+		"ILCE-7RM4 v2.00":    "2.00",
+		"SONY 1.02":          "1.02",
+		"GIMP 2.10":          "",
+		"Adobe Lightroom 12": "",
+	} {
+		tag, _ := tiff.NewTag(0x0131, tiff.DTAscii, le, software)
+		sony.Set(exif.Software, tag)
+		f, err := Firmware(sony)
+		if want == "" {
+			if err != ErrNoFirmware {
+				t.Errorf("Software %q: Firmware = %+v, %v", software, f, err)
+			}
+			continue
+		}
+		if err != nil || f.Version != want || f.Field != exif.Software {
+			t.Errorf("Software %q: Firmware = %+v, %v; want %s", software, f, err, want)
+		}
+		if len(f.Features) != 1 || f.Features[0] != FeaturePixelShift {
+			t.Errorf("Software %q: features %v", software, f.Features)
+		}
+	}
+}
+
+// mknoteNote returns the maker note generator of the fixture name.
+func mknoteNote(name string) func(off uint32) []byte {
+	for _, tt := range mknoteTests {
+		if tt.name == name {
+			return tt.note
+		}
+	}
+	panic("no fixture " + name)
+}
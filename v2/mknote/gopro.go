@@ -0,0 +1,180 @@
+package mknote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// GoPro cameras record their settings in GPMF (GoPro Metadata Format), a
+// big endian key-length-value encoding.  Each entry is a FourCC key, a one
+// byte type, a one byte sample size and a two byte repeat count, followed by
+// the data padded to a multiple of four bytes.  A type of zero marks a
+// nested container.
+
+// GPMFEntry is a single (non-container) entry of GPMF data.
+type GPMFEntry struct {
+	// Key is the FourCC identifying the entry (e.g. "FMWR").
+	Key string
+	// Type is the GPMF type character (e.g. 'c' for strings, 'L' for
+	// uint32).
+	Type byte
+	// Size is the size in bytes of a single sample.
+	Size int
+	// Repeat is the number of samples.
+	Repeat int
+	// Data holds the raw, unpadded sample bytes.
+	Data []byte
+}
+
+// ParseGPMF decodes data into a flat list of entries.  Nested containers
+// are descended into and their children returned in order.
+func ParseGPMF(data []byte) ([]GPMFEntry, error) {
+	var entries []GPMFEntry
+	for len(data) >= 8 {
+		e := GPMFEntry{
+			Key:    string(data[:4]),
+			Type:   data[4],
+			Size:   int(data[5]),
+			Repeat: int(binary.BigEndian.Uint16(data[6:])),
+		}
+		n := e.Size * e.Repeat
+		padded := (n + 3) &^ 3
+		if 8+padded > len(data) {
+			return entries, fmt.Errorf("mknote: GPMF entry %q overruns its data", e.Key)
+		}
+		body := data[8 : 8+n]
+		if e.Type == 0 {
+			children, err := ParseGPMF(body)
+			entries = append(entries, children...)
+			if err != nil {
+				return entries, err
+			}
+		} else {
+			e.Data = body
+			entries = append(entries, e)
+		}
+		data = data[8+padded:]
+	}
+	return entries, nil
+}
+
+// ExtractGPMF returns the GPMF data stored in the "GoPro" APP6 segment of the
+// JPEG in r.
+func ExtractGPMF(r io.Reader) ([]byte, error) {
+	const app6 = 0xE6
+	br := bufio.NewReader(r)
+	var soi [2]byte
+	if _, err := io.ReadFull(br, soi[:]); err != nil {
+		return nil, err
+	}
+	if soi != [2]byte{0xFF, 0xD8} {
+		return nil, errors.New("mknote: not a JPEG file")
+	}
+	for {
+		var hdr [4]byte
+		if _, err := io.ReadFull(br, hdr[:]); err != nil {
+			return nil, err
+		}
+		if hdr[0] != 0xFF {
+			return nil, errors.New("mknote: invalid JPEG marker")
+		}
+		marker := hdr[1]
+		if marker == 0xDA || marker == 0xD9 {
+			// start of scan or end of image: no more metadata segments
+			return nil, errors.New("mknote: no GoPro GPMF segment found")
+		}
+		n := int(binary.BigEndian.Uint16(hdr[2:])) - 2
+		if n < 0 {
+			return nil, errors.New("mknote: invalid JPEG segment length")
+		}
+		seg := make([]byte, n)
+		if _, err := io.ReadFull(br, seg); err != nil {
+			return nil, err
+		}
+		if marker == app6 && bytes.HasPrefix(seg, []byte("GoPro\000")) {
+			return seg[6:], nil
+		}
+	}
+}
+
+type goPro struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *goPro) Name() string { return "GoPro" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *goPro) CanParse(make string, header []byte) bool {
+	return strings.HasPrefix(make, "GoPro")
+}
+
+// Parse decodes the GPMF settings held in the makernote of GoPro images and
+// adds them to x.
+func (p *goPro) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+	data := bytes.TrimPrefix(m.Val, []byte("GoPro\000"))
+
+	entries, err := ParseGPMF(data)
+	if err != nil {
+		return err
+	}
+	return LoadGPMF(x, entries)
+}
+
+// LoadGPMF adds the known GoPro settings in entries to x.  It can be used to
+// load GPMF data found outside the makernote, e.g. via ExtractGPMF.
+func LoadGPMF(x *exif.Exif, entries []GPMFEntry) error {
+	d := &tiff.Dir{}
+	for _, e := range entries {
+		id, ok := goProKeys[e.Key]
+		if !ok {
+			continue
+		}
+		t, err := gpmfTag(id, e)
+		if err != nil {
+			continue
+		}
+		d.Tags = append(d.Tags, t)
+	}
+	x.LoadTags(d, makerNoteGoProFields, false)
+	return nil
+}
+
+// gpmfTypes maps GPMF type characters to tiff data types.
+var gpmfTypes = map[byte]tiff.DataType{
+	'b': tiff.DTSByte,
+	'B': tiff.DTByte,
+	'c': tiff.DTAscii,
+	'F': tiff.DTAscii,
+	's': tiff.DTSShort,
+	'S': tiff.DTShort,
+	'l': tiff.DTSLong,
+	'L': tiff.DTLong,
+	'f': tiff.DTFloat,
+	'd': tiff.DTDouble,
+}
+
+// gpmfTag converts a GPMF entry into a tiff tag with the given id.
+func gpmfTag(id uint16, e GPMFEntry) (*tiff.Tag, error) {
+	typ, ok := gpmfTypes[e.Type]
+	if !ok || len(e.Data) == 0 {
+		return nil, fmt.Errorf("mknote: unsupported GPMF type %q", e.Type)
+	}
+	val := e.Data
+	if typ == tiff.DTAscii {
+		val = append(append([]byte{}, bytes.TrimRight(val, "\x00 ")...), 0)
+	}
+	// A trailing partial value is dropped.
+	val = val[:len(val)-len(val)%typ.Size()]
+	return tiff.NewTag(id, typ, binary.BigEndian, val)
+}
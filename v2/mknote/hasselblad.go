@@ -0,0 +1,34 @@
+package mknote
+
+import (
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+type hasselblad struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *hasselblad) Name() string { return "Hasselblad" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *hasselblad) CanParse(make string, header []byte) bool {
+	return strings.HasPrefix(strings.ToLower(make), "hasselblad")
+}
+
+// Parse decodes all Hasselblad makernote data found in x (e.g. from 3FR/FFF
+// raw files) and adds it to x.
+func (p *hasselblad) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Hasselblad notes are a single IFD directory with no header.
+	mkNotesDir, err := noteDir(x, m, 0, baseTIFF)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteHasselbladFields, false)
+	return nil
+}
@@ -0,0 +1,82 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type kodak struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *kodak) Name() string { return "Kodak" }
+
+// CanParse implements exif.MakerNoteParser.  Only the fixed-structure Kodak
+// maker note is supported, not the IFD based formats.
+func (_ *kodak) CanParse(make string, header []byte) bool {
+	return strings.Contains(strings.ToUpper(make), "KODAK") &&
+		len(header) >= kodakNoteLen && kodakModelString(header[:8])
+}
+
+// Parse decodes the fixed-structure Kodak makernote found in x and adds it
+// to x.
+func (p *kodak) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+	return kodakLayout.Load(x, m.Val)
+}
+
+// kodakModelString reports whether b looks like the NUL padded model name
+// that starts the fixed-structure Kodak maker note.
+func kodakModelString(b []byte) bool {
+	if b[0] == 0 || bytes.HasPrefix(b, []byte("KDK")) {
+		return false
+	}
+	for _, c := range b {
+		if c != 0 && (c < 0x20 || c > 0x7e) {
+			return false
+		}
+	}
+	return true
+}
+
+// kodakNoteLen is the minimum size of the Kodak structure we decode.
+const kodakNoteLen = 0x6c
+
+// kodakLayout describes the Kodak DC/EasyShare maker note.  It is always
+// big endian, regardless of the byte order of the enclosing tiff structure.
+var kodakLayout = &Layout{
+	Order: binary.BigEndian,
+	Fields: []LayoutField{
+		{Kodak_Model, 0x00, tiff.DTAscii, 8},
+		{Quality, 0x09, tiff.DTByte, 1},
+		{Kodak_BurstMode, 0x0a, tiff.DTByte, 1},
+		{Kodak_ImageWidth, 0x0c, tiff.DTShort, 1},
+		{Kodak_ImageHeight, 0x0e, tiff.DTShort, 1},
+		{Kodak_YearCreated, 0x10, tiff.DTShort, 1},
+		{Kodak_MonthDayCreated, 0x12, tiff.DTByte, 2},
+		{Kodak_TimeCreated, 0x14, tiff.DTByte, 4},
+		{Kodak_ShutterMode, 0x1b, tiff.DTByte, 1},
+		{Kodak_MeteringMode, 0x1c, tiff.DTByte, 1},
+		{Kodak_SequenceNumber, 0x1d, tiff.DTByte, 1},
+		{Kodak_FNumber, 0x1e, tiff.DTShort, 1},
+		{Kodak_ExposureTime, 0x20, tiff.DTLong, 1},
+		{Kodak_ExposureCompensation, 0x24, tiff.DTSShort, 1},
+		{Kodak_FocusMode, 0x38, tiff.DTByte, 1},
+		{Kodak_WhiteBalance, 0x40, tiff.DTByte, 1},
+		{FlashMode, 0x5c, tiff.DTByte, 1},
+		{Kodak_FlashFired, 0x5d, tiff.DTByte, 1},
+		{ISOSettings, 0x5e, tiff.DTShort, 1},
+		{ISOSpeed, 0x60, tiff.DTShort, 1},
+		{Kodak_TotalZoom, 0x62, tiff.DTShort, 1},
+		{Kodak_DateTimeStamp, 0x64, tiff.DTShort, 1},
+		{ColorMode, 0x66, tiff.DTShort, 1},
+		{DigitalZoom, 0x68, tiff.DTShort, 1},
+		{Sharpening, 0x6b, tiff.DTSByte, 1},
+	},
+}
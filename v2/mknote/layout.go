@@ -0,0 +1,83 @@
+package mknote
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Some vendors (e.g. Kodak, older Minolta) store maker note data as a fixed
+// binary structure rather than a tiff IFD.  A Layout describes such a
+// structure so it can be loaded into an Exif with the same field naming as
+// IFD based maker notes.
+
+// LayoutField describes one value of a fixed-structure maker note.
+type LayoutField struct {
+	Name exif.FieldName
+	// Offset is the byte offset of the value from the start of the
+	// structure.
+	Offset int
+	// Type is the tiff data type the value is encoded as.
+	Type tiff.DataType
+	// Count is the number of values of type Type (e.g. the string length
+	// for DTAscii).  Zero is treated as one.
+	Count int
+}
+
+// Layout describes a fixed-structure (non-IFD) maker note.
+type Layout struct {
+	// Order is the byte order of the structure.  If nil, the byte order of
+	// the Exif's tiff structure is used.
+	Order  binary.ByteOrder
+	Fields []LayoutField
+}
+
+// Dir decodes the fields of l found in data into a tiff Dir.  The Id of
+// each tag is the index of its field in l.Fields.  Fields that extend past
+// the end of data are skipped.
+func (l *Layout) Dir(data []byte, order binary.ByteOrder) (*tiff.Dir, error) {
+	if l.Order != nil {
+		order = l.Order
+	}
+	d := &tiff.Dir{}
+	for i, f := range l.Fields {
+		size := f.Type.Size()
+		if size == 0 {
+			size = 1
+		}
+		count := f.Count
+		if count == 0 {
+			count = 1
+		}
+		end := f.Offset + size*count
+		if f.Offset < 0 || end > len(data) {
+			continue
+		}
+		val := data[f.Offset:end]
+		if f.Type == tiff.DTAscii {
+			val = append(append([]byte{}, val...), 0)
+		}
+		t, err := tiff.NewTag(uint16(i), f.Type, order, val)
+		if err != nil {
+			return d, fmt.Errorf("mknote: field %v: %v", f.Name, err)
+		}
+		d.Tags = append(d.Tags, t)
+	}
+	return d, nil
+}
+
+// Load decodes the fields of l found in data and adds them to x.
+func (l *Layout) Load(x *exif.Exif, data []byte) error {
+	d, err := l.Dir(data, x.Tiff.Order)
+	if err != nil {
+		return err
+	}
+	fields := make(map[uint16]exif.FieldName, len(l.Fields))
+	for i, f := range l.Fields {
+		fields[uint16(i)] = f.Name
+	}
+	x.LoadTags(d, fields, false)
+	return nil
+}
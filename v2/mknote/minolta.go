@@ -0,0 +1,103 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type minolta struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *minolta) Name() string { return "Minolta" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *minolta) CanParse(make string, header []byte) bool {
+	if !strings.Contains(strings.ToUpper(make), "MINOLTA") {
+		return false
+	}
+	for _, prefix := range []string{"MINOL", "CAMER", "MLY0", "KC", "+M+M", "\xd7"} {
+		if bytes.HasPrefix(header, []byte(prefix)) {
+			// a different maker note format
+			return false
+		}
+	}
+	return true
+}
+
+// Parse decodes all (pre Konica merger style) Minolta makernote data found in
+// x and adds it to x, including the fixed-structure camera settings block.
+func (p *minolta) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Minolta notes are a single IFD directory with no header.
+	mkNotesDir, err := noteDir(x, m, 0, baseTIFF)
+	if err != nil {
+		return err
+	}
+
+	// The camera settings are a fixed array of big endian 32 bit values
+	// stored in an undefined tag.  They are loaded first so that values
+	// also present as individual IFD tags take precedence.
+	for _, tag := range mkNotesDir.Tags {
+		if tag.Id == 0x0001 || tag.Id == 0x0003 {
+			if err := minoltaSettingsLayout.Load(x, tag.Val); err != nil {
+				return err
+			}
+		}
+	}
+	x.LoadTags(mkNotesDir, makerNoteMinoltaFields, false)
+	return nil
+}
+
+// minoltaSettingsLayout describes the Minolta camera settings block.  Values
+// are stored raw; see the exiftool Minolta documentation for their units.
+var minoltaSettingsLayout = &Layout{
+	Order: binary.BigEndian,
+	Fields: []LayoutField{
+		{Minolta_ExposureMode, 4 * 1, tiff.DTLong, 1},
+		{FlashMode, 4 * 2, tiff.DTLong, 1},
+		{Minolta_WhiteBalance, 4 * 3, tiff.DTLong, 1},
+		{Minolta_ImageSize, 4 * 4, tiff.DTLong, 1},
+		{Quality, 4 * 5, tiff.DTLong, 1},
+		{Minolta_DriveMode, 4 * 6, tiff.DTLong, 1},
+		{Minolta_MeteringMode, 4 * 7, tiff.DTLong, 1},
+		{ISOSpeed, 4 * 8, tiff.DTLong, 1},
+		{Minolta_ExposureTime, 4 * 9, tiff.DTLong, 1},
+		{Minolta_FNumber, 4 * 10, tiff.DTLong, 1},
+		{Minolta_MacroMode, 4 * 11, tiff.DTLong, 1},
+		{DigitalZoom, 4 * 12, tiff.DTLong, 1},
+		{Minolta_ExposureCompensation, 4 * 13, tiff.DTLong, 1},
+		{Minolta_BracketStep, 4 * 14, tiff.DTLong, 1},
+		{Minolta_IntervalLength, 4 * 16, tiff.DTLong, 1},
+		{Minolta_IntervalNumber, 4 * 17, tiff.DTLong, 1},
+		{Minolta_FocalLength, 4 * 18, tiff.DTLong, 1},
+		{FocusDistance, 4 * 19, tiff.DTLong, 1},
+		{Minolta_FlashFired, 4 * 20, tiff.DTLong, 1},
+		{Minolta_Date, 4 * 21, tiff.DTLong, 1},
+		{Minolta_Time, 4 * 22, tiff.DTLong, 1},
+		{Minolta_MaxAperture, 4 * 23, tiff.DTLong, 1},
+		{Minolta_FileNumberMemory, 4 * 26, tiff.DTLong, 1},
+		{Minolta_LastFileNumber, 4 * 27, tiff.DTLong, 1},
+		{Minolta_ColorBalanceRed, 4 * 28, tiff.DTLong, 1},
+		{Minolta_ColorBalanceGreen, 4 * 29, tiff.DTLong, 1},
+		{Minolta_ColorBalanceBlue, 4 * 30, tiff.DTLong, 1},
+		{Minolta_Saturation, 4 * 31, tiff.DTLong, 1},
+		{Minolta_Contrast, 4 * 32, tiff.DTLong, 1},
+		{Sharpening, 4 * 33, tiff.DTLong, 1},
+		{Minolta_SubjectProgram, 4 * 34, tiff.DTLong, 1},
+		{FlashExposureComp, 4 * 35, tiff.DTLong, 1},
+		{ISOSettings, 4 * 36, tiff.DTLong, 1},
+		{ModelID, 4 * 37, tiff.DTLong, 1},
+		{ColorMode, 4 * 40, tiff.DTLong, 1},
+		{Minolta_Brightness, 4 * 44, tiff.DTLong, 1},
+		{Minolta_FocusMode, 4 * 48, tiff.DTLong, 1},
+		{Minolta_FocusArea, 4 * 49, tiff.DTLong, 1},
+	},
+}
@@ -0,0 +1,138 @@
+// Package mknote provides makernote parsers that can be used with goexif/exif.
+package mknote
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+var (
+	// Canon is an exif.Parser for canon makernote data.
+	Canon = &canon{}
+	// NikonV3 is an exif.Parser for nikon makernote data.
+	NikonV3 = &nikonV3{}
+	// Sigma is an exif.Parser for sigma/foveon makernote data.
+	Sigma = &sigma{}
+	// GoPro is an exif.Parser for GoPro makernote (GPMF) data.
+	GoPro = &goPro{}
+	// Ricoh is an exif.Parser for ricoh (including Theta) makernote data.
+	Ricoh = &ricoh{}
+	// Hasselblad is an exif.Parser for hasselblad makernote data.
+	Hasselblad = &hasselblad{}
+	// PhaseOne is an exif.Parser for phase one makernote data.
+	PhaseOne = &phaseOne{}
+	// Kodak is an exif.Parser for kodak makernote data.
+	Kodak = &kodak{}
+	// Minolta is an exif.Parser for (older) minolta makernote data.
+	Minolta = &minolta{}
+	// Casio is an exif.Parser for casio makernote data.
+	Casio = &casio{}
+	// Olympus is an exif.Parser for olympus (and OM System) makernote data.
+	Olympus = &olympus{}
+	// Sony is an exif.Parser for sony makernote data.
+	Sony = &sony{}
+	// All is a list of all available makernote parsers
+	All = []exif.Parser{Canon, NikonV3, Sigma, GoPro, Ricoh, Hasselblad, PhaseOne, Kodak, Minolta, Casio, Olympus, Sony}
+)
+
+// makeOf returns the Make field value of x, or "" if it has none.
+func makeOf(x *exif.Exif) string {
+	mk, err := x.Get(exif.Make)
+	if err != nil {
+		return ""
+	}
+	val, _ := mk.StringVal()
+	return val
+}
+
+type canon struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *canon) Name() string { return "Canon" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *canon) CanParse(make string, header []byte) bool {
+	return make == "Canon"
+}
+
+// Parse decodes all Canon makernote data found in x and adds it to x.
+func (p *canon) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Canon notes are a single IFD directory with no header.
+	mkNotesDir, err := noteDir(x, m, 0, baseTIFF)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteCanonFields, false)
+	return nil
+}
+
+type nikonV3 struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *nikonV3) Name() string { return "NikonV3" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *nikonV3) CanParse(make string, header []byte) bool {
+	return bytes.HasPrefix(header, []byte("Nikon\000"))
+}
+
+// Parse decodes all Nikon makernote data found in x and adds it to x.
+func (p *nikonV3) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Nikon v3 maker note is a self-contained IFD (offsets are relative
+	// to the start of the maker note), after the signature, a 4 byte
+	// version and its own TIFF header.
+	if len(m.Val) < 18 {
+		return errors.New("mknote: Nikon maker note is too short")
+	}
+	mkNotes, err := tiff.Decode(bytes.NewReader(m.Val[10:]))
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotes.Dirs[0], makerNoteNikon3Fields, false)
+	return nil
+}
+
+type sigma struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *sigma) Name() string { return "Sigma" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *sigma) CanParse(make string, header []byte) bool {
+	return bytes.HasPrefix(header, []byte("SIGMA\000\000\000")) ||
+		bytes.HasPrefix(header, []byte("FOVEON\000\000"))
+}
+
+// Parse decodes all Sigma makernote data found in x and adds it to x.
+func (p *sigma) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Sigma notes are an 8 byte signature and a 2 byte version followed by
+	// a single IFD.
+	const hdrLen = 10
+	if len(m.Val) < hdrLen {
+		return nil
+	}
+	mkNotesDir, err := noteDir(x, m, hdrLen, baseTIFF)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteSigmaFields, false)
+	return nil
+}
@@ -0,0 +1,443 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// This file holds a table of tiny synthesized maker notes, one or more per
+// parser.  Each fixture is wrapped in a minimal TIFF structure (IFD0 with
+// Make and an Exif sub-IFD holding the MakerNote), decoded with every parser
+// in All registered and checked for the expected fields.  To add a vendor,
+// add its parser to All and a fixture to mknoteTests.
+
+func init() {
+	exif.RegisterParsers(All...)
+}
+
+type entry struct {
+	id    uint16
+	typ   tiff.DataType
+	count uint32
+	val   []byte
+}
+
+func ascii(id uint16, s string) entry {
+	return entry{id, tiff.DTAscii, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+func short(order binary.ByteOrder, id uint16, vs ...uint16) entry {
+	val := make([]byte, 2*len(vs))
+	for i, v := range vs {
+		order.PutUint16(val[2*i:], v)
+	}
+	return entry{id, tiff.DTShort, uint32(len(vs)), val}
+}
+
+func long(order binary.ByteOrder, id uint16, vs ...uint32) entry {
+	val := make([]byte, 4*len(vs))
+	for i, v := range vs {
+		order.PutUint32(val[4*i:], v)
+	}
+	return entry{id, tiff.DTLong, uint32(len(vs)), val}
+}
+
+func srational(order binary.ByteOrder, id uint16, vs ...int32) entry {
+	val := make([]byte, 4*len(vs))
+	for i, v := range vs {
+		order.PutUint32(val[4*i:], uint32(v))
+	}
+	return entry{id, tiff.DTSRational, uint32(len(vs) / 2), val}
+}
+
+func rational(order binary.ByteOrder, id uint16, vs ...uint32) entry {
+	e := long(order, id, vs...)
+	e.typ, e.count = tiff.DTRational, e.count/2
+	return e
+}
+
+// ifd encodes entries as an IFD that will be placed at offset base of the
+// structure its value offsets are relative to.  Values that don't fit the
+// entry are stored right after the IFD.
+func ifd(order binary.ByteOrder, base uint32, entries ...entry) []byte {
+	var dir, vals bytes.Buffer
+	valStart := base + 2 + 12*uint32(len(entries)) + 4
+	binary.Write(&dir, order, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&dir, order, e.id)
+		binary.Write(&dir, order, e.typ)
+		binary.Write(&dir, order, e.count)
+		if len(e.val) > 4 {
+			binary.Write(&dir, order, valStart+uint32(vals.Len()))
+			vals.Write(e.val)
+			if vals.Len()%2 == 1 {
+				vals.WriteByte(0)
+			}
+		} else {
+			dir.Write(append(e.val, make([]byte, 4-len(e.val))...))
+		}
+	}
+	binary.Write(&dir, order, uint32(0))
+	return append(dir.Bytes(), vals.Bytes()...)
+}
+
+// tiffHeader returns the 8 byte tiff header for order with IFD0 at offset 8.
+func tiffHeader(order binary.ByteOrder) []byte {
+	h := []byte("II*\x00\x08\x00\x00\x00")
+	if order == binary.BigEndian {
+		h = []byte("MM\x00*\x00\x00\x00\x08")
+	}
+	return h
+}
+
+// buildExif returns a TIFF structure with the given Make whose Exif sub-IFD
+// holds a maker note generated by mk.  mk is passed the absolute offset at
+// which the maker note is stored.
+func buildExif(order binary.ByteOrder, make string, mk func(off uint32) []byte) []byte {
+	const exifIFD = 100
+	ifd0 := ifd(order, 8, ascii(0x010F, make), long(order, exif.ExifIFDPointerID, exifIFD))
+	if 8+len(ifd0) > exifIFD {
+		panic("Make too long for fixture layout")
+	}
+	data := append(tiffHeader(order), ifd0...)
+	data = append(data, bytes.Repeat([]byte{0}, exifIFD-len(data))...)
+
+	// The Exif IFD has a single entry; the maker note directly follows it.
+	mkOff := uint32(exifIFD + 2 + 12 + 4)
+	note := mk(mkOff)
+	data = append(data, ifd(order, exifIFD, entry{exif.MakerNoteID, tiff.DTUndefined, uint32(len(note)), note})...)
+	return data
+}
+
+var le, be = binary.LittleEndian, binary.BigEndian
+
+var mknoteTests = []struct {
+	name   string
+	parser exif.Parser
+	order  binary.ByteOrder
+	make   string
+	note   func(off uint32) []byte
+	want   map[exif.FieldName]string
+	// n is the total number of makernote fields expected, if it is
+	// larger than len(want).
+	n int
+}{
+	{
+		name: "Canon", parser: Canon, order: be, make: "Canon",
+		note: func(off uint32) []byte {
+			return ifd(be, off, ascii(0x0006, "IMG:EOS 5D JPEG"), long(be, 0x0010, 0x80000213))
+		},
+		want: map[exif.FieldName]string{ImageType: `"IMG:EOS 5D JPEG"`, ModelID: `2147484179`},
+	},
+	{
+		// value offsets relative to the maker note
+		name: "CanonNoteBase", parser: Canon, order: be, make: "Canon",
+		note: func(off uint32) []byte {
+			return ifd(be, 0, ascii(0x0006, "IMG:EOS 5D JPEG"), long(be, 0x0010, 0x80000213))
+		},
+		want: map[exif.FieldName]string{ImageType: `"IMG:EOS 5D JPEG"`, ModelID: `2147484179`},
+	},
+	{
+		name: "NikonV3", parser: NikonV3, order: le, make: "NIKON CORPORATION",
+		note: func(off uint32) []byte {
+			note := []byte("Nikon\x00\x02\x10\x00\x00")
+			note = append(note, tiffHeader(be)...)
+			return append(note, ifd(be, 8, short(be, 0x0002, 0, 200), ascii(0x0004, "FINE"))...)
+		},
+		want: map[exif.FieldName]string{ISOSpeed: `[0,200]`, Quality: `"FINE"`},
+	},
+	{
+		name: "Sigma", parser: Sigma, order: le, make: "SIGMA",
+		note: func(off uint32) []byte {
+			note := []byte("SIGMA\x00\x00\x00\x01\x00")
+			return append(note, ifd(le, off+10, ascii(0x0002, "1234567"), ascii(0x0003, "SINGLE"))...)
+		},
+		want: map[exif.FieldName]string{SerialNumber: `"1234567"`, Sigma_DriveMode: `"SINGLE"`},
+	},
+	{
+		// value offsets relative to the maker note IFD
+		name: "SigmaIFDBase", parser: Sigma, order: le, make: "SIGMA",
+		note: func(off uint32) []byte {
+			note := []byte("SIGMA\x00\x00\x00\x01\x00")
+			return append(note, ifd(le, 0, ascii(0x0002, "1234567"), ascii(0x0003, "SINGLE"))...)
+		},
+		want: map[exif.FieldName]string{SerialNumber: `"1234567"`, Sigma_DriveMode: `"SINGLE"`},
+	},
+	{
+		name: "GoPro", parser: GoPro, order: le, make: "GoPro",
+		note: func(off uint32) []byte {
+			return []byte("DEVC\x00\x01\x00\x20" +
+				"FMWR\x63\x0b\x00\x01HD6.01.01.0\x00" +
+				"PIMX\x4c\x04\x00\x01\x00\x00\x06\x40")
+		},
+		want: map[exif.FieldName]string{FirmwareVersion: `"HD6.01.01.0"`, GoPro_ISOMax: `1600`},
+	},
+	{
+		name: "Ricoh", parser: Ricoh, order: le, make: "RICOH",
+		note: func(off uint32) []byte {
+			return append([]byte("Ricoh\x00\x00\x00"), ifd(le, off+8, ascii(0x0002, "1.10"))...)
+		},
+		want: map[exif.FieldName]string{FirmwareVersion: `"1.10"`},
+	},
+	{
+		name: "RicohTheta", parser: Ricoh, order: le, make: "RICOH",
+		note: func(off uint32) []byte {
+			note := []byte("RICOH\x00II")
+			// main IFD (2 entries) at 8, Theta IFD right after it
+			theta := uint32(8 + 2 + 2*12 + 4)
+			note = append(note, ifd(le, 8, ascii(0x0001, "Rdc"), long(le, thetaSubdirID, theta))...)
+			return append(note, ifd(le, theta, srational(le, 0x0003, 3, 2, -2, 1), rational(le, 0x0004, 90, 1))...)
+		},
+		want: map[exif.FieldName]string{
+			Ricoh_MakerNoteType: `"Rdc"`,
+			Ricoh_ThetaSubdir:   `38`,
+			Theta_Accelerometer: `["3/2","-2/1"]`,
+			Theta_Compass:       `"90/1"`,
+		},
+	},
+	{
+		name: "Hasselblad", parser: Hasselblad, order: le, make: "Hasselblad",
+		note: func(off uint32) []byte {
+			return ifd(le, off, ascii(0x0015, "H6D-100c"))
+		},
+		want: map[exif.FieldName]string{Hasselblad_CameraModelName: `"H6D-100c"`},
+	},
+	{
+		name: "PhaseOne", parser: PhaseOne, order: le, make: "Phase One",
+		note: func(off uint32) []byte {
+			d := make([]byte, 16+8+16*3+8)
+			copy(d, "IIII\x00waR")
+			le.PutUint32(d[8:], 16)
+			le.PutUint32(d[16:], 3)
+			put := func(i int, id, format, size, val uint32) {
+				e := d[24+16*i:]
+				le.PutUint32(e, id)
+				le.PutUint32(e[4:], format)
+				le.PutUint32(e[8:], size)
+				le.PutUint32(e[12:], val)
+			}
+			put(0, 0x0105, phaseOneInt32, 4, 400)
+			put(1, 0x0400, phaseOneInt32, 4, math.Float32bits(0.004))
+			put(2, 0x0410, phaseOneString, 8, 72)
+			copy(d[72:], "IQ180\x00\x00\x00")
+			return d
+		},
+		want: map[exif.FieldName]string{
+			PhaseOne_ISO:               `400`,
+			PhaseOne_ShutterSpeedValue: `0.004000000189989805`,
+			PhaseOne_CameraModel:       `"IQ180"`,
+		},
+	},
+	{
+		name: "Kodak", parser: Kodak, order: le, make: "EASTMAN KODAK COMPANY",
+		note: func(off uint32) []byte {
+			d := make([]byte, kodakNoteLen)
+			copy(d, "DC4800\x00\x00")
+			be.PutUint16(d[0x1e:], 280)
+			be.PutUint16(d[0x60:], 400)
+			return d
+		},
+		want: map[exif.FieldName]string{Kodak_Model: `"DC4800"`, Kodak_FNumber: `280`, ISOSpeed: `400`},
+		n:    len(kodakLayout.Fields),
+	},
+	{
+		name: "Minolta", parser: Minolta, order: be, make: "Minolta Co., Ltd.",
+		note: func(off uint32) []byte {
+			settings := make([]byte, 4*50)
+			be.PutUint32(settings[4*8:], 3)
+			return ifd(be, off, long(be, 0x0101, 1), entry{0x0003, tiff.DTUndefined, uint32(len(settings)), settings})
+		},
+		want: map[exif.FieldName]string{ColorMode: `1`, ISOSpeed: `3`},
+		n:    1 + len(minoltaSettingsLayout.Fields),
+	},
+	{
+		name: "Casio", parser: Casio, order: le, make: "CASIO",
+		note: func(off uint32) []byte {
+			return ifd(le, off, short(le, 0x0014, 80))
+		},
+		want: map[exif.FieldName]string{ISOSpeed: `80`},
+	},
+	{
+		name: "CasioQVC", parser: Casio, order: be, make: "CASIO COMPUTER CO.,LTD.",
+		note: func(off uint32) []byte {
+			return append([]byte("QVC\x00\x00\x00"), ifd(be, off+6, rational(be, 0x001d, 63, 10))...)
+		},
+		want: map[exif.FieldName]string{Casio_FocalLength: `"63/10"`},
+	},
+	{
+		name: "Olympus", parser: Olympus, order: le, make: "OLYMPUS CORPORATION",
+		note: func(off uint32) []byte {
+			// main IFD (2 entries) at 12, CameraSettings IFD right after it
+			settings := uint32(12 + 2 + 2*12 + 4)
+			note := []byte("OLYMPUS\x00II\x03\x00")
+			note = append(note, ifd(le, 12,
+				entry{0x0000, tiff.DTUndefined, 4, []byte("0100")},
+				entry{0x2020, tiff.DTIFD, 1, []byte{byte(settings), 0, 0, 0}})...)
+			return append(note, ifd(le, settings, short(le, 0x0600, 5, 3, 64), long(le, 0x0804, 0, 0))...)
+		},
+		want: map[exif.FieldName]string{
+			Olympus_MakerNoteVersion: `"0100"`,
+			Olympus_CameraSettings:   `42`,
+			Olympus_DriveMode:        `[5,3,64]`,
+			Olympus_StackedImage:     `[0,0]`,
+		},
+	},
+	{
+		// older notes with offsets w.r.t. the tiff structure
+		name: "OlympusOld", parser: Olympus, order: be, make: "OLYMPUS OPTICAL CO.,LTD",
+		note: func(off uint32) []byte {
+			return append([]byte("OLYMP\x00\x01\x00"), ifd(be, off+8, ascii(0x0207, "SX756"))...)
+		},
+		want: map[exif.FieldName]string{Olympus_CameraType: `"SX756"`},
+	},
+	{
+		name: "Sony", parser: Sony, order: le, make: "SONY",
+		note: func(off uint32) []byte {
+			pixelShift := []byte{0x12, 0x34, 0, 0, 0x78, 0x56, 0, 0, 2, 4}
+			return append([]byte("SONY DSC \x00\x00\x00"), ifd(le, off+12,
+				long(le, 0xb001, 358),
+				entry{0x202f, tiff.DTUndefined, uint32(len(pixelShift)), pixelShift})...)
+		},
+		want: map[exif.FieldName]string{Sony_ModelID: `358`, Sony_PixelShiftInfo: `"4xV"`},
+	},
+}
+
+func TestParsers(t *testing.T) {
+	covered := map[exif.Parser]bool{}
+	for _, tt := range mknoteTests {
+		covered[tt.parser] = true
+
+		data := buildExif(tt.order, tt.make, tt.note)
+		x, err := exif.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("%s: decode failed: %v", tt.name, err)
+			continue
+		}
+		if name := tt.parser.(exif.MakerNoteParser).Name(); x.MakerNoteParser() != name {
+			t.Errorf("%s: makernote parsed by %q, want %q", tt.name, x.MakerNoteParser(), name)
+		}
+		for field := range tt.want {
+			q := x.QualifiedName(field)
+			if group, _ := q.Group(); group != exif.GroupMakerNote+"."+x.MakerNoteParser() {
+				t.Errorf("%s: %v has qualified name %v", tt.name, field, q)
+			}
+		}
+
+		got := map[exif.FieldName]string{}
+		x.Walk(walkFunc(func(name exif.FieldName, tag *tiff.Tag) error {
+			switch name {
+			case exif.Make, exif.ExifIFDPointer, exif.MakerNote:
+			default:
+				got[name] = tag.String()
+			}
+			return nil
+		}))
+		for name, want := range tt.want {
+			if got[name] != want {
+				t.Errorf("%s: %v = %s, want %s", tt.name, name, got[name], want)
+			}
+		}
+		n := tt.n
+		if n < len(tt.want) {
+			n = len(tt.want)
+		}
+		if len(got) != n {
+			t.Errorf("%s: got %d fields, want %d: %v", tt.name, len(got), n, got)
+		}
+	}
+
+	for _, p := range All {
+		if !covered[p] {
+			t.Errorf("parser %T has no test fixture", p)
+		}
+	}
+}
+
+func TestParsersTruncated(t *testing.T) {
+	for _, tt := range mknoteTests {
+		n := len(tt.note(0))
+		for size := 0; size < n; size++ {
+			data := buildExif(tt.order, tt.make, func(off uint32) []byte {
+				return tt.note(off)[:size]
+			})
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("%s: note truncated to %d bytes: panic: %v", tt.name, size, r)
+					}
+				}()
+				exif.Decode(bytes.NewReader(data))
+			}()
+		}
+	}
+	x, err := exif.Decode(bytes.NewReader(buildExif(binary.BigEndian, "NIKON", func(uint32) []byte {
+		return []byte("Nikon\x00ab")
+	})))
+	if err == nil || x == nil {
+		t.Errorf("short Nikon note: error %v", err)
+	}
+}
+
+func TestTheta(t *testing.T) {
+	for _, tt := range mknoteTests {
+		if tt.name != "RicohTheta" {
+			continue
+		}
+		x, err := exif.Decode(bytes.NewReader(buildExif(tt.order, tt.make, tt.note)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		roll, pitch, err := ThetaZenith(x)
+		if err != nil || roll != 1.5 || pitch != -2 {
+			t.Errorf("ThetaZenith = %v, %v, %v; want 1.5, -2", roll, pitch, err)
+		}
+		if c, err := ThetaCompass(x); err != nil || c != 90 {
+			t.Errorf("ThetaCompass = %v, %v; want 90", c, err)
+		}
+	}
+}
+
+func TestDecoderMakerNoteParsers(t *testing.T) {
+	tt := mknoteTests[0]
+	data := buildExif(tt.order, tt.make, tt.note)
+
+	tests := []struct {
+		parsers []string
+		want    string
+	}{
+		{nil, "Canon"},
+		{[]string{}, ""},
+		{[]string{"NikonV3"}, ""},
+		{[]string{"NikonV3", "Canon"}, "Canon"},
+	}
+	for _, test := range tests {
+		d := &exif.Decoder{MakerNoteParsers: test.parsers}
+		x, err := d.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("%v: decode failed: %v", test.parsers, err)
+			continue
+		}
+		if got := x.MakerNoteParser(); got != test.want {
+			t.Errorf("%v: makernote parsed by %q, want %q", test.parsers, got, test.want)
+		}
+		if _, err := x.Get(ImageType); (err == nil) != (test.want == "Canon") {
+			t.Errorf("%v: Canon field presence mismatch (err=%v)", test.parsers, err)
+		}
+	}
+
+	d := &exif.Decoder{MakerNoteParsers: []string{"Leica"}}
+	if _, err := d.Decode(bytes.NewReader(data)); err == nil {
+		t.Errorf("unknown makernote parser: got no error")
+	}
+}
+
+type walkFunc func(exif.FieldName, *tiff.Tag) error
+
+func (f walkFunc) Walk(name exif.FieldName, tag *tiff.Tag) error {
+	return f(name, tag)
+}
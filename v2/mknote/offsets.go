@@ -0,0 +1,100 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// offsetBase is the position the value offsets of a makernote IFD are
+// relative to.
+type offsetBase int
+
+const (
+	// baseTIFF is the start of the tiff structure, as for all other IFDs.
+	baseTIFF offsetBase = iota
+	// baseNote is the start of the makernote.
+	baseNote
+	// baseIFD is the start of the makernote IFD, after any header.
+	baseIFD
+)
+
+// noteDir decodes the IFD found hdrLen bytes into the makernote m of x.
+// Value offsets are resolved against base, the one used by the vendor,
+// unless another base puts more of the values within the makernote: some
+// vendors (or firmware versions) compute offsets relative to the makernote
+// or its IFD, so strictly resolved values would hold unrelated bytes.
+func noteDir(x *exif.Exif, m *tiff.Tag, hdrLen int64, base offsetBase) (*tiff.Dir, error) {
+	order := x.Tiff.Order
+	note := int64(m.ValOffset)
+	starts := map[offsetBase]int64{baseTIFF: 0, baseNote: note, baseIFD: note + hdrLen}
+
+	best, bestFit := base, fittingValues(m.Val, hdrLen, order, starts[base]-note)
+	for _, b := range []offsetBase{baseTIFF, baseNote, baseIFD} {
+		if fit := fittingValues(m.Val, hdrLen, order, starts[b]-note); fit > bestFit {
+			best, bestFit = b, fit
+		}
+	}
+
+	// Reader offsets need to be w.r.t. the chosen base.
+	data := append(make([]byte, m.ValOffset), m.Val...)
+	start := starts[best]
+	r := io.NewSectionReader(bytes.NewReader(data), start, int64(len(data))-start)
+	if _, err := r.Seek(note+hdrLen-start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	d, _, err := tiff.DecodeDir(r, order)
+	return d, err
+}
+
+// fittingValues returns the number of entries of the IFD at hdrLen in note
+// whose value is stored out of the entry and fits, if value offsets are
+// relative to position delta in note: it lies within note without
+// overlapping the IFD and, for ASCII values, holds NUL terminated text.
+func fittingValues(note []byte, hdrLen int64, order binary.ByteOrder, delta int64) int {
+	size := int64(len(note))
+	if hdrLen+2 > size {
+		return 0
+	}
+	n := int64(order.Uint16(note[hdrLen:]))
+	ifdEnd := hdrLen + 2 + 12*n + 4
+	fit := 0
+	for e := hdrLen + 2; e < hdrLen+2+12*n && e+12 <= size; e += 12 {
+		typ := tiff.DataType(order.Uint16(note[e+2:]))
+		typSize := int64(typ.Size())
+		if typSize == 0 {
+			typSize = 1
+		}
+		valLen := typSize * int64(order.Uint32(note[e+4:]))
+		if valLen <= 4 {
+			continue
+		}
+		off := int64(order.Uint32(note[e+8:])) + delta
+		if off < 0 || off+valLen > size || (off < ifdEnd && off+valLen > hdrLen) {
+			continue
+		}
+		if typ == tiff.DTAscii && !isText(note[off:off+valLen]) {
+			continue
+		}
+		fit++
+	}
+	return fit
+}
+
+// isText reports whether val is printable ASCII text followed by at least
+// one NUL.
+func isText(val []byte) bool {
+	s := bytes.TrimRight(val, "\x00")
+	if len(s) == len(val) {
+		return false
+	}
+	for _, c := range s {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type olympus struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *olympus) Name() string { return "Olympus" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *olympus) CanParse(make string, header []byte) bool {
+	return bytes.HasPrefix(header, []byte("OLYMP\000")) ||
+		bytes.HasPrefix(header, []byte("OLYMPUS\000")) ||
+		bytes.HasPrefix(header, []byte("OM SYSTEM\000"))
+}
+
+// Parse decodes the Olympus makernote data found in x and adds it to x,
+// including the CameraSettings sub-IFD.
+func (p *olympus) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	var buf *bytes.Reader
+	var order binary.ByteOrder
+	var hdrLen int64
+	switch {
+	case bytes.HasPrefix(m.Val, []byte("OLYMP\000")):
+		// Older notes have an 8 byte header followed by an IFD with
+		// offsets w.r.t. the original tiff structure.
+		order = x.Tiff.Order
+		buf = bytes.NewReader(append(make([]byte, m.ValOffset), m.Val...))
+		hdrLen = int64(m.ValOffset) + 8
+	default:
+		// Newer notes carry their own byte order after the signature
+		// and offsets are relative to the start of the maker note.
+		hdrLen = 12
+		if bytes.HasPrefix(m.Val, []byte("OM SYSTEM\000")) {
+			hdrLen = 16
+		}
+		if int64(len(m.Val)) < hdrLen {
+			return nil
+		}
+		order = binary.LittleEndian
+		if m.Val[hdrLen-4] == 'M' {
+			order = binary.BigEndian
+		}
+		buf = bytes.NewReader(m.Val)
+	}
+	buf.Seek(hdrLen, 0)
+
+	mkNotesDir, _, err := tiff.DecodeDir(buf, order)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteOlympusFields, false)
+
+	for _, tag := range mkNotesDir.Tags {
+		if tag.Id != 0x2020 {
+			continue
+		}
+		// The sub-IFD is pointed to by an IFD offset or, in older notes,
+		// stored as the undefined value of the tag.
+		offset, err := tag.Int64(0)
+		if err != nil {
+			offset = int64(tag.ValOffset)
+		}
+		if _, err := buf.Seek(offset, 0); err != nil {
+			return err
+		}
+		dir, _, err := tiff.DecodeDir(buf, order)
+		if err != nil {
+			return err
+		}
+		x.LoadTags(dir, makerNoteOlympusCameraSettingsFields, false)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+// Phase One maker notes (IIQ raw files) are not IFDs.  They start with
+// "IIII" or "MMMM" (byte order), a "Raw" signature and a 4 byte offset to a
+// directory.  The directory holds an entry count, 4 unknown bytes and 16 byte
+// entries: tag, format, size and value (or value offset if size > 4), all
+// 32 bit.  Offsets are relative to the start of the maker note.
+
+// Phase One entry formats.
+const (
+	phaseOneString = 1
+	phaseOneInt16  = 2
+	phaseOneInt32  = 4
+)
+
+type phaseOne struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *phaseOne) Name() string { return "PhaseOne" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *phaseOne) CanParse(make string, header []byte) bool {
+	return len(header) >= 12 &&
+		(bytes.HasPrefix(header, []byte("IIII")) && string(header[5:8]) == "waR" ||
+			bytes.HasPrefix(header, []byte("MMMMRaw")))
+}
+
+// Parse decodes all Phase One makernote data found in x and adds it to x.
+func (p *phaseOne) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+	d, err := decodePhaseOneDir(m.Val)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(d, makerNotePhaseOneFields, false)
+	return nil
+}
+
+// decodePhaseOneDir converts the Phase One directory in data to a tiff Dir.
+// data must be a Phase One maker note.
+func decodePhaseOneDir(data []byte) (*tiff.Dir, error) {
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == 'M' {
+		order = binary.BigEndian
+	}
+
+	start := uint64(order.Uint32(data[8:]))
+	if start+8 > uint64(len(data)) {
+		return nil, errors.New("mknote: invalid Phase One directory offset")
+	}
+	n := uint64(order.Uint32(data[start:]))
+	if start+8+16*n > uint64(len(data)) {
+		return nil, errors.New("mknote: invalid Phase One directory entry count")
+	}
+
+	d := &tiff.Dir{}
+	for i := uint64(0); i < n; i++ {
+		e := data[start+8+16*i:]
+		id := order.Uint32(e)
+		format := order.Uint32(e[4:])
+		size := uint64(order.Uint32(e[8:]))
+		if id > math.MaxUint16 || size == 0 {
+			continue
+		}
+
+		val := e[12:16]
+		if size > 4 {
+			off := uint64(order.Uint32(e[12:]))
+			if off+size > uint64(len(data)) {
+				continue
+			}
+			val = data[off : off+size]
+		} else {
+			val = val[:size]
+		}
+
+		var typ tiff.DataType
+		switch {
+		case format == phaseOneString:
+			typ = tiff.DTAscii
+		case phaseOneFloats[uint16(id)]:
+			typ = tiff.DTFloat
+		case format == phaseOneInt16:
+			typ = tiff.DTSShort
+		case format == phaseOneInt32:
+			typ = tiff.DTSLong
+		default:
+			typ = tiff.DTUndefined
+		}
+		// A trailing partial value is dropped.
+		val = val[:len(val)-len(val)%typ.Size()]
+		t, err := tiff.NewTag(uint16(id), typ, order, val)
+		if err != nil {
+			continue
+		}
+		d.Tags = append(d.Tags, t)
+	}
+	return d, nil
+}
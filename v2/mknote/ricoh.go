@@ -0,0 +1,117 @@
+package mknote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+	"github.com/rwcarlsen/goexif/v2/tiff"
+)
+
+type ricoh struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *ricoh) Name() string { return "Ricoh" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *ricoh) CanParse(make string, header []byte) bool {
+	return bytes.HasPrefix(header, []byte("Ricoh")) || bytes.HasPrefix(header, []byte("RICOH"))
+}
+
+// Parse decodes all Ricoh makernote data found in x and adds it to x,
+// including the Theta 360 camera sub-IFD.
+func (p *ricoh) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	var buf *bytes.Reader
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(m.Val, []byte("RICOH\000II")), bytes.HasPrefix(m.Val, []byte("RICOH\000MM")):
+		// Newer notes (including the Theta) carry their own byte order
+		// and offsets are relative to the start of the maker note.
+		order = binary.LittleEndian
+		if m.Val[7] == 'M' {
+			order = binary.BigEndian
+		}
+		buf = bytes.NewReader(m.Val)
+		buf.Seek(8, 0)
+	case bytes.HasPrefix(m.Val, []byte("Ricoh")), bytes.HasPrefix(m.Val, []byte("RICOH")):
+		// Older notes have an 8 byte header followed by an IFD with
+		// offsets w.r.t. the original tiff structure.
+		order = x.Tiff.Order
+		buf = bytes.NewReader(append(make([]byte, m.ValOffset), m.Val...))
+		buf.Seek(int64(m.ValOffset)+8, 0)
+	default:
+		return nil
+	}
+
+	mkNotesDir, _, err := tiff.DecodeDir(buf, order)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteRicohFields, false)
+
+	for _, tag := range mkNotesDir.Tags {
+		if tag.Id != thetaSubdirID {
+			continue
+		}
+		offset, err := tag.Int64(0)
+		if err != nil {
+			return err
+		}
+		if _, err := buf.Seek(offset, 0); err != nil {
+			return err
+		}
+		thetaDir, _, err := tiff.DecodeDir(buf, order)
+		if err != nil {
+			return err
+		}
+		x.LoadTags(thetaDir, makerNoteThetaFields, false)
+	}
+	return nil
+}
+
+// ThetaZenith returns the camera tilt recorded by a Ricoh Theta as roll and
+// pitch angles in degrees.  Together with ThetaCompass it describes how to
+// level (zenith correct) and orient the equirectangular image.
+func ThetaZenith(x *exif.Exif) (roll, pitch float64, err error) {
+	tag, err := x.Get(Theta_Accelerometer)
+	if err != nil {
+		return 0, 0, err
+	}
+	if tag.Count < 2 {
+		return 0, 0, errors.New("mknote: Theta accelerometer data is too short")
+	}
+	if roll, err = ratFloat(tag, 0); err != nil {
+		return 0, 0, err
+	}
+	if pitch, err = ratFloat(tag, 1); err != nil {
+		return 0, 0, err
+	}
+	return roll, pitch, nil
+}
+
+// ThetaCompass returns the compass heading, in degrees, recorded by a Ricoh
+// Theta.
+func ThetaCompass(x *exif.Exif) (float64, error) {
+	tag, err := x.Get(Theta_Compass)
+	if err != nil {
+		return 0, err
+	}
+	return ratFloat(tag, 0)
+}
+
+func ratFloat(tag *tiff.Tag, i int) (float64, error) {
+	num, den, err := tag.Rat2(i)
+	if err != nil {
+		return 0, err
+	}
+	if den == 0 {
+		return 0, errors.New("mknote: zero denominator")
+	}
+	return float64(num) / float64(den), nil
+}
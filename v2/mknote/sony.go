@@ -0,0 +1,41 @@
+package mknote
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+type sony struct{}
+
+// Name implements exif.MakerNoteParser.
+func (_ *sony) Name() string { return "Sony" }
+
+// CanParse implements exif.MakerNoteParser.
+func (_ *sony) CanParse(make string, header []byte) bool {
+	return bytes.HasPrefix(header, []byte("SONY DSC \000\000\000")) ||
+		bytes.HasPrefix(header, []byte("SONY CAM \000\000\000")) ||
+		strings.ToUpper(make) == "SONY"
+}
+
+// Parse decodes all Sony makernote data found in x and adds it to x.
+func (p *sony) Parse(x *exif.Exif) error {
+	m, err := x.Get(exif.MakerNote)
+	if err != nil || !p.CanParse(makeOf(x), m.Val) {
+		return nil
+	}
+
+	// Sony notes are an IFD, preceded by a 12 byte header in older
+	// models, with offsets w.r.t. the original tiff structure.
+	var hdrLen int64
+	if bytes.HasPrefix(m.Val, []byte("SONY")) {
+		hdrLen = 12
+	}
+	mkNotesDir, err := noteDir(x, m, hdrLen, baseTIFF)
+	if err != nil {
+		return err
+	}
+	x.LoadTags(mkNotesDir, makerNoteSonyFields, false)
+	return nil
+}
@@ -0,0 +1,109 @@
+package mknote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+// StackKind identifies the multi-shot technique an image belongs to.
+type StackKind int
+
+const (
+	StackNone         StackKind = iota
+	StackFocusBracket           // source frame of a focus bracket
+	StackFocusStacked           // in-camera focus stack of a bracket
+	StackPixelShift             // source frame of a pixel shift sequence
+	StackHighRes                // in-camera composite of a pixel shift sequence
+)
+
+var stackNames = map[StackKind]string{
+	StackNone:         "none",
+	StackFocusBracket: "focus bracket",
+	StackFocusStacked: "focus stacked",
+	StackPixelShift:   "pixel shift",
+	StackHighRes:      "high resolution",
+}
+
+func (k StackKind) String() string {
+	if n, ok := stackNames[k]; ok {
+		return n
+	}
+	return fmt.Sprintf("StackKind(%d)", int(k))
+}
+
+// Stack describes the place of an image in a focus bracketing or pixel
+// shift sequence.
+type Stack struct {
+	Kind StackKind
+	// Group identifies the sequence, for vendors recording one (Sony);
+	// otherwise frames are grouped by their Shot numbers restarting at 1.
+	Group string
+	// Shot is the 1-based number of the frame in the sequence, or 0 for
+	// composites; Shots is the number of frames of the sequence, or 0 if
+	// not recorded.
+	Shot, Shots int
+}
+
+// ErrNoStack is returned by StackInfo for images without focus bracketing
+// or pixel shift metadata.
+var ErrNoStack = errors.New("mknote: no stacking metadata")
+
+// StackInfo returns the focus bracketing or pixel shift sequence the image
+// of x belongs to, as recorded in the Olympus CameraSettings (DriveMode and
+// StackedImage) or the Sony PixelShiftInfo makernote fields, so that
+// stacking software can group the source frames.  Single shots have Kind
+// StackNone.
+func StackInfo(x *exif.Exif) (Stack, error) {
+	if tag, err := x.Get(Sony_PixelShiftInfo); err == nil {
+		return sonyStack(x, tag.Val)
+	}
+	stacked, errStacked := x.Get(Olympus_StackedImage)
+	drive, errDrive := x.Get(Olympus_DriveMode)
+	if errStacked != nil && errDrive != nil {
+		return Stack{}, ErrNoStack
+	}
+	if errStacked == nil && stacked.Count >= 2 {
+		kind, _ := stacked.Int(0)
+		n, _ := stacked.Int(1)
+		switch kind {
+		case 9:
+			return Stack{Kind: StackFocusStacked, Shots: n}, nil
+		case 8, 11:
+			// tripod and hand-held high resolution shots
+			return Stack{Kind: StackHighRes, Shots: n}, nil
+		}
+	}
+	if errDrive == nil && drive.Count >= 3 {
+		// A mode of 5 is bracketing, of the kinds given by the bits of
+		// the third value; the second one is the shot number.
+		mode, _ := drive.Int(0)
+		shot, _ := drive.Int(1)
+		kinds, _ := drive.Int(2)
+		if mode == 5 && kinds&(1<<6) != 0 {
+			return Stack{Kind: StackFocusBracket, Shot: shot}, nil
+		}
+	}
+	return Stack{}, nil
+}
+
+// sonyStack decodes the Sony PixelShiftInfo structure: a 4 byte group
+// prefix and 4 byte group ID, both zero outside pixel shift sequences,
+// then the shot number and number of shots.
+func sonyStack(x *exif.Exif, b []byte) (Stack, error) {
+	if len(b) < 10 {
+		return Stack{}, errors.New("mknote: Sony PixelShiftInfo is too short")
+	}
+	order := x.Tiff.Order
+	prefix, id := order.Uint32(b), order.Uint32(b[4:])
+	if prefix == 0 && id == 0 {
+		return Stack{}, nil
+	}
+	return Stack{
+		Kind:  StackPixelShift,
+		Group: fmt.Sprintf("%08X%08X", prefix, id),
+		Shot:  int(b[8]),
+		Shots: int(b[9]),
+	}, nil
+}
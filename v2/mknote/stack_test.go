@@ -0,0 +1,52 @@
+package mknote
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rwcarlsen/goexif/v2/exif"
+)
+
+func TestStackInfo(t *testing.T) {
+	fixtures := map[string]func(off uint32) []byte{}
+	for _, tt := range mknoteTests {
+		fixtures[tt.name] = tt.note
+	}
+	olympus := func(settings ...entry) func(off uint32) []byte {
+		return func(off uint32) []byte {
+			note := []byte("OLYMPUS\x00II\x03\x00")
+			note = append(note, ifd(le, 12, long(le, 0x2020, 30))...)
+			return append(note, ifd(le, 30, settings...)...)
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		make string
+		note func(off uint32) []byte
+		want Stack
+	}{
+		{"sony", "SONY", fixtures["Sony"], Stack{Kind: StackPixelShift, Group: "0000341200005678", Shot: 2, Shots: 4}},
+		{"bracket", "OLYMPUS", fixtures["Olympus"], Stack{Kind: StackFocusBracket, Shot: 3}},
+		{"stacked", "OLYMPUS", olympus(long(le, 0x0804, 9, 15)), Stack{Kind: StackFocusStacked, Shots: 15}},
+		{"highres", "OLYMPUS", olympus(long(le, 0x0804, 11, 12)), Stack{Kind: StackHighRes, Shots: 12}},
+		{"single", "OLYMPUS", olympus(short(le, 0x0600, 0, 0, 0)), Stack{}},
+	} {
+		x, err := exif.Decode(bytes.NewReader(buildExif(le, tt.make, tt.note)))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		s, err := StackInfo(x)
+		if err != nil || s != tt.want {
+			t.Errorf("%s: StackInfo = %+v, %v; want %+v", tt.name, s, err, tt.want)
+		}
+	}
+
+	x, err := exif.Decode(bytes.NewReader(buildExif(le, "RICOH", fixtures["Ricoh"])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := StackInfo(x); err != ErrNoStack {
+		t.Errorf("StackInfo without stacking metadata = %v", err)
+	}
+}
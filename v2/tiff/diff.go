@@ -0,0 +1,118 @@
+package tiff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// A TagDiff is a difference between two IFDs: a tag present in only one of
+// them or holding different values in each.
+type TagDiff struct {
+	Dir int    // index of the IFD in the Tiffs compared by DiffTiff
+	Id  uint16 // tag ID
+	// A and B are the tag in each IFD, or nil if it is missing from it.
+	A, B *Tag
+}
+
+func (d TagDiff) String() string {
+	show := func(t *Tag) string {
+		if t == nil {
+			return "(missing)"
+		}
+		return fmt.Sprintf("%v %v", typeNames[t.Type], t)
+	}
+	return fmt.Sprintf("IFD%d tag %#04x: %s != %s", d.Dir, d.Id, show(d.A), show(d.B))
+}
+
+// DiffDirs compares the IFDs a and b tag by tag and returns their
+// differences ordered by tag ID.  Tags are equal if they have the same type
+// and values, whatever their byte order and value offset.  Either IFD may
+// be nil.
+func DiffDirs(a, b *Dir) []TagDiff {
+	tags := func(d *Dir) map[uint16]*Tag {
+		m := map[uint16]*Tag{}
+		if d == nil {
+			return m
+		}
+		for _, t := range d.Tags {
+			if _, dup := m[t.Id]; !dup {
+				m[t.Id] = t
+			}
+		}
+		return m
+	}
+	ta, tb := tags(a), tags(b)
+
+	var diffs []TagDiff
+	for id, t := range ta {
+		if !tagsEqual(t, tb[id]) {
+			diffs = append(diffs, TagDiff{Id: id, A: t, B: tb[id]})
+		}
+	}
+	for id, t := range tb {
+		if ta[id] == nil {
+			diffs = append(diffs, TagDiff{Id: id, B: t})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Id < diffs[j].Id })
+	return diffs
+}
+
+// DiffTiff compares the IFDs of a and b pairwise with DiffDirs, IFDs
+// missing from one of them comparing as empty.
+func DiffTiff(a, b *Tiff) []TagDiff {
+	n := len(a.Dirs)
+	if len(b.Dirs) > n {
+		n = len(b.Dirs)
+	}
+	var diffs []TagDiff
+	for i := 0; i < n; i++ {
+		var da, db *Dir
+		if i < len(a.Dirs) {
+			da = a.Dirs[i]
+		}
+		if i < len(b.Dirs) {
+			db = b.Dirs[i]
+		}
+		for _, d := range DiffDirs(da, db) {
+			d.Dir = i
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// tagsEqual reports whether a and b (either possibly nil) have the same type
+// and values.
+func tagsEqual(a, b *Tag) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Type != b.Type || a.Count != b.Count || a.format != b.format {
+		return false
+	}
+	switch a.format {
+	case IntVal:
+		for i := range a.intVals {
+			if a.intVals[i] != b.intVals[i] {
+				return false
+			}
+		}
+	case RatVal:
+		for i := range a.ratVals {
+			if a.ratVals[i][0] != b.ratVals[i][0] || a.ratVals[i][1] != b.ratVals[i][1] {
+				return false
+			}
+		}
+	case FloatVal:
+		for i := range a.floatVals {
+			if a.floatVals[i] != b.floatVals[i] {
+				return false
+			}
+		}
+	default:
+		return bytes.Equal(a.Val, b.Val)
+	}
+	return true
+}
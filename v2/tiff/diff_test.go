@@ -0,0 +1,48 @@
+package tiff
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDiffTiff(t *testing.T) {
+	tag := func(id uint16, typ DataType, order binary.ByteOrder, vals ...interface{}) *Tag {
+		tg, err := NewTag(id, typ, order, vals...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tg
+	}
+	le, be := binary.LittleEndian, binary.BigEndian
+	a := &Tiff{Order: le, Dirs: []*Dir{{Tags: []*Tag{
+		tag(0x010F, DTAscii, le, "Canon"),
+		tag(0x0112, DTShort, le, 1),
+		tag(0x011A, DTRational, le, [2]int64{72, 1}),
+	}}}}
+	b := &Tiff{Order: be, Dirs: []*Dir{{Tags: []*Tag{
+		tag(0x011A, DTRational, be, [2]int64{72, 1}),
+		tag(0x0112, DTShort, be, 6),
+		tag(0x0110, DTAscii, be, "EOS"),
+	}}, {Tags: []*Tag{
+		tag(0x0201, DTLong, be, 1000),
+	}}}}
+
+	want := []string{
+		`IFD0 tag 0x010f: ascii "Canon" != (missing)`,
+		`IFD0 tag 0x0110: (missing) != ascii "EOS"`,
+		`IFD0 tag 0x0112: short 1 != short 6`,
+		`IFD1 tag 0x0201: (missing) != long 1000`,
+	}
+	diffs := DiffTiff(a, b)
+	if len(diffs) != len(want) {
+		t.Fatalf("got %v, want %v", diffs, want)
+	}
+	for i, d := range diffs {
+		if d.String() != want[i] {
+			t.Errorf("diff %d is %v, want %v", i, d, want[i])
+		}
+	}
+	if diffs := DiffTiff(a, a); len(diffs) != 0 {
+		t.Errorf("a differs from itself: %v", diffs)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build go1.23
+// +build go1.23
+
+package tiff
+
+import "iter"
+
+// All returns an iterator over the IFDs of tf and their indices.
+func (tf *Tiff) All() iter.Seq2[int, *Dir] {
+	return func(yield func(int, *Dir) bool) {
+		for i, d := range tf.Dirs {
+			if !yield(i, d) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the tags of d and their indices.
+func (d *Dir) All() iter.Seq2[int, *Tag] {
+	return func(yield func(int, *Tag) bool) {
+		for i, t := range d.Tags {
+			if !yield(i, t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+//go:build go1.23
+// +build go1.23
+
+package tiff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	f, err := os.Open(filepath.Join(*dataDir, "sample1.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tif, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, tags := 0, 0
+	for i, d := range tif.All() {
+		if d != tif.Dirs[i] {
+			t.Errorf("dir %d out of order", i)
+		}
+		dirs++
+		for j, tag := range d.All() {
+			if tag != d.Tags[j] {
+				t.Errorf("dir %d tag %d out of order", i, j)
+			}
+			tags++
+		}
+	}
+	if dirs != len(tif.Dirs) || tags == 0 {
+		t.Errorf("visited %d dirs and %d tags", dirs, tags)
+	}
+}
@@ -0,0 +1,167 @@
+package tiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// intRange gives the range of the values of each integer data type, and of
+// the bytes of DTUndefined.
+var intRange = map[DataType][2]int64{
+	DTByte:      {0, math.MaxUint8},
+	DTUndefined: {0, math.MaxUint8},
+	DTShort:     {0, math.MaxUint16},
+	DTLong:      {0, math.MaxUint32},
+	DTIFD:       {0, math.MaxUint32},
+	DTSByte:     {math.MinInt8, math.MaxInt8},
+	DTSShort:    {math.MinInt16, math.MaxInt16},
+	DTSLong:     {math.MinInt32, math.MaxInt32},
+}
+
+// NewTag returns a tag with the given ID and data type holding vals encoded
+// in order, with Count computed from them.  The values must suit the format
+// of typ:
+//
+//   - IntVal types accept Go integers within the range of typ.
+//   - RatVal types accept *big.Rat and [2]int64 (numerator, denominator)
+//     values whose parts fit 32 bit integers of the signedness of typ.
+//   - FloatVal types accept float32 and float64.
+//   - DTAscii and DTUTF8 accept strings, each stored NUL terminated.
+//   - DTUndefined accepts Go integers from 0 to 255, one byte each.
+//
+// All types also accept []byte values holding values already encoded in
+// order, such as read from a fixed makernote layout; its length must be a
+// whole number of values.  ASCII text must then include its NUL
+// terminator.
+//
+// The tag's ValOffset is zero: it is only known once the tag is encoded.
+func NewTag(id uint16, typ DataType, order binary.ByteOrder, vals ...interface{}) (*Tag, error) {
+	t := &Tag{Id: id, Type: typ, order: order}
+	var buf bytes.Buffer
+	for i, v := range vals {
+		n, err := appendVal(&buf, typ, order, v)
+		if err != nil {
+			return nil, fmt.Errorf("tiff: value %d of tag %#04x: %v", i, id, err)
+		}
+		t.Count += n
+	}
+	t.Val = buf.Bytes()
+	if err := t.convertVals(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// appendVal encodes v as values of type typ to buf and returns the number
+// of values written.
+func appendVal(buf *bytes.Buffer, typ DataType, order binary.ByteOrder, v interface{}) (uint32, error) {
+	if b, ok := v.([]byte); ok {
+		size := typ.Size()
+		if size == 0 {
+			return 0, fmt.Errorf("unsupported type %d", typ)
+		}
+		if len(b)%size != 0 {
+			return 0, fmt.Errorf("%d bytes are not a whole number of %v values", len(b), typeNames[typ])
+		}
+		buf.Write(b)
+		return uint32(len(b) / size), nil
+	}
+	switch typ {
+	case DTByte, DTShort, DTLong, DTSByte, DTSShort, DTSLong, DTIFD, DTUndefined:
+		n, ok := toInt64(v)
+		if !ok {
+			return 0, fmt.Errorf("%T is not an integer", v)
+		}
+		if r := intRange[typ]; n < r[0] || n > r[1] {
+			return 0, fmt.Errorf("%d out of range for type %v", n, typeNames[typ])
+		}
+		b := make([]byte, typeSize[typ])
+		switch len(b) {
+		case 1:
+			b[0] = byte(n)
+		case 2:
+			order.PutUint16(b, uint16(n))
+		case 4:
+			order.PutUint32(b, uint32(n))
+		}
+		buf.Write(b)
+	case DTRational, DTSRational:
+		var num, den int64
+		switch r := v.(type) {
+		case *big.Rat:
+			if !r.Num().IsInt64() || !r.Denom().IsInt64() {
+				return 0, fmt.Errorf("%v out of range for type %v", r, typeNames[typ])
+			}
+			num, den = r.Num().Int64(), r.Denom().Int64()
+		case [2]int64:
+			num, den = r[0], r[1]
+		default:
+			return 0, fmt.Errorf("%T is not a rational", v)
+		}
+		r := intRange[DTLong]
+		if typ == DTSRational {
+			r = intRange[DTSLong]
+		}
+		if num < r[0] || num > r[1] || den < r[0] || den > r[1] {
+			return 0, fmt.Errorf("%d/%d out of range for type %v", num, den, typeNames[typ])
+		}
+		binary.Write(buf, order, uint32(num))
+		binary.Write(buf, order, uint32(den))
+	case DTFloat, DTDouble:
+		var f float64
+		switch x := v.(type) {
+		case float32:
+			f = float64(x)
+		case float64:
+			f = x
+		default:
+			return 0, fmt.Errorf("%T is not a float", v)
+		}
+		if typ == DTFloat {
+			binary.Write(buf, order, float32(f))
+		} else {
+			binary.Write(buf, order, f)
+		}
+	case DTAscii, DTUTF8:
+		s, ok := v.(string)
+		if !ok {
+			return 0, fmt.Errorf("%T is not a string", v)
+		}
+		buf.WriteString(s)
+		buf.WriteByte(0)
+		return uint32(len(s) + 1), nil
+	default:
+		return 0, fmt.Errorf("unsupported type %d", typ)
+	}
+	return 1, nil
+}
+
+// toInt64 converts the Go integer v to an int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint:
+		return int64(n), n <= math.MaxInt64
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), n <= math.MaxInt64
+	}
+	return 0, false
+}
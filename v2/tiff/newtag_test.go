@@ -0,0 +1,62 @@
+package tiff
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+)
+
+func TestNewTag(t *testing.T) {
+	tests := []struct {
+		typ   DataType
+		order binary.ByteOrder
+		vals  []interface{}
+		count uint32
+		want  string
+	}{
+		{DTShort, binary.BigEndian, []interface{}{1, uint16(200)}, 2, `[1,200]`},
+		{DTSLong, binary.LittleEndian, []interface{}{int32(-5)}, 1, `-5`},
+		{DTRational, binary.BigEndian, []interface{}{big.NewRat(1, 250), [2]int64{28, 10}}, 2, `["1/250","28/10"]`},
+		{DTSRational, binary.LittleEndian, []interface{}{[2]int64{-2, 3}}, 1, `"-2/3"`},
+		{DTDouble, binary.BigEndian, []interface{}{1.5}, 1, `1.5`},
+		{DTAscii, binary.LittleEndian, []interface{}{"Canon"}, 6, `"Canon"`},
+		{DTUTF8, binary.LittleEndian, []interface{}{"Zoë"}, 5, `"Zoë"`},
+		{DTUndefined, binary.LittleEndian, []interface{}{[]byte("0230")}, 4, `"0230"`},
+		{DTUndefined, binary.BigEndian, []interface{}{'0', byte('2'), 0x33, []byte("0")}, 4, `"0230"`},
+		{DTShort, binary.BigEndian, []interface{}{[]byte{0, 1, 0, 2}}, 2, `[1,2]`},
+		{DTAscii, binary.BigEndian, []interface{}{[]byte("ab\x00")}, 3, `"ab"`},
+	}
+	for _, tt := range tests {
+		tag, err := NewTag(0x0100, tt.typ, tt.order, tt.vals...)
+		if err != nil {
+			t.Errorf("%v %v: %v", tt.typ, tt.vals, err)
+			continue
+		}
+		if tag.Count != tt.count || tag.String() != tt.want {
+			t.Errorf("%v %v: got count %d, %s; want %d, %s", tt.typ, tt.vals, tag.Count, tag, tt.count, tt.want)
+		}
+	}
+
+	bad := []struct {
+		typ DataType
+		val interface{}
+	}{
+		{DTByte, 256},
+		{DTShort, -1},
+		{DTShort, "1"},
+		{DTRational, [2]int64{-1, 2}},
+		{DTRational, 0.5},
+		{DTFloat, 1},
+		{DTUndefined, 256},
+		{DTUndefined, "0230"},
+		{DTAscii, 1},
+		{DTShort, []byte{1, 2, 3}},
+		{DataType(99), []byte{1}},
+		{DataType(99), 1},
+	}
+	for _, tt := range bad {
+		if _, err := NewTag(1, tt.typ, binary.BigEndian, tt.val); err == nil {
+			t.Errorf("%v %#v: no error", tt.typ, tt.val)
+		}
+	}
+}
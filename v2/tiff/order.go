@@ -0,0 +1,52 @@
+package tiff
+
+import "encoding/binary"
+
+// unitSize gives the size of the integers the values of each multi-byte
+// data type are made of.
+var unitSize = map[DataType]int{
+	DTShort:     2,
+	DTSShort:    2,
+	DTLong:      4,
+	DTIFD:       4,
+	DTSLong:     4,
+	DTRational:  4,
+	DTSRational: 4,
+	DTFloat:     4,
+	DTDouble:    8,
+}
+
+// ConvertOrder rewrites the tag values of t in the byte order order and sets
+// t.Order accordingly, e.g. to merge tags from TIFF structures of mixed
+// endianness into one.  Values of types made of single bytes (ASCII, byte
+// and undefined) are left as is, so data of undefined type with an internal
+// structure of its own, such as a makernote, is not converted.  Tag values
+// are replaced rather than modified in place, as they may be shared.
+func ConvertOrder(t *Tiff, order binary.ByteOrder) {
+	for _, d := range t.Dirs {
+		for _, tag := range d.Tags {
+			tag.ConvertOrder(order)
+		}
+	}
+	t.Order = order
+}
+
+// ConvertOrder rewrites the value of t in the byte order order (see the
+// ConvertOrder function).
+func (t *Tag) ConvertOrder(order binary.ByteOrder) {
+	if t.order == order {
+		return
+	}
+	t.order = order
+	size := unitSize[t.Type]
+	if size == 0 {
+		return
+	}
+	val := make([]byte, len(t.Val))
+	for i := 0; i+size <= len(val); i += size {
+		for j := 0; j < size; j++ {
+			val[i+j] = t.Val[i+size-1-j]
+		}
+	}
+	t.Val = val
+}
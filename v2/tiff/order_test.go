@@ -0,0 +1,49 @@
+package tiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertOrder(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tif, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := binary.ByteOrder(binary.BigEndian)
+	if tif.Order == binary.BigEndian {
+		order = binary.LittleEndian
+	}
+	ConvertOrder(tif, order)
+	if tif.Order != order {
+		t.Errorf("Order = %v, want %v", tif.Order, order)
+	}
+	for i, d := range tif.Dirs {
+		for j, tag := range d.Tags {
+			w := want.Dirs[i].Tags[j]
+			if unitSize[tag.Type] > 1 && bytes.Equal(tag.Val, w.Val) && len(bytes.Trim(w.Val, "\x00")) > 0 {
+				t.Errorf("%v: value not converted", tag)
+			}
+			// Re-decoding the converted value yields the same values.
+			again := &Tag{Id: tag.Id, Type: tag.Type, Count: tag.Count, Val: tag.Val, order: order}
+			if err := again.convertVals(); err != nil {
+				t.Fatal(err)
+			}
+			if again.String() != w.String() {
+				t.Errorf("converted %v, want %v", again, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package tiff
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Reader reads a tiff structure one IFD and one tag at a time, so that
+// huge IFDs can be scanned without holding all their tags in memory.
+// Unlike Decode, it reads only the parts of the structure asked for.
+type Reader struct {
+	r     io.ReaderAt
+	order binary.ByteOrder
+
+	next int64          // offset of the next IFD, 0 if none
+	seen map[int64]bool // offsets of the IFDs read
+
+	dir  int64 // offset of the current IFD
+	n    int   // number of tags in the current IFD
+	read int   // number of tags read from the current IFD
+}
+
+// NewReader returns a Reader for the tiff structure at the start of r after
+// reading its header.
+func NewReader(r io.ReaderAt) (*Reader, error) {
+	var hdr [8]byte
+	if _, err := r.ReadAt(hdr[:], 0); err != nil {
+		return nil, errors.New("tiff: could not read tiff header")
+	}
+	rd := &Reader{r: r, seen: map[int64]bool{}, dir: -1}
+	switch string(hdr[:2]) {
+	case "II":
+		rd.order = binary.LittleEndian
+	case "MM":
+		rd.order = binary.BigEndian
+	default:
+		return nil, errors.New("tiff: could not read tiff byte order")
+	}
+	if rd.order.Uint16(hdr[2:]) != 42 {
+		return nil, errors.New("tiff: could not find special tiff marker")
+	}
+	rd.next = int64(rd.order.Uint32(hdr[4:]))
+	return rd, nil
+}
+
+// Order returns the byte order of the tiff structure.
+func (rd *Reader) Order() binary.ByteOrder {
+	return rd.order
+}
+
+// NextDir advances to the next IFD in the chain starting with IFD0 and
+// returns its number of tags.  Tags of the current IFD not yet read are
+// skipped.  It returns io.EOF after the last IFD.
+func (rd *Reader) NextDir() (n int, err error) {
+	if rd.dir >= 0 {
+		var off [4]byte
+		if _, err := rd.r.ReadAt(off[:], rd.dir+2+12*int64(rd.n)); err != nil {
+			return 0, errors.New("tiff: failed to read offset to next IFD: " + err.Error())
+		}
+		rd.next = int64(rd.order.Uint32(off[:]))
+	}
+	if rd.next == 0 {
+		return 0, io.EOF
+	}
+	return rd.SeekDir(rd.next)
+}
+
+// SeekDir moves to the IFD at offset (e.g. a sub-IFD located by a pointer
+// tag) and returns its number of tags.  A subsequent NextDir continues with
+// the IFD linked from it.
+func (rd *Reader) SeekDir(offset int64) (n int, err error) {
+	if rd.seen[offset] {
+		return 0, errors.New("tiff: recursive IFD")
+	}
+	rd.seen[offset] = true
+
+	var cnt [2]byte
+	if _, err := rd.r.ReadAt(cnt[:], offset); err != nil {
+		return 0, errors.New("tiff: failed to read IFD tag count: " + err.Error())
+	}
+	rd.dir, rd.n, rd.read = offset, int(rd.order.Uint16(cnt[:])), 0
+	return rd.n, nil
+}
+
+// NextTag returns the next tag of the current IFD.  It returns io.EOF after
+// the last tag of the IFD, or if no IFD is current.
+func (rd *Reader) NextTag() (*Tag, error) {
+	if rd.dir < 0 || rd.read >= rd.n {
+		return nil, io.EOF
+	}
+	entry := make([]byte, 12)
+	if _, err := rd.r.ReadAt(entry, rd.dir+2+12*int64(rd.read)); err != nil {
+		return nil, errors.New("tiff: tag id read failed: " + err.Error())
+	}
+	rd.read++
+	return DecodeEntry(entry, rd.r, rd.order)
+}
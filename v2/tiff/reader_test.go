@@ -0,0 +1,78 @@
+package tiff
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tif, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rd, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rd.Order() != tif.Order {
+		t.Errorf("Order = %v, want %v", rd.Order(), tif.Order)
+	}
+	for i := 0; ; i++ {
+		n, err := rd.NextDir()
+		if err == io.EOF {
+			if i != len(tif.Dirs) {
+				t.Errorf("read %d IFDs, want %d", i, len(tif.Dirs))
+			}
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if i >= len(tif.Dirs) || n != len(tif.Dirs[i].Tags) {
+			t.Fatalf("IFD %d has %d tags", i, n)
+		}
+		// Only read the first tag of IFDs after IFD0.
+		for j, want := range tif.Dirs[i].Tags {
+			if i > 0 && j > 0 {
+				break
+			}
+			tag, err := rd.NextTag()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tag.Id != want.Id || !bytes.Equal(tag.Val, want.Val) {
+				t.Errorf("IFD %d tag %d is %v, want %v", i, j, tag, want)
+			}
+		}
+		if i == 0 {
+			if _, err := rd.NextTag(); err != io.EOF {
+				t.Errorf("NextTag after last tag: %v, want io.EOF", err)
+			}
+		}
+	}
+}
+
+func TestReaderLoop(t *testing.T) {
+	data := []byte("II*\x00\x08\x00\x00\x00" +
+		"\x00\x00\x0e\x00\x00\x00" +
+		"\x00\x00\x08\x00\x00\x00")
+	rd, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := rd.NextDir(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := rd.NextDir(); err == nil || err == io.EOF {
+		t.Errorf("looping IFDs: got %v", err)
+	}
+}
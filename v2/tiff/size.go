@@ -0,0 +1,28 @@
+package tiff
+
+import "unsafe"
+
+// SizeBytes estimates the memory retained by t: the Tag itself, its raw
+// value and its decoded values.  Memory shared with other tags (e.g.
+// interned strings) is counted for each of them.
+func (t *Tag) SizeBytes() int {
+	n := int(unsafe.Sizeof(*t)) + cap(t.Val) + len(t.strVal)
+	n += cap(t.intVals)*8 + cap(t.floatVals)*8
+	n += cap(t.ratVals) * int(unsafe.Sizeof([]int64(nil)))
+	for _, r := range t.ratVals {
+		n += cap(r) * 8
+	}
+	return n
+}
+
+// SizeBytes estimates the memory retained by tf and the tags of its IFDs.
+func (tf *Tiff) SizeBytes() int {
+	n := int(unsafe.Sizeof(*tf)) + cap(tf.Dirs)*int(unsafe.Sizeof((*Dir)(nil)))
+	for _, d := range tf.Dirs {
+		n += int(unsafe.Sizeof(*d)) + cap(d.Tags)*int(unsafe.Sizeof((*Tag)(nil)))
+		for _, t := range d.Tags {
+			n += t.SizeBytes()
+		}
+	}
+	return n
+}
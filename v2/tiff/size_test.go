@@ -0,0 +1,41 @@
+package tiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestSizeBytes(t *testing.T) {
+	short, err := NewTag(0x0112, DTShort, binary.LittleEndian, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long, err := NewTag(0x0112, DTShort, binary.LittleEndian, 1, 2, 3, 4, 5, 6, 7, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, l := short.SizeBytes(), long.SizeBytes(); s >= l {
+		t.Errorf("tag of 1 value takes %d bytes, tag of 8 values %d", s, l)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(*dataDir, "sample1.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tif, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := 0
+	for _, d := range tif.Dirs {
+		for _, tag := range d.Tags {
+			vals += len(tag.Val)
+		}
+	}
+	if n := tif.SizeBytes(); n <= vals {
+		t.Errorf("SizeBytes = %d, less than the %d bytes of values", n, vals)
+	}
+}
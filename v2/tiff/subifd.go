@@ -0,0 +1,121 @@
+package tiff
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// subIFDsTag is the ID of the SubIFDs tag of TIFF-EP and DNG, holding the
+// offsets of child IFDs (e.g. the full size raw image of a DNG).
+const subIFDsTag = 0x014A
+
+// Default limits for DecodeSubIFDs.
+const (
+	DefaultMaxSubIFDDepth = 4
+	DefaultMaxSubIFDs     = 256
+)
+
+var (
+	// ErrSubIFDDepth is returned when SubIFDs pointers nest deeper than
+	// allowed.
+	ErrSubIFDDepth = errors.New("tiff: SubIFDs nested too deep")
+	// ErrTooManySubIFDs is returned when a tree of SubIFDs pointers holds
+	// more IFDs than allowed.
+	ErrTooManySubIFDs = errors.New("tiff: too many SubIFDs")
+)
+
+// SubIFDLimits bound the trees of IFDs decoded by DecodeSubIFDs, so that
+// crafted SubIFDs pointers cannot exhaust the stack or memory.
+type SubIFDLimits struct {
+	// MaxDepth is the deepest nesting of sub-IFDs followed, the IFDs
+	// pointed to by the parent IFD being at depth 1.  Zero means
+	// DefaultMaxSubIFDDepth.
+	MaxDepth int
+	// MaxDirs is the most sub-IFDs decoded in total.  Zero means
+	// DefaultMaxSubIFDs.
+	MaxDirs int
+}
+
+// SubDir is an IFD pointed to by a SubIFDs tag, with its own sub-IFDs.
+type SubDir struct {
+	*Dir
+	// Offset is the offset of the IFD in the tiff structure.
+	Offset uint32
+	Subs   []*SubDir
+}
+
+// DecodeSubIFDs decodes the tree of IFDs pointed to by the SubIFDs tag
+// (0x014A) of d and, recursively, of the IFDs found.  ReadAt offsets of r
+// are relative to the start of the tiff structure.  It fails with
+// ErrSubIFDDepth or ErrTooManySubIFDs if the tree exceeds lim, and on
+// pointers looping back to an IFD of the tree.
+func DecodeSubIFDs(r io.ReaderAt, order binary.ByteOrder, d *Dir, lim SubIFDLimits) ([]*SubDir, error) {
+	if lim.MaxDepth <= 0 {
+		lim.MaxDepth = DefaultMaxSubIFDDepth
+	}
+	if lim.MaxDirs <= 0 {
+		lim.MaxDirs = DefaultMaxSubIFDs
+	}
+	s := &subDecoder{
+		r:     io.NewSectionReader(r, 0, 1<<63-1),
+		order: order,
+		lim:   lim,
+		seen:  map[uint32]bool{},
+	}
+	return s.decode(d, 1)
+}
+
+type subDecoder struct {
+	r     *io.SectionReader
+	order binary.ByteOrder
+	lim   SubIFDLimits
+	seen  map[uint32]bool // offsets of the IFDs decoded
+	n     int             // number of IFDs decoded
+}
+
+func (s *subDecoder) decode(parent *Dir, depth int) ([]*SubDir, error) {
+	var ptrs *Tag
+	for _, t := range parent.Tags {
+		if t.Id == subIFDsTag {
+			ptrs = t
+			break
+		}
+	}
+	if ptrs == nil || ptrs.format != IntVal {
+		return nil, nil
+	}
+	if depth > s.lim.MaxDepth {
+		return nil, ErrSubIFDDepth
+	}
+
+	var subs []*SubDir
+	for i := range ptrs.intVals {
+		off := uint32(ptrs.intVals[i])
+		if off == 0 {
+			// Unused entry of a fixed size SubIFDs array.
+			continue
+		}
+		if s.seen[off] {
+			return nil, errors.New("tiff: recursive IFD")
+		}
+		s.seen[off] = true
+		if s.n++; s.n > s.lim.MaxDirs {
+			return nil, ErrTooManySubIFDs
+		}
+
+		if _, err := s.r.Seek(int64(off), io.SeekStart); err != nil {
+			return nil, errors.New("tiff: seek to IFD failed")
+		}
+		d, _, err := DecodeDir(s.r, s.order)
+		if err != nil {
+			return nil, err
+		}
+		sub := &SubDir{Dir: d, Offset: off}
+		if sub.Subs, err = s.decode(d, depth+1); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
@@ -0,0 +1,100 @@
+package tiff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// subIFDChain returns a little endian tiff structure of n IFDs, each but the
+// last holding a SubIFDs pointer to the next one.  With loop, the last one
+// points back to the first sub-IFD instead.
+func subIFDChain(n int, loop bool) []byte {
+	le := binary.LittleEndian
+	data := []byte("II*\x00\x08\x00\x00\x00")
+	for i := 0; i < n; i++ {
+		next := uint32(8 + 18*(i+1))
+		if i == n-1 {
+			if !loop {
+				data = append(data, 0, 0, 0, 0, 0, 0)
+				break
+			}
+			next = 8 + 18
+		}
+		entry := make([]byte, 18)
+		le.PutUint16(entry, 1)
+		le.PutUint16(entry[2:], subIFDsTag)
+		le.PutUint16(entry[4:], uint16(DTLong))
+		le.PutUint32(entry[6:], 1)
+		le.PutUint32(entry[10:], next)
+		data = append(data, entry...)
+	}
+	return data
+}
+
+func TestDecodeSubIFDs(t *testing.T) {
+	tests := []struct {
+		n    int
+		loop bool
+		lim  SubIFDLimits
+		err  bool
+	}{
+		{4, false, SubIFDLimits{}, false},
+		{4, false, SubIFDLimits{MaxDepth: 3}, false},
+		{4, false, SubIFDLimits{MaxDepth: 2}, true},
+		{4, false, SubIFDLimits{MaxDirs: 2}, true},
+		{DefaultMaxSubIFDDepth + 2, false, SubIFDLimits{}, true},
+		{3, true, SubIFDLimits{}, true},
+	}
+	for i, test := range tests {
+		data := subIFDChain(test.n, test.loop)
+		tif, err := Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs, err := DecodeSubIFDs(bytes.NewReader(data), tif.Order, tif.Dirs[0], test.lim)
+		if test.err {
+			if err == nil {
+				t.Errorf("%d: no error", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%d: %v", i, err)
+			continue
+		}
+
+		depth := 0
+		for len(subs) == 1 {
+			depth++
+			if want := uint32(8 + 18*depth); subs[0].Offset != want {
+				t.Errorf("%d: sub-IFD %d at %d, want %d", i, depth, subs[0].Offset, want)
+			}
+			subs = subs[0].Subs
+		}
+		if len(subs) != 0 || depth != test.n-1 {
+			t.Errorf("%d: got %d nested sub-IFDs, want %d", i, depth, test.n-1)
+		}
+	}
+}
+
+func TestDecodeSubIFDsIFDType(t *testing.T) {
+	data := subIFDChain(3, false)
+	// Type the SubIFDs pointer of IFD0 as an IFD offset, as ERF and SRW
+	// files do.
+	binary.LittleEndian.PutUint16(data[12:], uint16(DTIFD))
+	tif, err := Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ptr := tif.Dirs[0].Tags[0]
+	if off, err := ptr.Int64(0); err != nil || off != 8+18 {
+		t.Errorf("pointer = %d, %v", off, err)
+	}
+	subs, err := DecodeSubIFDs(bytes.NewReader(data), tif.Order, tif.Dirs[0], SubIFDLimits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || len(subs[0].Subs) != 1 {
+		t.Errorf("got sub-IFDs %v", subs)
+	}
+}
@@ -3,11 +3,14 @@ package tiff
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 	"unicode/utf8"
 )
@@ -52,6 +55,15 @@ const (
 	DTSRational DataType = 10
 	DTFloat     DataType = 11
 	DTDouble    DataType = 12
+
+	// DTIFD is the IFD offset type of TIFF-EP and Adobe's TIFF Technote 1,
+	// an unsigned long used by some raw formats (e.g. Epson ERF and
+	// Samsung SRW) for SubIFDs and other sub-IFD pointers.
+	DTIFD DataType = 13
+
+	// DTUTF8 is the UTF-8 text type added by EXIF 3.0 for fields such as
+	// ImageDescription and Artist.  Values are NUL terminated like ASCII.
+	DTUTF8 DataType = 129
 )
 
 var typeNames = map[DataType]string{
@@ -67,6 +79,8 @@ var typeNames = map[DataType]string{
 	DTSRational: "signed rational",
 	DTFloat:     "float",
 	DTDouble:    "double",
+	DTIFD:       "ifd",
+	DTUTF8:      "utf-8",
 }
 
 // typeSize specifies the size in bytes of each type.
@@ -83,13 +97,22 @@ var typeSize = map[DataType]uint32{
 	DTSRational: 8,
 	DTFloat:     4,
 	DTDouble:    8,
+	DTIFD:       4,
+	DTUTF8:      1,
+}
+
+// Size returns the size in bytes of a value of type dt, or 0 if dt is not a
+// known type.
+func (dt DataType) Size() int {
+	return int(typeSize[dt])
 }
 
 // Tag reflects the parsed content of a tiff IFD tag.
 type Tag struct {
 	// Id is the 2-byte tiff tag identifier.
 	Id uint16
-	// Type is an integer (1 through 12) indicating the tag value's data type.
+	// Type is an integer (1 through 12, or 129) indicating the tag value's
+	// data type.
 	Type DataType
 	// Count is the number of type Type stored in the tag's value (i.e. the
 	// tag's value is an array of type Type and length Count).
@@ -178,7 +201,7 @@ func (t *Tag) convertVals() error {
 	r := bytes.NewReader(t.Val)
 
 	switch t.Type {
-	case DTAscii:
+	case DTAscii, DTUTF8:
 		if len(t.Val) <= 0 {
 			break
 		}
@@ -209,7 +232,7 @@ func (t *Tag) convertVals() error {
 			}
 			t.intVals[i] = int64(v)
 		}
-	case DTLong:
+	case DTLong, DTIFD:
 		var v uint32
 		t.intVals = make([]int64, int(t.Count))
 		for i := range t.intVals {
@@ -300,13 +323,13 @@ func (t *Tag) convertVals() error {
 	}
 
 	switch t.Type {
-	case DTByte, DTShort, DTLong, DTSByte, DTSShort, DTSLong:
+	case DTByte, DTShort, DTLong, DTSByte, DTSShort, DTSLong, DTIFD:
 		t.format = IntVal
 	case DTRational, DTSRational:
 		t.format = RatVal
 	case DTFloat, DTDouble:
 		t.format = FloatVal
-	case DTAscii:
+	case DTAscii, DTUTF8:
 		t.format = StringVal
 	case DTUndefined:
 		t.format = UndefVal
@@ -326,55 +349,65 @@ func (t *Tag) typeErr(to Format) error {
 }
 
 // Rat returns the tag's i'th value as a rational number. It returns a nil and
-// an error if this tag's Format is not RatVal.  It panics for zero deminators
-// or if i is out of range.
+// an error if this tag's Format is not RatVal, if i is out of range or if the
+// denominator is zero.
 func (t *Tag) Rat(i int) (*big.Rat, error) {
 	n, d, err := t.Rat2(i)
 	if err != nil {
 		return nil, err
 	}
+	if d == 0 {
+		return nil, errors.New("tiff: zero denominator")
+	}
 	return big.NewRat(n, d), nil
 }
 
 // Rat2 returns the tag's i'th value as a rational number represented by a
 // numerator-denominator pair. It returns an error if the tag's Format is not
-// RatVal. It panics if i is out of range.
+// RatVal or if i is out of range.
 func (t *Tag) Rat2(i int) (num, den int64, err error) {
 	if t.format != RatVal {
 		return 0, 0, t.typeErr(RatVal)
 	}
+	if i < 0 || i >= len(t.ratVals) {
+		return 0, 0, &IndexError{i, len(t.ratVals)}
+	}
 	return t.ratVals[i][0], t.ratVals[i][1], nil
 }
 
 // Int64 returns the tag's i'th value as an integer. It returns an error if the
-// tag's Format is not IntVal. It panics if i is out of range.
+// tag's Format is not IntVal or if i is out of range.
 func (t *Tag) Int64(i int) (int64, error) {
 	if t.format != IntVal {
 		return 0, t.typeErr(IntVal)
 	}
+	if i < 0 || i >= len(t.intVals) {
+		return 0, &IndexError{i, len(t.intVals)}
+	}
 	return t.intVals[i], nil
 }
 
 // Int returns the tag's i'th value as an integer. It returns an error if the
-// tag's Format is not IntVal. It panics if i is out of range.
+// tag's Format is not IntVal or if i is out of range.
 func (t *Tag) Int(i int) (int, error) {
-	if t.format != IntVal {
-		return 0, t.typeErr(IntVal)
-	}
-	return int(t.intVals[i]), nil
+	v, err := t.Int64(i)
+	return int(v), err
 }
 
 // Float returns the tag's i'th value as a float. It returns an error if the
-// tag's Format is not IntVal.  It panics if i is out of range.
+// tag's Format is not FloatVal or if i is out of range.
 func (t *Tag) Float(i int) (float64, error) {
 	if t.format != FloatVal {
 		return 0, t.typeErr(FloatVal)
 	}
+	if i < 0 || i >= len(t.floatVals) {
+		return 0, &IndexError{i, len(t.floatVals)}
+	}
 	return t.floatVals[i], nil
 }
 
 // StringVal returns the tag's value as a string. It returns an error if the
-// tag's Format is not StringVal. It panics if i is out of range.
+// tag's Format is not StringVal.
 func (t *Tag) StringVal() (string, error) {
 	if t.format != StringVal {
 		return "", t.typeErr(StringVal)
@@ -396,8 +429,104 @@ func (t *Tag) String() string {
 }
 
 func (t *Tag) MarshalJSON() ([]byte, error) {
+	return t.MarshalJSONStrings(StringsASCII)
+}
+
+// StringMode selects how ASCII and undefined values are rendered as JSON.
+type StringMode int
+
+const (
+	// StringsASCII drops the bytes of the value that are not printable
+	// characters when taken as Latin-1, yielding "" if what remains is not
+	// valid UTF-8.  This mangles UTF-8 text and is kept for compatibility:
+	// it is what MarshalJSON does.  Values of type DTUTF8 are rendered as
+	// by StringsUTF8.
+	StringsASCII StringMode = iota
+	// StringsUTF8 treats the value as UTF-8 text, dropping invalid bytes
+	// and non-printable characters.
+	StringsUTF8
+	// StringsBase64 renders values that are UTF-8 text as by StringsUTF8 and
+	// other (binary) values as a JSON object {"base64": "..."} holding the
+	// base64 encoding of the value.
+	StringsBase64
+)
+
+// MarshalJSONStrings is MarshalJSON rendering ASCII and undefined values as
+// selected by mode.
+func (t *Tag) MarshalJSONStrings(mode StringMode) ([]byte, error) {
+	return t.MarshalJSONOptions(JSONOptions{Strings: mode})
+}
+
+// RatMode selects how rational values are rendered.
+type RatMode int
+
+const (
+	// RatsFraction renders rationals as stored, e.g. "10/2500".
+	RatsFraction RatMode = iota
+	// RatsSimplified renders rationals reduced to lowest terms, e.g.
+	// "1/250", or as whole numbers where possible.
+	RatsSimplified
+	// RatsDecimal renders rationals as decimal numbers.
+	RatsDecimal
+)
+
+// JSONOptions control the JSON rendering of tags by MarshalJSONOptions.
+// The zero value renders tags like MarshalJSON.
+type JSONOptions struct {
+	Strings StringMode
+	Rats    RatMode
+	// Precision is the number of digits after the decimal point of
+	// rationals rendered with RatsDecimal.  Zero means as many as needed to
+	// represent the value exactly (as a float64).
+	Precision int
+}
+
+// RatString returns the tag's i'th value, a rational, rendered as selected
+// by mode and precision (see JSONOptions).  Values with a zero denominator
+// are always rendered as fractions.
+func (t *Tag) RatString(i int, mode RatMode, precision int) (string, error) {
+	num, den, err := t.Rat2(i)
+	if err != nil {
+		return "", err
+	}
+	if den == 0 {
+		mode = RatsFraction
+	}
+	switch mode {
+	case RatsSimplified:
+		r := big.NewRat(num, den)
+		if r.IsInt() {
+			return r.Num().String(), nil
+		}
+		return r.String(), nil
+	case RatsDecimal:
+		if precision <= 0 {
+			precision = -1
+		}
+		return strconv.FormatFloat(float64(num)/float64(den), 'f', precision, 64), nil
+	}
+	return fmt.Sprintf("%v/%v", num, den), nil
+}
+
+// MarshalJSONOptions is MarshalJSON rendering values as selected by o.
+// Rationals rendered with RatsDecimal are JSON numbers; other rationals
+// are JSON strings.
+func (t *Tag) MarshalJSONOptions(o JSONOptions) ([]byte, error) {
 	switch t.format {
 	case StringVal, UndefVal:
+		if t.Type == DTUTF8 && o.Strings == StringsASCII {
+			// The legacy rendering would mangle non-ASCII text.
+			return utf8String(t.Val), nil
+		}
+		switch o.Strings {
+		case StringsUTF8:
+			return utf8String(t.Val), nil
+		case StringsBase64:
+			if !isText(t.Val) {
+				return json.Marshal(map[string][]byte{"base64": t.Val})
+			}
+			return utf8String(t.Val), nil
+		}
 		return nullString(t.Val), nil
 	case OtherVal:
 		return []byte(fmt.Sprintf("unknown tag type '%v'", t.Type)), nil
@@ -407,8 +536,11 @@ func (t *Tag) MarshalJSON() ([]byte, error) {
 	for i := 0; i < int(t.Count); i++ {
 		switch t.format {
 		case RatVal:
-			n, d, _ := t.Rat2(i)
-			rv = append(rv, fmt.Sprintf(`"%v/%v"`, n, d))
+			r, _ := t.RatString(i, o.Rats, o.Precision)
+			if _, d, _ := t.Rat2(i); o.Rats != RatsDecimal || d == 0 {
+				r = strconv.Quote(r)
+			}
+			rv = append(rv, r)
 		case FloatVal:
 			v, _ := t.Float(i)
 			rv = append(rv, fmt.Sprintf("%v", v))
@@ -425,6 +557,9 @@ func nullString(in []byte) []byte {
 	rv.WriteByte('"')
 	for _, b := range in {
 		if unicode.IsPrint(rune(b)) {
+			if b == '"' || b == '\\' {
+				rv.WriteByte('\\')
+			}
 			rv.WriteByte(b)
 		}
 	}
@@ -436,6 +571,90 @@ func nullString(in []byte) []byte {
 	return []byte(`""`)
 }
 
+// utf8String returns in as a JSON string, dropping invalid UTF-8 and
+// non-printable characters.
+func utf8String(in []byte) []byte {
+	var s strings.Builder
+	for len(in) > 0 {
+		r, size := utf8.DecodeRune(in)
+		in = in[size:]
+		if r != utf8.RuneError && unicode.IsPrint(r) {
+			s.WriteRune(r)
+		}
+	}
+	b, _ := json.Marshal(s.String())
+	return b
+}
+
+// isText reports whether in is UTF-8 text, allowing NUL terminators and
+// separators and the usual whitespace control characters.
+func isText(in []byte) bool {
+	if !utf8.Valid(in) {
+		return false
+	}
+	for _, r := range string(in) {
+		if unicode.IsControl(r) && r != 0 && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// internMaxLen is the longest ASCII value an Interner shares.  Longer
+// values are rarely repeated.
+const internMaxLen = 256
+
+// Interner shares the values of identical ASCII tags (e.g. Make, Model or
+// Software) so that many decoded tags hold one copy of each distinct
+// string.  Tags sharing a value also share their Val slice, which must then
+// not be modified.  An Interner is safe for concurrent use.
+type Interner struct {
+	mu       sync.Mutex
+	vals     map[string]*Tag
+	interned int
+}
+
+// NewInterner returns an empty Interner.
+func NewInterner() *Interner {
+	return &Interner{vals: map[string]*Tag{}}
+}
+
+// Intern replaces the value of t, if it is an ASCII tag, with that of a
+// previously interned tag holding identical bytes.
+func (in *Interner) Intern(t *Tag) {
+	if t.Type != DTAscii || len(t.Val) == 0 || len(t.Val) > internMaxLen {
+		return
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if prev, ok := in.vals[string(t.Val)]; ok {
+		if &prev.Val[0] != &t.Val[0] {
+			t.Val, t.strVal = prev.Val, prev.strVal
+			in.interned++
+		}
+		return
+	}
+	in.vals[string(t.Val)] = &Tag{Val: t.Val, strVal: t.strVal}
+}
+
+// Stats returns the number of distinct values held by in and the number of
+// tags whose value was replaced by a shared one.
+func (in *Interner) Stats() (distinct, interned int) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return len(in.vals), in.interned
+}
+
+// IndexError is returned by the value accessors of a Tag for an index out
+// of the range of its values.
+type IndexError struct {
+	Index, Count int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("tiff: value index %d out of range [0,%d)", e.Index, e.Count)
+}
+
 type wrongFmtErr struct {
 	From, To string
 }
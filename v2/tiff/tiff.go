@@ -68,8 +68,14 @@ func Decode(r io.Reader) (*Tiff, error) {
 
 	// load IFD's
 	var d *Dir
-	prev := offset
+	seen := map[int32]bool{}
 	for offset != 0 {
+		// A crafted chain of IFDs may loop back to any earlier IFD.
+		if seen[offset] {
+			return nil, errors.New("tiff: recursive IFD")
+		}
+		seen[offset] = true
+
 		// seek to offset
 		_, err := buf.Seek(int64(offset), 0)
 		if err != nil {
@@ -86,11 +92,6 @@ func Decode(r io.Reader) (*Tiff, error) {
 			return nil, err
 		}
 
-		if offset == prev {
-			return nil, errors.New("tiff: recursive IFD")
-		}
-		prev = offset
-
 		t.Dirs = append(t.Dirs, d)
 	}
 
@@ -117,6 +118,27 @@ type Dir struct {
 // byte of the IFD. ReadAt offsets should generally be relative to the
 // beginning of the tiff structure (not relative to the beginning of the IFD).
 func DecodeDir(r ReadAtReader, order binary.ByteOrder) (d *Dir, offset int32, err error) {
+	return DecodeDirFunc(r, order, nil)
+}
+
+// entryReader reads an IFD entry from its own buffer while reading values
+// stored elsewhere from the tiff structure.
+type entryReader struct {
+	io.Reader
+	io.ReaderAt
+}
+
+// DecodeEntry is like DecodeTag, but reads the 12 byte IFD entry from entry
+// and the values stored outside of it from r, at the offset in the entry.
+// It decodes entries read or synthesized apart from their IFD.
+func DecodeEntry(entry []byte, r io.ReaderAt, order binary.ByteOrder) (*Tag, error) {
+	return DecodeTag(entryReader{bytes.NewReader(entry), r}, order)
+}
+
+// DecodeDirFunc is like DecodeDir, but leaves out the tags whose IDs keep
+// returns false for, without reading their values.  A nil keep keeps all
+// tags.
+func DecodeDirFunc(r ReadAtReader, order binary.ByteOrder, keep func(id uint16) bool) (d *Dir, offset int32, err error) {
 	d = new(Dir)
 
 	// get num of tags in ifd
@@ -128,7 +150,19 @@ func DecodeDir(r ReadAtReader, order binary.ByteOrder) (d *Dir, offset int32, er
 
 	// load tags
 	for n := 0; n < int(nTags); n++ {
-		t, err := DecodeTag(r, order)
+		var t *Tag
+		if keep == nil {
+			t, err = DecodeTag(r, order)
+		} else {
+			entry := make([]byte, 12)
+			if _, err := io.ReadFull(r, entry); err != nil {
+				return nil, 0, errors.New("tiff: tag id read failed: " + err.Error())
+			}
+			if !keep(order.Uint16(entry)) {
+				continue
+			}
+			t, err = DecodeEntry(entry, r, order)
+		}
 		if err != nil {
 			return nil, 0, err
 		}
@@ -236,6 +236,45 @@ func TestDecodeTag_blob(t *testing.T) {
 	t.Logf("tag rat val: %v/%v\n", n, d)
 }
 
+func TestTagIndexRange(t *testing.T) {
+	rat, err := NewTag(0x1A, DTRational, binary.LittleEndian, [2]int64{1, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := rat.Rat2(1); err == nil {
+		t.Error("Rat2(1) of a single value succeeded")
+	} else if ie, ok := err.(*IndexError); !ok || ie.Index != 1 || ie.Count != 1 {
+		t.Errorf("Rat2(1) error = %#v, want IndexError", err)
+	}
+	if _, err := rat.Rat(0); err == nil {
+		t.Error("Rat(0) with zero denominator succeeded")
+	}
+
+	short, err := NewTag(0x100, DTShort, binary.LittleEndian, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{-1, 1} {
+		if _, err := short.Int(i); err == nil {
+			t.Errorf("Int(%d) succeeded", i)
+		}
+		if _, err := short.Int64(i); err == nil {
+			t.Errorf("Int64(%d) succeeded", i)
+		}
+	}
+	if v, err := short.Int(0); v != 7 || err != nil {
+		t.Errorf("Int(0) = %d, %v", v, err)
+	}
+
+	dbl, err := NewTag(0x200, DTDouble, binary.LittleEndian, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbl.Float(3); err == nil {
+		t.Error("Float(3) succeeded")
+	}
+}
+
 func data() []byte {
 	s1 := "49492A000800000002001A0105000100"
 	s1 += "00002600000069870400010000001102"
@@ -247,3 +286,116 @@ func data() []byte {
 	}
 	return dat
 }
+
+func TestInterner(t *testing.T) {
+	ascii := func(s string) *Tag {
+		tg := &Tag{Type: DTAscii, Count: uint32(len(s) + 1), Val: append([]byte(s), 0)}
+		if err := tg.convertVals(); err != nil {
+			t.Fatal(err)
+		}
+		return tg
+	}
+
+	in := NewInterner()
+	a, b, c := ascii("Canon"), ascii("Canon"), ascii("Nikon")
+	for _, tg := range []*Tag{a, b, c, b} {
+		in.Intern(tg)
+	}
+	if &a.Val[0] != &b.Val[0] {
+		t.Errorf("identical values not shared")
+	}
+	if s, _ := b.StringVal(); s != "Canon" {
+		t.Errorf("interned string is %q, want %q", s, "Canon")
+	}
+	if distinct, interned := in.Stats(); distinct != 2 || interned != 1 {
+		t.Errorf("stats are %v distinct, %v interned; want 2, 1", distinct, interned)
+	}
+
+	short := &Tag{Type: DTShort, Count: 1, Val: []byte{1, 0}}
+	in.Intern(short)
+	if distinct, _ := in.Stats(); distinct != 2 {
+		t.Errorf("non-ASCII tag interned")
+	}
+}
+
+func TestDecodeDirFunc(t *testing.T) {
+	buf := bytes.NewReader(data())
+	buf.Seek(8, 0)
+	d, _, err := DecodeDirFunc(buf, binary.LittleEndian, func(id uint16) bool { return id == 0x8769 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Tags) != 1 || d.Tags[0].Id != 0x8769 {
+		t.Fatalf("got tags %v, want only 0x8769", d)
+	}
+	if v, err := d.Tags[0].Int(0); err != nil || v != 0x211 {
+		t.Errorf("tag value = %v, %v; want %v", v, err, 0x211)
+	}
+}
+
+func TestMarshalJSONStrings(t *testing.T) {
+	tag := func(typ DataType, val string) *Tag {
+		tg := &Tag{Type: typ, Count: uint32(len(val)), Val: []byte(val)}
+		if err := tg.convertVals(); err != nil {
+			t.Fatal(err)
+		}
+		return tg
+	}
+	tests := []struct {
+		tag                 *Tag
+		ascii, utf8, base64 string
+	}{
+		{tag(DTAscii, "Canon\x00"), `"Canon"`, `"Canon"`, `"Canon"`},
+		{tag(DTAscii, "上海市\x00"), `""`, `"上海市"`, `"上海市"`},
+		{tag(DTAscii, "say \"hi\" \\o/\x00"), `"say \"hi\" \\o/"`, `"say \"hi\" \\o/"`, `"say \"hi\" \\o/"`},
+		{tag(DTUndefined, "\x01\xff\x02"), `""`, `""`, `{"base64":"Af8C"}`},
+		{tag(DTUTF8, "上海市\x00"), `"上海市"`, `"上海市"`, `"上海市"`},
+	}
+	for i, tt := range tests {
+		for mode, want := range []string{tt.ascii, tt.utf8, tt.base64} {
+			got, err := tt.tag.MarshalJSONStrings(StringMode(mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want {
+				t.Errorf("test %d, mode %d: got %s, want %s", i, mode, got, want)
+			}
+		}
+	}
+}
+
+func TestMarshalJSONRats(t *testing.T) {
+	tg := &Tag{Type: DTRational, Count: 2, Val: []byte{10, 0, 0, 0, 196, 9, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0}}
+	tg.order = binary.LittleEndian
+	if err := tg.convertVals(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		o    JSONOptions
+		want string
+	}{
+		{JSONOptions{}, `["10/2500","5/0"]`},
+		{JSONOptions{Rats: RatsSimplified}, `["1/250","5/0"]`},
+		{JSONOptions{Rats: RatsDecimal}, `[0.004,"5/0"]`},
+		{JSONOptions{Rats: RatsDecimal, Precision: 2}, `[0.00,"5/0"]`},
+	}
+	for _, tt := range tests {
+		got, err := tg.MarshalJSONOptions(tt.o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%+v: got %s, want %s", tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeIFDLoop(t *testing.T) {
+	// IFD0 at 8 and IFD1 at 14 (no entries each) link back to IFD0.
+	data := []byte("II*\x00\x08\x00\x00\x00" +
+		"\x00\x00\x0e\x00\x00\x00" +
+		"\x00\x00\x08\x00\x00\x00")
+	if _, err := Decode(bytes.NewReader(data)); err == nil {
+		t.Fatal("no error decoding looping IFDs")
+	}
+}